@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hitsumabushi845/task-management/internal/domain"
+)
+
+// runAdd implements "task add": create a task from the command line
+func runAdd(args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	fs := flag.NewFlagSet("add", flag.ContinueOnError)
+	due := fs.String("due", "", `due date, e.g. 2026-11-03, tomorrow, fri, "next monday", +3d, 11/3, 明日`)
+	priority := fs.String("priority", "medium", "priority: low, medium or high")
+	category := fs.String("category", "", "category name")
+	desc := fs.String("desc", "", "description")
+	remind := fs.String("remind", "", `reminder time, e.g. +30m, 14:30, "fri 9:00"`)
+
+	rest, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+
+	task := &domain.Task{
+		Title:       strings.Join(rest, " "),
+		Description: *desc,
+		Status:      domain.TaskStatusNew,
+		Priority:    domain.Priority(*priority),
+	}
+
+	if *due != "" {
+		parsed, err := domain.ParseDueDateWeekStart(*due, time.Now(), time.Weekday(cfg.WeekStart))
+		if err != nil {
+			return err
+		}
+		task.DueDate = &parsed
+	}
+
+	if *remind != "" {
+		parsed, err := domain.ParseReminder(*remind, time.Now())
+		if err != nil {
+			return err
+		}
+		task.RemindAt = &parsed
+	}
+
+	repo, err := openRepository(cfg)
+	if err != nil {
+		return err
+	}
+	defer repo.Close()
+
+	ctx := context.Background()
+
+	if *category != "" {
+		categories, err := repo.GetCategories(ctx)
+		if err != nil {
+			return err
+		}
+		for _, cat := range categories {
+			if cat.Name == *category {
+				id := cat.ID
+				task.CategoryID = &id
+				break
+			}
+		}
+		if task.CategoryID == nil {
+			return fmt.Errorf("unknown category %q", *category)
+		}
+	}
+
+	if err := repo.Create(ctx, task); err != nil {
+		var verr *domain.ValidationError
+		if errors.As(err, &verr) && verr.Field == "priority" {
+			return fmt.Errorf("invalid priority %q: must be low, medium or high", *priority)
+		}
+		return err
+	}
+
+	fmt.Printf("Created task #%d: %s", task.ID, task.Title)
+	if task.DueDate != nil {
+		fmt.Printf(" (due %s)", task.DueDate.Format("Mon 2006-01-02"))
+	}
+	if task.RemindAt != nil {
+		fmt.Printf(" (remind %s)", task.RemindAt.Format("Mon 2006-01-02 15:04"))
+	}
+	fmt.Println()
+	return nil
+}
+
+// parseInterspersed parses flags that may appear before or after positional
+// arguments, e.g. `task add Buy milk --due fri`. Everything after a "--"
+// terminator is positional, e.g. `task add -- -p high`.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		rest := fs.Args()
+		if n := len(args) - len(rest); n > 0 && args[n-1] == "--" {
+			return append(positional, rest...), nil
+		}
+		if len(rest) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
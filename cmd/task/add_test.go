@@ -0,0 +1,50 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestParseInterspersed(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		wantTitle    []string
+		wantPriority string
+	}{
+		{"flags first", []string{"--priority", "high", "Buy", "milk"}, []string{"Buy", "milk"}, "high"},
+		{"flags last", []string{"Buy", "milk", "--priority", "low"}, []string{"Buy", "milk"}, "low"},
+		{"flags between", []string{"Buy", "--priority", "low", "milk"}, []string{"Buy", "milk"}, "low"},
+		{"terminator", []string{"--", "-p", "high"}, []string{"-p", "high"}, "medium"},
+		{"flag before terminator", []string{"--priority", "low", "--", "--priority", "high"}, []string{"--priority", "high"}, "low"},
+		{"title before terminator", []string{"Fix", "--", "-v", "flag"}, []string{"Fix", "-v", "flag"}, "medium"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("add", flag.ContinueOnError)
+			priority := fs.String("priority", "medium", "")
+			got, err := parseInterspersed(fs, tt.args)
+			if err != nil {
+				t.Fatalf("parseInterspersed() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.wantTitle) {
+				t.Errorf("positional = %q, want %q", got, tt.wantTitle)
+			}
+			if *priority != tt.wantPriority {
+				t.Errorf("priority = %q, want %q", *priority, tt.wantPriority)
+			}
+		})
+	}
+}
+
+func TestParseInterspersed_Help(t *testing.T) {
+	fs := flag.NewFlagSet("add", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	if _, err := parseInterspersed(fs, []string{"Buy", "-h"}); !errors.Is(err, flag.ErrHelp) {
+		t.Errorf("parseInterspersed(-h) error = %v, want flag.ErrHelp for main to exit quietly", err)
+	}
+}
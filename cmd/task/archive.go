@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/hitsumabushi845/task-management/internal/domain"
+)
+
+// runUnarchive implements "task unarchive [ID...]": bring archived tasks
+// back to the task list, or list the archived tasks when no ID is given
+func runUnarchive(args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	fs := flag.NewFlagSet("unarchive", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	var ids []int64
+	for _, arg := range fs.Args() {
+		id, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid task ID %q", arg)
+		}
+		ids = append(ids, id)
+	}
+
+	repo, err := openRepository(cfg)
+	if err != nil {
+		return err
+	}
+	defer repo.Close()
+
+	ctx := context.Background()
+	if len(ids) == 0 {
+		return listArchived(ctx, repo)
+	}
+	for _, id := range ids {
+		task, err := repo.GetByID(ctx, id)
+		if errors.Is(err, domain.ErrNotFound) {
+			return fmt.Errorf("no task with ID %d", id)
+		}
+		if err != nil {
+			return err
+		}
+		if task.ArchivedAt == nil {
+			fmt.Printf("Task #%d is not archived\n", task.ID)
+			continue
+		}
+		task.ArchivedAt = nil
+		if err := repo.Update(ctx, task); err != nil {
+			return err
+		}
+		fmt.Printf("Unarchived task #%d %s\n", task.ID, task.Title)
+	}
+	return nil
+}
+
+// listArchived prints the ID and title of every archived task
+func listArchived(ctx context.Context, repo domain.TaskRepository) error {
+	tasks, err := repo.List(ctx)
+	if err != nil {
+		return err
+	}
+	filter := domain.Filter{Archived: domain.ArchivedOnly}
+	archived := filter.Apply(tasks)
+	if len(archived) == 0 {
+		fmt.Println("No archived tasks")
+		return nil
+	}
+	for _, task := range archived {
+		fmt.Printf("#%d\t%s\t(archived %s)\n", task.ID, task.Title, task.ArchivedAt.Format("2006-01-02"))
+	}
+	return nil
+}
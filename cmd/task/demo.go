@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/hitsumabushi845/task-management/internal/domain"
+	"github.com/hitsumabushi845/task-management/internal/repository"
+)
+
+// runDemo implements "task --demo": run the UI on sample tasks kept in
+// memory, leaving the real database untouched
+func runDemo() error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	store := repository.NewMemoryRepository()
+	if err := seedDemo(context.Background(), store, time.Now()); err != nil {
+		return err
+	}
+
+	bus := repository.NewEventBus()
+	repo := repository.Chain(store, repository.WithEvents(bus))
+	defer repo.Close()
+
+	return runUI(repo, cfg, bus)
+}
+
+// seedDemo fills repo with tasks that show off each view: every status and
+// priority, overdue and upcoming due dates, a stale task and a reminder.
+// Each task was created before it was started.
+func seedDemo(ctx context.Context, repo domain.TaskRepository, now time.Time) error {
+	categories, err := repo.GetCategories(ctx)
+	if err != nil {
+		return err
+	}
+	category := func(i int) *int64 {
+		if i >= len(categories) {
+			return nil
+		}
+		return &categories[i].ID
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	day := func(offset int) *time.Time {
+		t := today.AddDate(0, 0, offset)
+		return &t
+	}
+	ago := func(d time.Duration) *time.Time {
+		t := now.Add(-d)
+		return &t
+	}
+	since := func(d time.Duration) time.Time {
+		return now.Add(-d)
+	}
+	in := func(d time.Duration) *time.Time {
+		t := now.Add(d)
+		return &t
+	}
+
+	tasks := []*domain.Task{
+		{Title: "Renew passport", Status: domain.TaskStatusNew, Priority: domain.PriorityHigh, CategoryID: category(1), DueDate: day(-2), CreatedAt: since(5 * 24 * time.Hour)},
+		{Title: "Prepare sprint review slides", Description: "Cover the API and webhook work", Status: domain.TaskStatusWorking, Priority: domain.PriorityHigh, CategoryID: category(0), DueDate: day(0), StartedAt: ago(3 * time.Hour), CreatedAt: since(2 * 24 * time.Hour)},
+		{Title: "Reply to design feedback", Status: domain.TaskStatusNew, Priority: domain.PriorityMedium, CategoryID: category(0), DueDate: day(1), RemindAt: in(time.Minute), CreatedAt: since(4 * time.Hour)},
+		{Title: "Book dentist appointment", Status: domain.TaskStatusNew, Priority: domain.PriorityLow, CategoryID: category(1), DueDate: day(4), CreatedAt: since(24 * time.Hour)},
+		{Title: "Migrate CI to the new runners", Description: "Blocked on credentials from infra", Status: domain.TaskStatusWorking, Priority: domain.PriorityMedium, CategoryID: category(0), StartedAt: ago(12 * 24 * time.Hour), CreatedAt: since(20 * 24 * time.Hour)},
+		{Title: "Write onboarding guide", Status: domain.TaskStatusWorking, Priority: domain.PriorityMedium, CategoryID: category(0), DueDate: day(9), StartedAt: ago(26 * time.Hour), CreatedAt: since(6 * 24 * time.Hour)},
+		{Title: "Plan weekend hike", Status: domain.TaskStatusNew, Priority: domain.PriorityLow, CategoryID: category(2), CreatedAt: since(3 * 24 * time.Hour)},
+		{Title: "Fix flaky login test", Status: domain.TaskStatusCompleted, Priority: domain.PriorityHigh, CategoryID: category(0), StartedAt: ago(3 * 24 * time.Hour), CompletedAt: ago(2 * 24 * time.Hour), CreatedAt: since(4 * 24 * time.Hour)},
+		{Title: "Pay electricity bill", Status: domain.TaskStatusCompleted, Priority: domain.PriorityMedium, CategoryID: category(1), DueDate: day(-1), StartedAt: ago(30 * time.Hour), CompletedAt: ago(28 * time.Hour), CreatedAt: since(8 * 24 * time.Hour)},
+		{Title: "Read \"Designing Data-Intensive Applications\"", Status: domain.TaskStatusNew, Priority: domain.PriorityLow, CategoryID: category(2), DueDate: day(30), CreatedAt: since(14 * 24 * time.Hour)},
+	}
+
+	// Restore keeps CreatedAt, which Create would set to now, after the
+	// tasks were started or completed
+	for _, task := range tasks {
+		if err := repo.Restore(ctx, task); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hitsumabushi845/task-management/internal/repository"
+)
+
+func TestSeedDemo_Chronology(t *testing.T) {
+	repo := repository.NewMemoryRepository()
+	ctx := context.Background()
+	now := time.Now()
+	if err := seedDemo(ctx, repo, now); err != nil {
+		t.Fatalf("seedDemo() error = %v", err)
+	}
+
+	tasks, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	for _, task := range tasks {
+		if task.CreatedAt.After(now) {
+			t.Errorf("%q created %v, after now", task.Title, task.CreatedAt)
+		}
+		if task.StartedAt != nil && task.StartedAt.Before(task.CreatedAt) {
+			t.Errorf("%q started %v before it was created %v", task.Title, *task.StartedAt, task.CreatedAt)
+		}
+		if task.CompletedAt != nil && task.StartedAt != nil && task.CompletedAt.Before(*task.StartedAt) {
+			t.Errorf("%q completed %v before it was started %v", task.Title, *task.CompletedAt, *task.StartedAt)
+		}
+	}
+}
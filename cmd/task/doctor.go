@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/hitsumabushi845/task-management/internal/attachment"
+	"github.com/hitsumabushi845/task-management/internal/repository"
+)
+
+// runDoctor implements "task doctor": report values the app cannot read
+// and copied attachments no task refers to any more and, with --fix,
+// repair them
+func runDoctor(args []string) error {
+	fs := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	fix := fs.Bool("fix", false, "apply the suggested repairs")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	// Open the database directly: hooks and webhooks do not apply to repairs
+	repo, err := openSQLite()
+	if err != nil {
+		return err
+	}
+	defer repo.Close()
+
+	ctx := context.Background()
+	problems, err := repo.Diagnose(ctx)
+	if err != nil {
+		return err
+	}
+	// Which files are referred to is only known once every row reads
+	var orphans []string
+	dir, err := attachmentDir()
+	if err != nil {
+		return err
+	}
+	if len(problems) == 0 {
+		if orphans, err = orphanedAttachments(ctx, repo, dir); err != nil {
+			return err
+		}
+	}
+	if len(problems) == 0 && len(orphans) == 0 {
+		fmt.Println("No problems found")
+		return nil
+	}
+
+	fmt.Printf("Found %d problem(s):\n", len(problems)+len(orphans))
+	for _, p := range problems {
+		fmt.Printf("  %s\n", p)
+	}
+	for _, path := range orphans {
+		fmt.Printf("  %s: copied attachment of no task; delete it\n", path)
+	}
+
+	if !*fix {
+		fmt.Println(`Run "task doctor --fix" to apply these repairs.`)
+		return nil
+	}
+	if err := repo.Repair(ctx, problems); err != nil {
+		return err
+	}
+	for _, path := range orphans {
+		if err := attachment.Discard(dir, path); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("Repaired %d problem(s)\n", len(problems)+len(orphans))
+	return nil
+}
+
+// orphanedAttachments lists the files in the attachment directory dir that
+// no task refers to, left behind by deleted tasks or removed attachments
+func orphanedAttachments(ctx context.Context, repo *repository.SQLiteRepository, dir string) ([]string, error) {
+	tasks, err := repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var locations []string
+	for _, task := range tasks {
+		for _, a := range task.Attachments {
+			locations = append(locations, a.Location)
+		}
+	}
+	return attachment.Orphans(dir, locations)
+}
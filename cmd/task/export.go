@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/hitsumabushi845/task-management/internal/api"
+)
+
+// runExport implements "task export": write every task, archived ones
+// included, with its checklist, attachments and notes, and the categories
+// as JSON
+func runExport(args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	output := fs.String("o", "", "write to this file instead of standard output")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected argument %q", fs.Arg(0))
+	}
+
+	repo, err := openRepository(cfg)
+	if err != nil {
+		return err
+	}
+	defer repo.Close()
+
+	ctx := context.Background()
+	tasks, err := repo.List(ctx)
+	if err != nil {
+		return err
+	}
+	categories, err := repo.GetCategories(ctx)
+	if err != nil {
+		return err
+	}
+	doc := api.Export{
+		ExportedAt: time.Now(),
+		Categories: api.FromCategories(categories),
+		Tasks:      api.FromTasks(tasks),
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	if *output != "" {
+		fmt.Fprintf(os.Stderr, "Exported %d tasks to %s\n", len(tasks), *output)
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hitsumabushi845/task-management/internal/api"
+	"github.com/hitsumabushi845/task-management/internal/config"
+	"github.com/hitsumabushi845/task-management/internal/domain"
+)
+
+// runImport implements "task import": read a file written by "task export"
+// and create its tasks with their checklists, attachments and notes.
+// Categories are matched by name and created when missing. The tasks get
+// new IDs but keep their creation, note and other times. Attached files
+// are referred to where they are, not copied again.
+func runImport(args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	replace := fs.Bool("replace", false, "delete all existing tasks first")
+	yes := fs.Bool("yes", false, "do not ask before deleting with --replace; required when FILE is - unless skip_confirm is set")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: task import [--replace] [--yes] FILE (- for standard input)")
+	}
+	if *replace && !*yes && !cfg.SkipConfirm && fs.Arg(0) == "-" {
+		// Standard input holds the export, so it cannot answer the question
+		return fmt.Errorf("--replace with standard input needs --yes or skip_confirm")
+	}
+
+	var r io.Reader = os.Stdin
+	if name := fs.Arg(0); name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	var doc api.Export
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return fmt.Errorf("read export: %w", err)
+	}
+
+	repo, err := openRepository(cfg)
+	if err != nil {
+		return err
+	}
+	defer repo.Close()
+
+	ctx := context.Background()
+	if *replace && !*yes {
+		existing, err := repo.List(ctx)
+		if err != nil {
+			return err
+		}
+		if len(existing) > 0 && !confirm(cfg, fmt.Sprintf("Delete all %d existing tasks before importing?", len(existing))) {
+			return fmt.Errorf("import cancelled")
+		}
+	}
+
+	err = repo.WithTx(ctx, func(tx domain.TaskRepository) error {
+		if *replace {
+			existing, err := tx.List(ctx)
+			if err != nil {
+				return err
+			}
+			for _, task := range existing {
+				if err := tx.Delete(ctx, task.ID); err != nil {
+					return err
+				}
+			}
+		}
+
+		categoryIDs, err := importCategories(ctx, tx, doc.Categories)
+		if err != nil {
+			return err
+		}
+		// Create the oldest first, as they were created originally
+		for i := len(doc.Tasks) - 1; i >= 0; i-- {
+			if err := importTask(ctx, tx, doc.Tasks[i], categoryIDs); err != nil {
+				return fmt.Errorf("task %d %q: %w", doc.Tasks[i].ID, doc.Tasks[i].Title, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Imported %d tasks\n", len(doc.Tasks))
+	return nil
+}
+
+// importCategories maps the exported category IDs to the IDs of categories
+// with the same names, creating those that do not exist yet
+func importCategories(ctx context.Context, repo domain.TaskRepository, categories []api.Category) (map[int64]int64, error) {
+	existing, err := repo.GetCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]int64, len(existing))
+	for _, c := range existing {
+		byName[c.Name] = c.ID
+	}
+
+	ids := make(map[int64]int64, len(categories))
+	for _, c := range categories {
+		id, ok := byName[c.Name]
+		if !ok {
+			created := &domain.Category{Name: c.Name, Color: c.Color}
+			if err := repo.CreateCategory(ctx, created); err != nil {
+				return nil, fmt.Errorf("category %q: %w", c.Name, err)
+			}
+			id = created.ID
+			byName[c.Name] = id
+		}
+		ids[c.ID] = id
+	}
+	return ids, nil
+}
+
+// importTask restores one exported task with its notes
+func importTask(ctx context.Context, repo domain.TaskRepository, in api.Task, categoryIDs map[int64]int64) error {
+	task := &domain.Task{
+		Title:       in.Title,
+		Description: in.Description,
+		Status:      in.Status,
+		Priority:    in.Priority,
+		CreatedAt:   in.CreatedAt,
+		StartedAt:   in.StartedAt,
+		CompletedAt: in.CompletedAt,
+		RemindAt:    in.RemindAt,
+		ArchivedAt:  in.ArchivedAt,
+	}
+	if in.CategoryID != nil {
+		id, ok := categoryIDs[*in.CategoryID]
+		if !ok {
+			return fmt.Errorf("category %d is not in the export", *in.CategoryID)
+		}
+		task.CategoryID = &id
+	}
+	if in.DueDate != nil {
+		due, err := domain.ParseDueDate(*in.DueDate, time.Now())
+		if err != nil {
+			return err
+		}
+		task.DueDate = &due
+	}
+	for _, item := range in.Checklist {
+		task.Checklist = append(task.Checklist, domain.ChecklistItem{Text: item.Text, Done: item.Done})
+	}
+	for _, a := range in.Attachments {
+		task.Attachments = append(task.Attachments, domain.Attachment{Location: a.Location, AddedAt: a.AddedAt})
+	}
+	for _, n := range in.Notes {
+		task.Notes = append(task.Notes, domain.Note{Text: n.Text, CreatedAt: n.CreatedAt})
+	}
+	return repo.Restore(ctx, task)
+}
+
+// confirm asks a yes/no question on the terminal, defaulting to no. Like
+// the TUI's confirmation dialog it agrees straight away when skip_confirm
+// is set in the config.
+func confirm(cfg config.Config, question string) bool {
+	if cfg.SkipConfirm {
+		return true
+	}
+	fmt.Printf("%s [y/N] ", question)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
@@ -1,41 +1,169 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/hitsumabushi845/task-management/internal/app"
+	"github.com/hitsumabushi845/task-management/internal/config"
+	"github.com/hitsumabushi845/task-management/internal/domain"
 	"github.com/hitsumabushi845/task-management/internal/repository"
 )
 
+const usage = `Usage:
+  task                    Launch the interactive UI
+  task --demo             Launch the UI on sample data held in memory
+  task add [flags] TITLE  Create a task (see "task add -h")
+  task remind [--watch]   Deliver due reminders (see "task remind -h")
+  task snooze ID [DUR]    Push a task's reminder forward
+  task unarchive [ID...]  Restore archived tasks, or list them without IDs
+  task serve [--addr A]   Serve the REST API (default 127.0.0.1:8080)
+  task rpc                Serve JSON-RPC / MCP tools on stdin and stdout
+  task doctor [--fix]     Find and repair corrupt rows in the database
+  task export [-o FILE]   Write all tasks, notes and categories as JSON
+  task import FILE        Create the tasks of an export (see "task import -h")
+`
+
 func main() {
-	// Get user's home directory
-	home, err := os.UserHomeDir()
+	args := os.Args[1:]
+	if len(args) > 0 {
+		var err error
+		switch args[0] {
+		case "add":
+			err = runAdd(args[1:])
+		case "remind":
+			err = runRemind(args[1:])
+		case "snooze":
+			err = runSnooze(args[1:])
+		case "unarchive":
+			err = runUnarchive(args[1:])
+		case "serve":
+			err = runServe(args[1:])
+		case "rpc":
+			err = runRPC(args[1:])
+		case "doctor":
+			err = runDoctor(args[1:])
+		case "export":
+			err = runExport(args[1:])
+		case "import":
+			err = runImport(args[1:])
+		case "--demo":
+			err = runDemo()
+		case "help", "-h", "--help":
+			fmt.Print(usage)
+			return
+		default:
+			fmt.Fprintf(os.Stderr, "Unknown command %q\n\n%s", args[0], usage)
+			os.Exit(2)
+		}
+		if errors.Is(err, flag.ErrHelp) {
+			return // The command printed its usage for -h
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n%s", err, repository.ErrorHint(err))
+			os.Exit(1)
+		}
+		return
+	}
+
+	cfg, err := loadConfig()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
 		os.Exit(1)
 	}
 
-	// Create data directory
-	dataDir := filepath.Join(home, ".task-management")
-	dbPath := filepath.Join(dataDir, "tasks.db")
-
-	// Create repository
-	repo, err := repository.NewSQLiteRepository(dbPath)
+	// Create repository, publishing changes to the UI
+	bus := repository.NewEventBus()
+	repo, err := openRepository(cfg, repository.WithEvents(bus))
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error creating repository: %v\n", err)
 		os.Exit(1)
 	}
 	defer repo.Close()
 
-	// Create and run application
-	model := app.New(repo)
-	p := tea.NewProgram(model)
+	// Reload when the CLI or another TUI changes the database
+	path, err := dbPath()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating repository: %v\n", err)
+		os.Exit(1)
+	}
+	watcher, err := repository.NewChangeWatcher(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error watching the database: %v\n", err)
+		os.Exit(1)
+	}
+	defer watcher.Close()
 
-	if _, err := p.Run(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error running application: %v\n", err)
+	attachments, err := attachmentDir()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating repository: %v\n", err)
+		os.Exit(1)
+	}
+	if err := runUI(repo, cfg, bus, app.WithChangeDetector(watcher), app.WithAttachmentDir(attachments)); err != nil {
+		fmt.Fprintf(os.Stderr, "Error running application: %v\n%s", err, repository.ErrorHint(err))
 		os.Exit(1)
 	}
 }
+
+// runUI runs the interactive UI until it quits. bus must be installed in
+// repo with repository.WithEvents.
+func runUI(repo domain.TaskRepository, cfg config.Config, bus *repository.EventBus, opts ...app.Option) error {
+	events, unsubscribe := bus.Subscribe(256)
+	defer unsubscribe()
+
+	opts = append([]app.Option{app.WithConfig(cfg), app.WithEvents(events)}, opts...)
+	model := app.New(repo, opts...)
+	_, err := tea.NewProgram(model).Run()
+	return err
+}
+
+// dataDir returns the directory holding the database and other user data
+func dataDir() (string, error) {
+	// Get user's home directory
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".task-management"), nil
+}
+
+// dbPath returns the path of the task database
+func dbPath() (string, error) {
+	dir, err := dataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "tasks.db"), nil
+}
+
+// openSQLite opens the SQLite repository in the data directory
+func openSQLite() (*repository.SQLiteRepository, error) {
+	path, err := dbPath()
+	if err != nil {
+		return nil, err
+	}
+	return repository.NewSQLiteRepository(path)
+}
+
+// attachmentDir returns the directory attached files are copied into,
+// next to the database
+func attachmentDir() (string, error) {
+	path, err := dbPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), "attachments"), nil
+}
+
+// loadConfig reads config.json from the data directory
+func loadConfig() (config.Config, error) {
+	dir, err := dataDir()
+	if err != nil {
+		return config.Config{}, err
+	}
+	return config.Load(filepath.Join(dir, "config.json"))
+}
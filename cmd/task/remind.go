@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/hitsumabushi845/task-management/internal/domain"
+	"github.com/hitsumabushi845/task-management/internal/reminder"
+)
+
+// runRemind implements "task remind": deliver due reminders once, or keep
+// checking in the background with --watch
+func runRemind(args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	fs := flag.NewFlagSet("remind", flag.ContinueOnError)
+	watch := fs.Bool("watch", false, "keep running and check for due reminders every interval")
+	interval := fs.Duration("interval", time.Minute, "how often to check in --watch mode")
+	command := fs.String("command", cfg.NotifyCommand, `notification command, e.g. "notify-send" (default: print to stdout)`)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	repo, err := openRepository(cfg)
+	if err != nil {
+		return err
+	}
+	defer repo.Close()
+
+	notifier := reminder.NewNotifier(*command, os.Stdout)
+
+	if !*watch {
+		_, err := reminder.Check(context.Background(), repo, notifier, time.Now())
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return reminder.Watch(ctx, repo, notifier, *interval, os.Stderr)
+}
+
+// runSnooze implements "task snooze ID [DURATION]": push a task's reminder forward
+func runSnooze(args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	fs := flag.NewFlagSet("snooze", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() < 1 || fs.NArg() > 2 {
+		return fmt.Errorf("usage: task snooze ID [DURATION]")
+	}
+
+	id, err := strconv.ParseInt(fs.Arg(0), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid task ID %q", fs.Arg(0))
+	}
+	d := time.Duration(cfg.SnoozeDuration)
+	if fs.NArg() == 2 {
+		d, err = time.ParseDuration(fs.Arg(1))
+		if err != nil {
+			return err
+		}
+	}
+
+	repo, err := openRepository(cfg)
+	if err != nil {
+		return err
+	}
+	defer repo.Close()
+
+	ctx := context.Background()
+	task, err := repo.GetByID(ctx, id)
+	if errors.Is(err, domain.ErrNotFound) {
+		return fmt.Errorf("no task with ID %d", id)
+	}
+	if err != nil {
+		return err
+	}
+	task.Snooze(d, time.Now())
+	if err := repo.Update(ctx, task); err != nil {
+		return err
+	}
+
+	fmt.Printf("Snoozed task #%d until %s\n", task.ID, task.RemindAt.Format("Mon 2006-01-02 15:04"))
+	return nil
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hitsumabushi845/task-management/internal/config"
+	"github.com/hitsumabushi845/task-management/internal/domain"
+	"github.com/hitsumabushi845/task-management/internal/hooks"
+	"github.com/hitsumabushi845/task-management/internal/repository"
+	"github.com/hitsumabushi845/task-management/internal/webhook"
+)
+
+const (
+	// webhookInterval is how often queued webhooks are retried while a
+	// command runs
+	webhookInterval = 5 * time.Second
+
+	// webhookFlushTimeout bounds the final delivery attempt on exit
+	webhookFlushTimeout = 5 * time.Second
+)
+
+// openRepository opens the task repository used by every command, wrapped
+// with the configured middleware, innermost last:
+//
+//   - logging to cfg.LogFile, when set
+//   - hook scripts in the hooks directory, so vetoed changes go no further
+//   - extra
+//   - webhook deliveries, when URLs are configured, queued in the same
+//     transaction as the change
+//
+// Queued webhooks are sent in the background; anything still undelivered on
+// Close stays queued for the next command.
+func openRepository(cfg config.Config, extra ...repository.Middleware) (domain.TaskRepository, error) {
+	dir, err := dataDir()
+	if err != nil {
+		return nil, err
+	}
+	store, err := openSQLite()
+	if err != nil {
+		return nil, err
+	}
+
+	var mws []repository.Middleware
+	repo := &closingRepository{}
+	logger := slog.New(slog.DiscardHandler)
+
+	if cfg.LogFile != "" {
+		path := cfg.LogFile
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+		if err != nil {
+			store.Close()
+			return nil, err
+		}
+		logger = slog.New(slog.NewJSONHandler(f, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		mws = append(mws, repository.WithLogging(logger))
+		// Errors reported after the fact, such as events that fail to
+		// publish once committed, go to the same file
+		slog.SetDefault(logger)
+		repo.logFile = f
+	}
+
+	mws = append(mws, hooks.Middleware(&hooks.Runner{Dir: filepath.Join(dir, "hooks")}))
+
+	mws = append(mws, extra...)
+	if len(cfg.WebhookURLs) > 0 {
+		mws = append(mws, webhook.Middleware(cfg.WebhookURLs))
+		repo.stop = startDispatcher(store, cfg.WebhookSecret, logger)
+	}
+
+	repo.TaskRepository = repository.Chain(store, mws...)
+	return repo, nil
+}
+
+// startDispatcher sends queued webhooks in the background, logging
+// failures to logger. The returned function stops it after one last attempt
+// at anything still pending.
+func startDispatcher(queue webhook.Queue, secret string, logger *slog.Logger) func() {
+	dispatcher := &webhook.Dispatcher{Queue: queue, Secret: secret}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		dispatcher.Run(ctx, webhookInterval, logger)
+		close(done)
+	}()
+
+	return func() {
+		cancel()
+		<-done
+		flushCtx, cancelFlush := context.WithTimeout(context.Background(), webhookFlushTimeout)
+		defer cancelFlush()
+		dispatcher.Flush(flushCtx)
+	}
+}
+
+// closingRepository stops the webhook dispatcher before closing the
+// database and closes the log file after it
+type closingRepository struct {
+	domain.TaskRepository
+	stop    func()
+	logFile *os.File
+}
+
+// Close sends pending webhooks one last time and closes the database
+func (r *closingRepository) Close() error {
+	if r.stop != nil {
+		r.stop()
+	}
+	err := r.TaskRepository.Close()
+	if r.logFile != nil {
+		r.logFile.Close()
+	}
+	return err
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/hitsumabushi845/task-management/internal/rpc"
+)
+
+// runRPC implements "task rpc": serve JSON-RPC (and MCP tools) on stdin/stdout
+func runRPC(args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	fs := flag.NewFlagSet("rpc", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	repo, err := openRepository(cfg)
+	if err != nil {
+		return err
+	}
+	defer repo.Close()
+
+	// An interrupt stops Serve cleanly even while it waits on stdin
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	srv := rpc.New(repo, rpc.WithWeekStart(time.Weekday(cfg.WeekStart)))
+	return srv.Serve(ctx, os.Stdin, os.Stdout)
+}
@@ -0,0 +1,267 @@
+// Package api defines the JSON representation of tasks and categories shared
+// by the HTTP and RPC servers.
+package api
+
+import (
+	"time"
+
+	"github.com/hitsumabushi845/task-management/internal/domain"
+)
+
+// DateLayout is the format of due dates in requests and responses
+const DateLayout = "2006-01-02"
+
+// Task is the JSON form of domain.Task
+type Task struct {
+	ID          int64             `json:"id"`
+	Title       string            `json:"title"`
+	Description string            `json:"description"`
+	Status      domain.TaskStatus `json:"status"`
+	Priority    domain.Priority   `json:"priority"`
+	CategoryID  *int64            `json:"category_id"`
+	DueDate     *string           `json:"due_date"` // YYYY-MM-DD
+	CreatedAt   time.Time         `json:"created_at"`
+	StartedAt   *time.Time        `json:"started_at"`
+	CompletedAt *time.Time        `json:"completed_at"`
+	RemindAt    *time.Time        `json:"remind_at"`
+	ArchivedAt  *time.Time        `json:"archived_at"`
+	Checklist   []ChecklistItem   `json:"checklist"`
+	Attachments []Attachment      `json:"attachments"`
+	Notes       []Note            `json:"notes"` // Oldest first; added through the notes endpoint, not TaskInput
+	Version     int64             `json:"version"`
+}
+
+// ChecklistItem is the JSON form of domain.ChecklistItem
+type ChecklistItem struct {
+	Text string `json:"text"`
+	Done bool   `json:"done"`
+}
+
+// Attachment is the JSON form of domain.Attachment. In input, a missing
+// added_at means now.
+type Attachment struct {
+	Location string    `json:"location"`
+	AddedAt  time.Time `json:"added_at"`
+}
+
+// Note is the JSON form of domain.Note
+type Note struct {
+	ID        int64     `json:"id"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NoteInput is the body of an add note request
+type NoteInput struct {
+	Text string `json:"text"`
+}
+
+// FromNotes converts a list of domain notes, never returning nil
+func FromNotes(notes []*domain.Note) []Note {
+	out := make([]Note, 0, len(notes))
+	for _, n := range notes {
+		out = append(out, Note{ID: n.ID, Text: n.Text, CreatedAt: n.CreatedAt})
+	}
+	return out
+}
+
+// FromTask converts a domain task to its JSON form
+func FromTask(t *domain.Task) Task {
+	out := Task{
+		ID:          t.ID,
+		Title:       t.Title,
+		Description: t.Description,
+		Status:      t.Status,
+		Priority:    t.Priority,
+		CategoryID:  t.CategoryID,
+		CreatedAt:   t.CreatedAt,
+		StartedAt:   t.StartedAt,
+		CompletedAt: t.CompletedAt,
+		RemindAt:    t.RemindAt,
+		ArchivedAt:  t.ArchivedAt,
+		Checklist:   make([]ChecklistItem, 0, len(t.Checklist)),
+		Attachments: make([]Attachment, 0, len(t.Attachments)),
+		Notes:       make([]Note, 0, len(t.Notes)),
+		Version:     t.Version,
+	}
+	for _, item := range t.Checklist {
+		out.Checklist = append(out.Checklist, ChecklistItem{Text: item.Text, Done: item.Done})
+	}
+	for _, a := range t.Attachments {
+		out.Attachments = append(out.Attachments, Attachment{Location: a.Location, AddedAt: a.AddedAt})
+	}
+	for _, n := range t.Notes {
+		out.Notes = append(out.Notes, Note{ID: n.ID, Text: n.Text, CreatedAt: n.CreatedAt})
+	}
+	if t.DueDate != nil {
+		due := t.DueDate.Format(DateLayout)
+		out.DueDate = &due
+	}
+	return out
+}
+
+// FromTasks converts a list of domain tasks, never returning nil so the
+// result encodes as [] rather than null
+func FromTasks(tasks []*domain.Task) []Task {
+	out := make([]Task, 0, len(tasks))
+	for _, t := range tasks {
+		out = append(out, FromTask(t))
+	}
+	return out
+}
+
+// Category is the JSON form of domain.Category
+type Category struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Color     string    `json:"color"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// FromCategories converts a list of domain categories
+func FromCategories(categories []*domain.Category) []Category {
+	out := make([]Category, 0, len(categories))
+	for _, c := range categories {
+		out = append(out, Category{ID: c.ID, Name: c.Name, Color: c.Color, CreatedAt: c.CreatedAt})
+	}
+	return out
+}
+
+// Export is the document written by "task export" and read by "task
+// import": every task, archived ones included, with its checklist,
+// attachments and notes, and the categories they refer to
+type Export struct {
+	ExportedAt time.Time  `json:"exported_at"`
+	Categories []Category `json:"categories"`
+	Tasks      []Task     `json:"tasks"`
+}
+
+// CategoryInput is the body of a create category request
+type CategoryInput struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// TaskInput is the body of a create or update request. Omitted fields are
+// left unchanged on update.
+type TaskInput struct {
+	Title       *string            `json:"title"`
+	Description *string            `json:"description"`
+	Status      *domain.TaskStatus `json:"status"`
+	Priority    *domain.Priority   `json:"priority"`
+	CategoryID  *int64             `json:"category_id"` // 0 removes the category
+	DueDate     *string            `json:"due_date"`    // Any form domain.ParseDueDate accepts; "" clears
+	RemindAt    *string            `json:"remind_at"`   // Any form domain.ParseReminder accepts; "" clears
+	Checklist   *[]ChecklistItem   `json:"checklist"`   // Replaces the whole checklist; [] clears
+	Attachments *[]Attachment      `json:"attachments"` // Replaces all attachments; [] removes them
+	Archived    *bool              `json:"archived"`    // true archives the task, false unarchives it
+	Version     *int64             `json:"version"`     // Update only: the version last read; the update conflicts if the task has changed since
+}
+
+// NewTask returns a task with the defaults used by the TUI and CLI
+func NewTask() *domain.Task {
+	return &domain.Task{Status: domain.TaskStatusNew, Priority: domain.PriorityMedium}
+}
+
+// Apply copies the set fields onto task. Date expressions are resolved
+// relative to now. Status changes go through Task.SetStatus so timestamps
+// stay consistent.
+func (in TaskInput) Apply(task *domain.Task, now time.Time, weekStart time.Weekday) error {
+	if in.Title != nil {
+		task.Title = *in.Title
+	}
+	if in.Description != nil {
+		task.Description = *in.Description
+	}
+	if in.Priority != nil {
+		task.Priority = *in.Priority
+	}
+	if in.CategoryID != nil {
+		if *in.CategoryID == 0 {
+			task.CategoryID = nil
+		} else {
+			id := *in.CategoryID
+			task.CategoryID = &id
+		}
+	}
+	if in.DueDate != nil {
+		if *in.DueDate == "" {
+			task.DueDate = nil
+		} else {
+			due, err := domain.ParseDueDateWeekStart(*in.DueDate, now, weekStart)
+			if err != nil {
+				return fieldError("due_date", err)
+			}
+			task.DueDate = &due
+		}
+	}
+	if in.RemindAt != nil {
+		if *in.RemindAt == "" {
+			task.RemindAt = nil
+		} else {
+			at, err := domain.ParseReminder(*in.RemindAt, now)
+			if err != nil {
+				return fieldError("remind_at", err)
+			}
+			task.RemindAt = &at
+		}
+	}
+	if in.Checklist != nil {
+		task.Checklist = nil
+		for _, item := range *in.Checklist {
+			task.Checklist = append(task.Checklist, domain.ChecklistItem{Text: item.Text, Done: item.Done})
+		}
+	}
+	if in.Attachments != nil {
+		task.Attachments = nil
+		for _, a := range *in.Attachments {
+			if a.AddedAt.IsZero() {
+				a.AddedAt = now
+			}
+			task.Attachments = append(task.Attachments, domain.Attachment{Location: a.Location, AddedAt: a.AddedAt})
+		}
+	}
+	if in.Archived != nil {
+		if !*in.Archived {
+			task.ArchivedAt = nil
+		} else if task.ArchivedAt == nil {
+			task.ArchivedAt = &now
+		}
+	}
+	if in.Status != nil {
+		if err := task.SetStatus(*in.Status, now); err != nil {
+			return err
+		}
+	}
+	if in.Version != nil {
+		task.Version = *in.Version
+	}
+	return nil
+}
+
+// fieldError reports an unparsable input field as a validation error
+func fieldError(field string, err error) error {
+	return &domain.ValidationError{Field: field, Message: field + ": " + err.Error()}
+}
+
+// Error codes reported in ErrorBody.Code
+const (
+	CodeBadRequest       = "bad_request"
+	CodeValidationFailed = "validation_failed"
+	CodeNotFound         = "not_found"
+	CodeConflict         = "conflict" // e.g. a duplicate category name or a stale version
+	CodeRejected         = "rejected" // Vetoed by a hook script
+	CodeInternal         = "internal"
+)
+
+// ErrorBody describes a failed request
+type ErrorBody struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Field   string `json:"field,omitempty"` // The invalid field, for validation_failed
+}
+
+// ErrorResponse is the envelope of every error response
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+}
@@ -0,0 +1,218 @@
+package api
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hitsumabushi845/task-management/internal/domain"
+)
+
+func TestTaskInput_Apply(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, time.Local)
+	earlier := now.Add(-time.Hour)
+	due := time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local)
+	category := int64(3)
+
+	str := func(s string) *string { return &s }
+	id := func(n int64) *int64 { return &n }
+	flag := func(b bool) *bool { return &b }
+	status := func(s domain.TaskStatus) *domain.TaskStatus { return &s }
+
+	tests := []struct {
+		name      string
+		task      domain.Task
+		in        TaskInput
+		wantField string // Set when Apply must fail on this field
+		check     func(t *testing.T, task *domain.Task)
+	}{
+		{
+			name: "empty input changes nothing",
+			task: domain.Task{Title: "Keep", CategoryID: &category, DueDate: &due, Version: 2},
+			in:   TaskInput{},
+			check: func(t *testing.T, task *domain.Task) {
+				if task.Title != "Keep" || task.CategoryID == nil || task.DueDate == nil || task.Version != 2 {
+					t.Errorf("task = %+v, want it unchanged", task)
+				}
+			},
+		},
+		{
+			name: "title and description",
+			in:   TaskInput{Title: str("Write"), Description: str("Draft first")},
+			check: func(t *testing.T, task *domain.Task) {
+				if task.Title != "Write" || task.Description != "Draft first" {
+					t.Errorf("Title, Description = %q, %q", task.Title, task.Description)
+				}
+			},
+		},
+		{
+			name: "category 0 removes the category",
+			task: domain.Task{CategoryID: &category},
+			in:   TaskInput{CategoryID: id(0)},
+			check: func(t *testing.T, task *domain.Task) {
+				if task.CategoryID != nil {
+					t.Errorf("CategoryID = %d, want nil", *task.CategoryID)
+				}
+			},
+		},
+		{
+			name: "category is copied",
+			in:   TaskInput{CategoryID: id(5)},
+			check: func(t *testing.T, task *domain.Task) {
+				if task.CategoryID == nil || *task.CategoryID != 5 {
+					t.Errorf("CategoryID = %v, want 5", task.CategoryID)
+				}
+			},
+		},
+		{
+			name: "due date expression is resolved relative to now",
+			in:   TaskInput{DueDate: str("tomorrow")},
+			check: func(t *testing.T, task *domain.Task) {
+				if task.DueDate == nil || task.DueDate.Format(DateLayout) != "2026-10-15" {
+					t.Errorf("DueDate = %v, want 2026-10-15", task.DueDate)
+				}
+			},
+		},
+		{
+			name: "empty due date clears it",
+			task: domain.Task{DueDate: &due},
+			in:   TaskInput{DueDate: str("")},
+			check: func(t *testing.T, task *domain.Task) {
+				if task.DueDate != nil {
+					t.Errorf("DueDate = %v, want nil", task.DueDate)
+				}
+			},
+		},
+		{
+			name:      "invalid due date",
+			in:        TaskInput{DueDate: str("someday")},
+			wantField: "due_date",
+		},
+		{
+			name: "reminder offset",
+			in:   TaskInput{RemindAt: str("+30m")},
+			check: func(t *testing.T, task *domain.Task) {
+				if task.RemindAt == nil || !task.RemindAt.Equal(now.Add(30*time.Minute)) {
+					t.Errorf("RemindAt = %v, want %v", task.RemindAt, now.Add(30*time.Minute))
+				}
+			},
+		},
+		{
+			name: "empty reminder clears it",
+			task: domain.Task{RemindAt: &earlier},
+			in:   TaskInput{RemindAt: str("")},
+			check: func(t *testing.T, task *domain.Task) {
+				if task.RemindAt != nil {
+					t.Errorf("RemindAt = %v, want nil", task.RemindAt)
+				}
+			},
+		},
+		{
+			name:      "invalid reminder",
+			in:        TaskInput{RemindAt: str("later")},
+			wantField: "remind_at",
+		},
+		{
+			name: "checklist replaces the old one",
+			task: domain.Task{Checklist: []domain.ChecklistItem{{Text: "Old"}}},
+			in:   TaskInput{Checklist: &[]ChecklistItem{{Text: "Tag", Done: true}, {Text: "Publish"}}},
+			check: func(t *testing.T, task *domain.Task) {
+				if len(task.Checklist) != 2 || task.Checklist[0].Text != "Tag" || !task.Checklist[0].Done {
+					t.Errorf("Checklist = %+v, want Tag (done) and Publish", task.Checklist)
+				}
+			},
+		},
+		{
+			name: "empty checklist clears it",
+			task: domain.Task{Checklist: []domain.ChecklistItem{{Text: "Old"}}},
+			in:   TaskInput{Checklist: &[]ChecklistItem{}},
+			check: func(t *testing.T, task *domain.Task) {
+				if len(task.Checklist) != 0 {
+					t.Errorf("Checklist = %+v, want none", task.Checklist)
+				}
+			},
+		},
+		{
+			name: "attachment without added_at is added now",
+			in:   TaskInput{Attachments: &[]Attachment{{Location: "https://example.com"}, {Location: "spec.pdf", AddedAt: earlier}}},
+			check: func(t *testing.T, task *domain.Task) {
+				if len(task.Attachments) != 2 || !task.Attachments[0].AddedAt.Equal(now) || !task.Attachments[1].AddedAt.Equal(earlier) {
+					t.Errorf("Attachments = %+v, want added now and an hour ago", task.Attachments)
+				}
+			},
+		},
+		{
+			name: "archive",
+			in:   TaskInput{Archived: flag(true)},
+			check: func(t *testing.T, task *domain.Task) {
+				if task.ArchivedAt == nil || !task.ArchivedAt.Equal(now) {
+					t.Errorf("ArchivedAt = %v, want %v", task.ArchivedAt, now)
+				}
+			},
+		},
+		{
+			name: "archiving again keeps the time",
+			task: domain.Task{ArchivedAt: &earlier},
+			in:   TaskInput{Archived: flag(true)},
+			check: func(t *testing.T, task *domain.Task) {
+				if task.ArchivedAt == nil || !task.ArchivedAt.Equal(earlier) {
+					t.Errorf("ArchivedAt = %v, want %v", task.ArchivedAt, earlier)
+				}
+			},
+		},
+		{
+			name: "unarchive",
+			task: domain.Task{ArchivedAt: &earlier},
+			in:   TaskInput{Archived: flag(false)},
+			check: func(t *testing.T, task *domain.Task) {
+				if task.ArchivedAt != nil {
+					t.Errorf("ArchivedAt = %v, want nil", task.ArchivedAt)
+				}
+			},
+		},
+		{
+			name: "status sets timestamps",
+			task: domain.Task{Status: domain.TaskStatusNew},
+			in:   TaskInput{Status: status(domain.TaskStatusCompleted)},
+			check: func(t *testing.T, task *domain.Task) {
+				if task.Status != domain.TaskStatusCompleted || task.CompletedAt == nil || !task.CompletedAt.Equal(now) {
+					t.Errorf("Status %s completed %v, want completed now", task.Status, task.CompletedAt)
+				}
+			},
+		},
+		{
+			name:      "invalid status",
+			task:      domain.Task{Status: domain.TaskStatusNew},
+			in:        TaskInput{Status: status("done")},
+			wantField: "status",
+		},
+		{
+			name: "version",
+			task: domain.Task{Version: 4},
+			in:   TaskInput{Version: id(3)},
+			check: func(t *testing.T, task *domain.Task) {
+				if task.Version != 3 {
+					t.Errorf("Version = %d, want 3", task.Version)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := tt.task
+			err := tt.in.Apply(&task, now, time.Monday)
+			if tt.wantField != "" {
+				var verr *domain.ValidationError
+				if !errors.As(err, &verr) || verr.Field != tt.wantField {
+					t.Fatalf("Apply() error = %v, want a validation error on %s", err, tt.wantField)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			tt.check(t, &task)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hitsumabushi845/task-management/internal/domain"
+)
+
+// ListParams selects and orders tasks in a list request. The zero value
+// lists every task that is not archived, newest first.
+type ListParams struct {
+	Statuses   []string `json:"status,omitempty"`
+	Priorities []string `json:"priority,omitempty"`
+	Categories []int64  `json:"category,omitempty"`
+	Search     string   `json:"q,omitempty"`
+	Range      string   `json:"range,omitempty"`    // One of the keys of dateRanges
+	Field      string   `json:"field,omitempty"`    // due, created or completed
+	Days       int      `json:"days,omitempty"`     // Length of the next_days range
+	From       string   `json:"from,omitempty"`     // Start of the custom range
+	To         string   `json:"to,omitempty"`       // End (inclusive) of the custom range
+	Sort       string   `json:"sort,omitempty"`     // A domain.SortBy name such as due_date
+	Order      string   `json:"order,omitempty"`    // asc or desc
+	Archived   string   `json:"archived,omitempty"` // exclude (the default), include or only
+}
+
+var dateRanges = map[string]domain.DateRange{
+	"":           domain.DateRangeAll,
+	"all":        domain.DateRangeAll,
+	"today":      domain.DateRangeToday,
+	"this_week":  domain.DateRangeThisWeek,
+	"overdue":    domain.DateRangeOverdue,
+	"no_date":    domain.DateRangeNoDueDate,
+	"this_month": domain.DateRangeThisMonth,
+	"next_days":  domain.DateRangeNextDays,
+	"custom":     domain.DateRangeCustom,
+}
+
+var archivedMatches = map[string]domain.ArchivedMatch{
+	"":        domain.ArchivedExclude,
+	"exclude": domain.ArchivedExclude,
+	"include": domain.ArchivedAny,
+	"only":    domain.ArchivedOnly,
+}
+
+var dateFields = map[string]domain.DateField{
+	"":          domain.DateFieldDue,
+	"due":       domain.DateFieldDue,
+	"created":   domain.DateFieldCreated,
+	"completed": domain.DateFieldCompleted,
+}
+
+// Filter converts the parameters to a domain filter and sort, resolving
+// custom range dates relative to now
+func (p ListParams) Filter(now time.Time, weekStart time.Weekday) (domain.Filter, domain.Sort, error) {
+	filter := domain.Filter{
+		Categories: p.Categories,
+		SearchText: p.Search,
+		NextDays:   p.Days,
+		WeekStart:  weekStart,
+	}
+	sort := domain.Sort{By: domain.SortByCreatedAt}
+
+	for _, s := range p.Statuses {
+		status := domain.TaskStatus(s)
+		if !status.IsValid() {
+			return filter, sort, fmt.Errorf("invalid status %q", s)
+		}
+		filter.Statuses = append(filter.Statuses, status)
+	}
+	for _, s := range p.Priorities {
+		priority := domain.Priority(s)
+		if !priority.IsValid() {
+			return filter, sort, fmt.Errorf("invalid priority %q", s)
+		}
+		filter.Priorities = append(filter.Priorities, priority)
+	}
+
+	var ok bool
+	if filter.DateRange, ok = dateRanges[p.Range]; !ok {
+		return filter, sort, fmt.Errorf("invalid range %q", p.Range)
+	}
+	if filter.DateField, ok = dateFields[p.Field]; !ok {
+		return filter, sort, fmt.Errorf("invalid field %q", p.Field)
+	}
+	if filter.Archived, ok = archivedMatches[p.Archived]; !ok {
+		return filter, sort, fmt.Errorf("invalid archived %q", p.Archived)
+	}
+	if filter.DateRange == domain.DateRangeNoDueDate && !filter.DateField.CanBeUnset() {
+		return filter, sort, fmt.Errorf("range no_date does not apply to field %q", p.Field)
+	}
+	if p.Days < 0 {
+		return filter, sort, fmt.Errorf("days must not be negative")
+	}
+	if p.From != "" {
+		from, err := domain.ParseDueDateWeekStart(p.From, now, weekStart)
+		if err != nil {
+			return filter, sort, fmt.Errorf("from: %w", err)
+		}
+		filter.RangeFrom = &from
+	}
+	if p.To != "" {
+		to, err := domain.ParseDueDateWeekStart(p.To, now, weekStart)
+		if err != nil {
+			return filter, sort, fmt.Errorf("to: %w", err)
+		}
+		filter.RangeTo = &to
+	}
+
+	if p.Sort != "" {
+		found := false
+		for by := domain.SortByCreatedAt; by.IsValid(); by++ {
+			if by.String() == p.Sort {
+				sort.By = by
+				found = true
+				break
+			}
+		}
+		if !found {
+			return filter, sort, fmt.Errorf("invalid sort %q", p.Sort)
+		}
+	}
+	switch p.Order {
+	case "", "desc":
+		sort.Ascending = false
+	case "asc":
+		sort.Ascending = true
+	default:
+		return filter, sort, fmt.Errorf("invalid order %q", p.Order)
+	}
+
+	return filter, sort, nil
+}
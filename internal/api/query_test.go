@@ -0,0 +1,133 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hitsumabushi845/task-management/internal/domain"
+)
+
+func TestListParams_Filter(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		name    string
+		params  ListParams
+		wantErr bool
+		check   func(t *testing.T, filter domain.Filter, sort domain.Sort)
+	}{
+		{
+			name:   "zero value excludes archived tasks, newest first",
+			params: ListParams{},
+			check: func(t *testing.T, filter domain.Filter, sort domain.Sort) {
+				if filter.Archived != domain.ArchivedExclude || filter.DateRange != domain.DateRangeAll || filter.DateField != domain.DateFieldDue {
+					t.Errorf("filter = %+v, want all unarchived tasks by due date", filter)
+				}
+				if sort.By != domain.SortByCreatedAt || sort.Ascending {
+					t.Errorf("sort = %+v, want created_at descending", sort)
+				}
+			},
+		},
+		{
+			name:   "statuses, priorities, categories and search",
+			params: ListParams{Statuses: []string{"new", "working"}, Priorities: []string{"high"}, Categories: []int64{2}, Search: "report"},
+			check: func(t *testing.T, filter domain.Filter, sort domain.Sort) {
+				if len(filter.Statuses) != 2 || filter.Statuses[1] != domain.TaskStatusWorking {
+					t.Errorf("Statuses = %v, want new and working", filter.Statuses)
+				}
+				if len(filter.Priorities) != 1 || filter.Priorities[0] != domain.PriorityHigh {
+					t.Errorf("Priorities = %v, want high", filter.Priorities)
+				}
+				if len(filter.Categories) != 1 || filter.Categories[0] != 2 || filter.SearchText != "report" {
+					t.Errorf("Categories %v search %q, want [2] and report", filter.Categories, filter.SearchText)
+				}
+			},
+		},
+		{name: "invalid status", params: ListParams{Statuses: []string{"done"}}, wantErr: true},
+		{name: "invalid priority", params: ListParams{Priorities: []string{"urgent"}}, wantErr: true},
+		{name: "invalid range", params: ListParams{Range: "someday"}, wantErr: true},
+		{name: "invalid field", params: ListParams{Field: "started"}, wantErr: true},
+		{name: "invalid archived", params: ListParams{Archived: "all"}, wantErr: true},
+		{name: "no_date on created", params: ListParams{Range: "no_date", Field: "created"}, wantErr: true},
+		{name: "negative days", params: ListParams{Range: "next_days", Days: -1}, wantErr: true},
+		{name: "invalid from", params: ListParams{Range: "custom", From: "someday"}, wantErr: true},
+		{name: "invalid sort", params: ListParams{Sort: "size"}, wantErr: true},
+		{name: "invalid order", params: ListParams{Order: "up"}, wantErr: true},
+		{
+			name:   "no_date on completed",
+			params: ListParams{Range: "no_date", Field: "completed"},
+			check: func(t *testing.T, filter domain.Filter, sort domain.Sort) {
+				if filter.DateRange != domain.DateRangeNoDueDate || filter.DateField != domain.DateFieldCompleted {
+					t.Errorf("filter = %+v, want no completed date", filter)
+				}
+			},
+		},
+		{
+			name:   "next days",
+			params: ListParams{Range: "next_days", Days: 3},
+			check: func(t *testing.T, filter domain.Filter, sort domain.Sort) {
+				if filter.DateRange != domain.DateRangeNextDays || filter.NextDays != 3 {
+					t.Errorf("filter = %+v, want the next 3 days", filter)
+				}
+			},
+		},
+		{
+			name:   "custom range is resolved relative to now",
+			params: ListParams{Range: "custom", From: "today", To: "2026-10-31"},
+			check: func(t *testing.T, filter domain.Filter, sort domain.Sort) {
+				if filter.RangeFrom == nil || filter.RangeFrom.Format(DateLayout) != "2026-10-14" {
+					t.Errorf("RangeFrom = %v, want 2026-10-14", filter.RangeFrom)
+				}
+				if filter.RangeTo == nil || filter.RangeTo.Format(DateLayout) != "2026-10-31" {
+					t.Errorf("RangeTo = %v, want 2026-10-31", filter.RangeTo)
+				}
+			},
+		},
+		{
+			name:   "archived only",
+			params: ListParams{Archived: "only"},
+			check: func(t *testing.T, filter domain.Filter, sort domain.Sort) {
+				if filter.Archived != domain.ArchivedOnly {
+					t.Errorf("Archived = %v, want ArchivedOnly", filter.Archived)
+				}
+			},
+		},
+		{
+			name:   "archived included",
+			params: ListParams{Archived: "include"},
+			check: func(t *testing.T, filter domain.Filter, sort domain.Sort) {
+				if filter.Archived != domain.ArchivedAny {
+					t.Errorf("Archived = %v, want ArchivedAny", filter.Archived)
+				}
+			},
+		},
+		{
+			name:   "sort ascending",
+			params: ListParams{Sort: "due_date", Order: "asc"},
+			check: func(t *testing.T, filter domain.Filter, sort domain.Sort) {
+				if sort.By != domain.SortByDueDate || !sort.Ascending {
+					t.Errorf("sort = %+v, want due_date ascending", sort)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, sort, err := tt.params.Filter(now, time.Monday)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Filter() expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Filter() error = %v", err)
+			}
+			if filter.WeekStart != time.Monday {
+				t.Errorf("WeekStart = %v, want Monday", filter.WeekStart)
+			}
+			tt.check(t, filter, sort)
+		})
+	}
+}
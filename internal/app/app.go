@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -9,7 +10,10 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/hitsumabushi845/task-management/internal/config"
 	"github.com/hitsumabushi845/task-management/internal/domain"
+	"github.com/hitsumabushi845/task-management/internal/reminder"
+	"github.com/hitsumabushi845/task-management/internal/repository"
 	"github.com/hitsumabushi845/task-management/internal/ui/styles"
 )
 
@@ -22,6 +26,16 @@ const (
 	viewModeFilter
 	viewModeHelp
 	viewModeEdit
+	viewModeBulk
+)
+
+// maxDueDateInputLen bounds the free-form due date input in the edit form
+const maxDueDateInputLen = 24
+
+// Size of the description text area in the edit form
+const (
+	descWidth  = 35
+	descHeight = 6
 )
 
 // Model is the root application model
@@ -41,36 +55,95 @@ type Model struct {
 	kanbanColumn  int    // 0=New, 1=Working, 2=Completed
 	kanbanCursors [3]int // Cursor position within each column
 	// Filter state
-	filter       domain.Filter
-	filterCursor int
+	filter          domain.Filter
+	filterCursor    int
+	filterFromInput string // Custom date range start, parsed as typed
+	filterToInput   string // Custom date range end, parsed as typed
+	filterTyping    bool   // Keys go to the text row under the cursor, j/k included
+	filterRetro     retroPreset
 	// Sort state
 	taskSort     domain.Sort
 	sortMenuOpen bool
 	// Edit state
 	editTask        *domain.Task    // Reference to task being edited
-	editCursor      int             // 0=title, 1=desc, 2=priority, 3=category, 4=date, 5=save, 6=cancel
+	editBase        domain.Task     // The task as loaded, to merge with after a conflict
+	editCursor      int             // 0=title, 1=desc, 2=priority, 3=category, 4=date, 5=remind, 6=save, 7=cancel
 	editingField    bool            // Currently typing in a field
 	editTitle       string          // Edited title value
-	editDesc        string          // Edited description value
+	editDesc        textArea        // Edited description value
 	editPriority    domain.Priority
 	editCategoryIdx int    // Index into categories slice, -1 for no category
 	editDueDate     string // String for input, parsed on save
+	editRemind      string // Reminder input, parsed on save
 	editError       string // Validation error message
+	// Date picker state (opened from the Due Date field)
+	datePickerOpen bool
+	datePicker     datePicker
+	// Confirmation dialog shown before destructive actions
+	confirmOpen bool
+	confirm     confirmDialog
+	// Detail pane shown over the list or kanban view
+	detailOpen   bool
+	detailID     int64
+	detailScroll int
+	detailItem   int             // Selected checklist item
+	detailAdding detailInputKind // What the input line is adding
+	detailInput  string          // The new item's, note's or attachment's text
+	detailDetach bool            // The digit keys remove an attachment rather than open it
+	detailSaving bool            // An edit made in the detail pane is being saved
+	detailEdits  []detailEdit    // Edits waiting for that save to finish
+	// Where attached files are copied; empty attaches them in place
+	attachmentDir string
 	// Category state
 	categories []*domain.Category // All available categories
+	// Reminder state
+	config          config.Config
+	notifier        reminder.Notifier // nil unless a notify command is configured
+	activeReminders []*domain.Task    // Fired reminders shown in the banner, oldest first
+	// Message from a change rejected by a hook, shown until the next key press
+	notice string
+	// Repository events; nil means reload the list after each change
+	events <-chan domain.Event
+	// Detects changes made outside the TUI; nil disables live reload
+	changes ChangeDetector
+	// Bulk selection state
+	marked      map[int64]bool // IDs of the tasks marked with x
+	bulkMenu    bulkMenu       // Page of the bulk action modal
+	bulkDueDate string         // Due date input for a bulk due date change
+	undoStack   []undoEntry    // Bulk actions that can be undone, oldest first
+}
+
+// Option configures the application model
+type Option func(*Model)
+
+// WithConfig applies user settings
+func WithConfig(cfg config.Config) Option {
+	return func(m *Model) {
+		m.config = cfg
+		m.filter.WeekStart = time.Weekday(cfg.WeekStart)
+		if cfg.NotifyCommand != "" {
+			m.notifier = reminder.NewNotifier(cfg.NotifyCommand, nil)
+		}
+	}
 }
 
 // New creates a new application model
-func New(repo domain.TaskRepository) *Model {
-	return &Model{
-		repo:  repo,
-		tasks: []*domain.Task{},
+func New(repo domain.TaskRepository, opts ...Option) *Model {
+	m := &Model{
+		repo:   repo,
+		tasks:  []*domain.Task{},
+		config: config.Default(),
+		filter: domain.Filter{WeekStart: time.Weekday(config.Default().WeekStart)},
+	}
+	for _, opt := range opts {
+		opt(m)
 	}
+	return m
 }
 
 // Init initializes the application
 func (m *Model) Init() tea.Cmd {
-	return tea.Batch(m.loadTasks(), m.loadCategories())
+	return tea.Batch(m.loadTasks(), m.loadCategories(), reminderTick(), m.waitForEvent(), m.changePoll())
 }
 
 // loadTasks loads all tasks from the repository
@@ -80,7 +153,14 @@ func (m *Model) loadTasks() tea.Cmd {
 		if err != nil {
 			return errMsg{err: err}
 		}
-		return taskListLoadedMsg{tasks: tasks}
+		// Archived tasks are kept in the repository but never shown
+		shown := make([]*domain.Task, 0, len(tasks))
+		for _, task := range tasks {
+			if task.ArchivedAt == nil {
+				shown = append(shown, task)
+			}
+		}
+		return taskListLoadedMsg{tasks: shown}
 	}
 }
 
@@ -122,10 +202,16 @@ func (m *Model) createTask(title string, priority domain.Priority, categoryIdx i
 // deleteTask deletes the selected task
 func (m *Model) deleteTask(id int64) tea.Cmd {
 	return func() tea.Msg {
-		err := m.repo.Delete(context.Background(), id)
+		ctx := context.Background()
+		task, err := m.repo.GetByID(ctx, id)
+		if err == nil {
+			err = m.repo.Delete(ctx, id)
+		}
 		if err != nil {
 			return errMsg{err: err}
 		}
+		// Nothing refers to its copied files any more
+		m.discardCopies(task.Attachments)
 		return taskDeletedMsg{id: id}
 	}
 }
@@ -134,18 +220,12 @@ func (m *Model) deleteTask(id int64) tea.Cmd {
 func (m *Model) toggleTaskStatus(task *domain.Task) tea.Cmd {
 	return func() tea.Msg {
 		// Update status and timestamps
-		now := time.Now()
-		switch task.Status {
-		case domain.TaskStatusNew:
-			task.Status = domain.TaskStatusWorking
-			task.StartedAt = &now
-		case domain.TaskStatusWorking:
-			task.Status = domain.TaskStatusCompleted
-			task.CompletedAt = &now
-		case domain.TaskStatusCompleted:
-			task.Status = domain.TaskStatusNew
-			task.StartedAt = nil
-			task.CompletedAt = nil
+		next := task.NextStatus()
+		if task.Status == domain.TaskStatusCompleted {
+			next = domain.TaskStatusNew
+		}
+		if err := task.SetStatus(next, time.Now()); err != nil {
+			return errMsg{err: err}
 		}
 
 		err := m.repo.Update(context.Background(), task)
@@ -175,6 +255,13 @@ func (m *Model) tasksByStatus(status domain.TaskStatus) []*domain.Task {
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		m.notice = ""
+
+		// A confirmation dialog takes all keys while open
+		if m.confirmOpen {
+			return m.updateConfirm(msg)
+		}
+
 		// Handle create mode separately
 		if m.mode == viewModeCreate {
 			return m.updateCreateMode(msg)
@@ -199,6 +286,35 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.updateEditMode(msg)
 		}
 
+		// Handle the bulk action modal
+		if m.mode == viewModeBulk {
+			return m.updateBulkMode(msg)
+		}
+
+		// The detail pane takes keys until it is closed
+		if m.detailOpen {
+			return m.updateDetail(msg)
+		}
+
+		// Marking and bulk actions work the same in list and kanban views
+		if m.mode == viewModeList || m.mode == viewModeKanban {
+			if cmd, ok := m.updateBulkKeys(msg); ok {
+				return m, cmd
+			}
+		}
+
+		// Snooze or dismiss a reminder shown in the list or kanban banner.
+		// Esc clears marks first; Z is shifted so that no stray key snoozes.
+		if len(m.activeReminders) > 0 && (m.mode == viewModeList || m.mode == viewModeKanban) {
+			switch msg.String() {
+			case "Z":
+				return m, m.snoozeReminder()
+			case "esc":
+				m.dismissReminder()
+				return m, nil
+			}
+		}
+
 		// Handle kanban mode
 		if m.mode == viewModeKanban {
 			return m.updateKanbanMode(msg)
@@ -210,7 +326,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 
 		case "j", "down":
-			if m.cursor < len(m.tasks)-1 {
+			if m.cursor < len(m.visibleTasks())-1 {
 				m.cursor++
 			}
 
@@ -228,24 +344,25 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case "d":
 			// Delete selected task
-			if len(m.tasks) > 0 && m.cursor < len(m.tasks) {
-				task := m.tasks[m.cursor]
-				return m, m.deleteTask(task.ID)
+			if task := m.selectedTask(); task != nil {
+				return m, m.confirmDelete(task.ID, task.Title)
 			}
 
 		case "e":
 			// Edit selected task
-			filteredTasks := m.filter.Apply(m.tasks)
-			sortedTasks := m.taskSort.Apply(filteredTasks)
-			if len(sortedTasks) > 0 && m.cursor < len(sortedTasks) {
-				task := sortedTasks[m.cursor]
+			if task := m.selectedTask(); task != nil {
 				m.startEditMode(task)
 			}
 
+		case "enter", "o":
+			// Show the selected task in the detail pane
+			if task := m.selectedTask(); task != nil {
+				m.openDetail(task)
+			}
+
 		case " ":
 			// Toggle task status
-			if len(m.tasks) > 0 && m.cursor < len(m.tasks) {
-				task := m.tasks[m.cursor]
+			if task := m.selectedTask(); task != nil {
 				return m, m.toggleTaskStatus(task)
 			}
 
@@ -295,30 +412,90 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case taskListLoadedMsg:
-		m.tasks = msg.tasks
-		if m.cursor >= len(m.tasks) {
-			m.cursor = len(m.tasks) - 1
-		}
-		if m.cursor < 0 {
-			m.cursor = 0
+		// Keep the cursor on the same task wherever it moved
+		var selectedID int64
+		if task := m.selectedTask(); task != nil {
+			selectedID = task.ID
 		}
+		m.tasks = msg.tasks
+		m.restoreSelection(selectedID)
+		m.pruneMarks()
 
 	case categoriesLoadedMsg:
 		m.categories = msg.categories
 
-	case taskCreatedMsg:
-		// Task created, reload list
-		return m, m.loadTasks()
+	case taskCreatedMsg, taskDeletedMsg, taskUpdatedMsg:
+		// Without events, reload the list to pick up the change
+		if m.events == nil {
+			return m, m.loadTasks()
+		}
 
-	case taskDeletedMsg:
-		// Task deleted, reload list
-		return m, m.loadTasks()
+	case detailEditMsg:
+		return m, m.saveEdit(msg.edit)
 
-	case taskUpdatedMsg:
-		// Task updated, reload list
+	case detailSavedMsg:
+		return m, m.finishDetailSave(msg)
+
+	case noteAddedMsg:
 		return m, m.loadTasks()
 
+	case attachmentNoticeMsg:
+		m.notice = msg.text
+
+	case taskEventMsg:
+		if msg.event.Type == domain.EventsDropped {
+			// Missed events can only be caught up with by reloading
+			return m, tea.Batch(m.loadTasks(), m.waitForEvent())
+		}
+		m.applyEvent(msg.event)
+		return m, m.waitForEvent()
+
+	case bulkDoneMsg:
+		return m, m.finishBulk(msg.entry)
+
+	case undoneMsg:
+		m.notice = fmt.Sprintf("Undid: %s %s", msg.entry.verb, pluralTasks(len(msg.entry.before)))
+		if msg.skipped > 0 {
+			m.notice += fmt.Sprintf(" (skipped %s changed since)", pluralTasks(msg.skipped))
+		}
+		if m.events == nil {
+			return m, m.loadTasks()
+		}
+
+	case purgedMsg:
+		m.notice = fmt.Sprintf("Purged %s from the archive", pluralTasks(msg.count))
+
+	case undoFailedMsg:
+		m.undoStack = append(m.undoStack, msg.entry)
+		return m.Update(errMsg{err: msg.err})
+
+	case changePollMsg:
+		return m, m.checkChanges()
+
+	case externalChangeMsg:
+		if msg.changed {
+			return m, tea.Batch(m.loadTasks(), m.loadCategories(), m.changePoll())
+		}
+		return m, m.changePoll()
+
+	case reminderTickMsg:
+		return m, m.checkReminders(msg.now)
+
+	case editConflictMsg:
+		m.resolveEditConflict(msg)
+
+	case reminderFiredMsg:
+		return m, m.fireReminder(msg.task)
+
+	case editorDoneMsg:
+		m.finishEditor(msg)
+
 	case errMsg:
+		if notice, ok := errorNotice(msg.err); ok {
+			// Reload to discard the rejected change from the in-memory list
+			m.notice = notice
+			return m, m.loadTasks()
+		}
 		m.err = msg.err
 
 	case tea.WindowSizeMsg:
@@ -384,11 +561,7 @@ func (m *Model) updateCreateMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // updateKanbanMode handles input in kanban mode
 func (m *Model) updateKanbanMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	columns := [3][]*domain.Task{
-		m.tasksByStatus(domain.TaskStatusNew),
-		m.tasksByStatus(domain.TaskStatusWorking),
-		m.tasksByStatus(domain.TaskStatusCompleted),
-	}
+	columns := m.kanbanColumns()
 
 	switch msg.String() {
 	case "q", "ctrl+c":
@@ -438,6 +611,12 @@ func (m *Model) updateKanbanMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, m.advanceTaskStatus(task)
 		}
 
+	case "o":
+		// Show the selected task in the detail pane
+		if task := m.selectedTask(); task != nil {
+			m.openDetail(task)
+		}
+
 	case "n":
 		m.mode = viewModeCreate
 		m.inputTitle = ""
@@ -448,7 +627,7 @@ func (m *Model) updateKanbanMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		col := m.kanbanColumn
 		if len(columns[col]) > 0 && m.kanbanCursors[col] < len(columns[col]) {
 			task := columns[col][m.kanbanCursors[col]]
-			return m, m.deleteTask(task.ID)
+			return m, m.confirmDelete(task.ID, task.Title)
 		}
 
 	case "e":
@@ -503,18 +682,13 @@ func (m *Model) updateKanbanMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 // advanceTaskStatus moves task to next status (new -> working -> completed)
 func (m *Model) advanceTaskStatus(task *domain.Task) tea.Cmd {
 	return func() tea.Msg {
-		now := time.Now()
-		switch task.Status {
-		case domain.TaskStatusNew:
-			task.Status = domain.TaskStatusWorking
-			task.StartedAt = &now
-		case domain.TaskStatusWorking:
-			task.Status = domain.TaskStatusCompleted
-			task.CompletedAt = &now
-		case domain.TaskStatusCompleted:
+		if task.Status == domain.TaskStatusCompleted {
 			// Already completed, no change
 			return nil
 		}
+		if err := task.SetStatus(task.NextStatus(), time.Now()); err != nil {
+			return errMsg{err: err}
+		}
 
 		err := m.repo.Update(context.Background(), task)
 		if err != nil {
@@ -529,32 +703,21 @@ func (m *Model) advanceTaskStatus(task *domain.Task) tea.Cmd {
 func (m *Model) startEditMode(task *domain.Task) {
 	m.previousMode = m.mode // Save current mode to return to after edit
 	m.editTask = task
+	m.editBase = *task
 	m.editCursor = 0
 	m.editingField = false
-	m.editTitle = task.Title
-	m.editDesc = task.Description
-	m.editPriority = task.Priority
-	// Find category index
-	m.editCategoryIdx = -1
-	if task.CategoryID != nil {
-		for i, cat := range m.categories {
-			if cat.ID == *task.CategoryID {
-				m.editCategoryIdx = i
-				break
-			}
-		}
-	}
-	if task.DueDate != nil {
-		m.editDueDate = task.DueDate.Format("2006-01-02")
-	} else {
-		m.editDueDate = ""
-	}
+	m.setEditForm(m.editFormOf(task))
 	m.editError = ""
 	m.mode = viewModeEdit
 }
 
 // updateEditMode handles input in edit mode
 func (m *Model) updateEditMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Calendar overlay takes all keys while open
+	if m.datePickerOpen {
+		return m.updateDatePicker(msg)
+	}
+
 	// If editing a text field, handle text input
 	if m.editingField {
 		return m.updateEditFieldInput(msg)
@@ -563,7 +726,7 @@ func (m *Model) updateEditMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Navigation mode
 	switch msg.String() {
 	case "j", "down":
-		if m.editCursor < 6 {
+		if m.editCursor < 7 {
 			m.editCursor++
 		}
 
@@ -574,26 +737,34 @@ func (m *Model) updateEditMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "enter":
 		switch m.editCursor {
-		case 0, 1, 4: // Title, Description, Due Date (due date moved to 4)
+		case 0, 1, 4, 5: // Title, Description, Due Date, Remind
 			// Start editing text field
 			m.editingField = true
 		case 2: // Priority
 			m.cyclePriority()
 		case 3: // Category
 			m.cycleCategory()
-		case 5: // Save button
+		case 6: // Save button
 			return m.saveEditedTask()
-		case 6: // Cancel button
+		case 7: // Cancel button
 			m.mode = m.previousMode
 			m.editError = ""
 		}
 
 	case "tab":
-		// Cycle priority or category depending on cursor
+		// Cycle priority or category, or open the calendar, depending on cursor
 		if m.editCursor == 2 {
 			m.cyclePriority()
 		} else if m.editCursor == 3 {
 			m.cycleCategory()
+		} else if m.editCursor == 4 {
+			m.openDatePicker()
+		}
+
+	case "ctrl+o":
+		// Edit the description in $EDITOR
+		if m.editCursor == 1 {
+			return m, m.openEditor()
 		}
 
 	case "esc":
@@ -628,6 +799,19 @@ func (m *Model) cycleCategory() {
 
 // updateEditFieldInput handles text input when editing a field
 func (m *Model) updateEditFieldInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// The description is multi-line: Enter starts a new line and Esc finishes
+	if m.editCursor == 1 {
+		switch msg.String() {
+		case "esc":
+			m.editingField = false
+		case "ctrl+o":
+			return m, m.openEditor()
+		default:
+			m.editDesc.update(msg)
+		}
+		return m, nil
+	}
+
 	switch msg.String() {
 	case "enter", "esc":
 		// Stop editing this field
@@ -641,14 +825,15 @@ func (m *Model) updateEditFieldInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				runes := []rune(m.editTitle)
 				m.editTitle = string(runes[:len(runes)-1])
 			}
-		case 1: // Description
-			if len(m.editDesc) > 0 {
-				runes := []rune(m.editDesc)
-				m.editDesc = string(runes[:len(runes)-1])
-			}
 		case 4: // Due Date (moved to position 4)
 			if len(m.editDueDate) > 0 {
-				m.editDueDate = m.editDueDate[:len(m.editDueDate)-1]
+				runes := []rune(m.editDueDate)
+				m.editDueDate = string(runes[:len(runes)-1])
+			}
+		case 5: // Remind
+			if len(m.editRemind) > 0 {
+				runes := []rune(m.editRemind)
+				m.editRemind = string(runes[:len(runes)-1])
 			}
 		}
 
@@ -667,13 +852,15 @@ func (m *Model) updateEditFieldInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			switch m.editCursor {
 			case 0: // Title
 				m.editTitle += char
-			case 1: // Description
-				m.editDesc += char
 			case 4: // Due Date (moved to position 4)
-				// Only allow date-like characters
-				if len(m.editDueDate) < 10 {
+				// Free-form input such as "tomorrow" or "来週金曜"
+				if utf8.RuneCountInString(m.editDueDate+char) <= maxDueDateInputLen {
 					m.editDueDate += char
 				}
+			case 5: // Remind, e.g. "+30m" or "fri 9:00"
+				if utf8.RuneCountInString(m.editRemind+char) <= maxDueDateInputLen {
+					m.editRemind += char
+				}
 			}
 		}
 	}
@@ -691,20 +878,32 @@ func (m *Model) saveEditedTask() (tea.Model, tea.Cmd) {
 
 	// Validate and parse due date
 	var dueDate *time.Time
-	if m.editDueDate != "" {
-		parsed, err := time.Parse("2006-01-02", m.editDueDate)
+	if strings.TrimSpace(m.editDueDate) != "" {
+		parsed, err := domain.ParseDueDateWeekStart(m.editDueDate, time.Now(), m.weekStart())
 		if err != nil {
-			m.editError = "Invalid date format (use YYYY-MM-DD)"
+			m.editError = "Invalid date (e.g. fri, +3d)"
 			return m, nil
 		}
 		dueDate = &parsed
 	}
 
+	// Validate and parse reminder
+	var remindAt *time.Time
+	if strings.TrimSpace(m.editRemind) != "" {
+		parsed, err := domain.ParseReminder(m.editRemind, time.Now())
+		if err != nil {
+			m.editError = "Invalid reminder (e.g. +30m)"
+			return m, nil
+		}
+		remindAt = &parsed
+	}
+
 	// Update task
 	m.editTask.Title = strings.TrimSpace(m.editTitle)
-	m.editTask.Description = m.editDesc
+	m.editTask.Description = m.editDesc.String()
 	m.editTask.Priority = m.editPriority
 	m.editTask.DueDate = dueDate
+	m.editTask.RemindAt = remindAt
 
 	// Update category
 	if m.editCategoryIdx >= 0 && m.editCategoryIdx < len(m.categories) {
@@ -714,23 +913,29 @@ func (m *Model) saveEditedTask() (tea.Model, tea.Cmd) {
 		m.editTask.CategoryID = nil
 	}
 
-	// Validate using domain validation
+	// Validate using domain validation, moving to the invalid field
 	if err := m.editTask.Validate(); err != nil {
 		m.editError = err.Error()
+		var verr *domain.ValidationError
+		if errors.As(err, &verr) {
+			if cursor, ok := editFieldCursor(verr.Field); ok {
+				m.editCursor = cursor
+			}
+		}
 		return m, nil
 	}
 
 	// Save to repository
 	m.mode = m.previousMode
 	m.editError = ""
-	return m, m.updateTask(m.editTask)
+	return m, m.storeEditedTask(m.editTask)
 }
 
-// updateTask updates a task in the repository
+// updateTask updates a task in the repository. A conflict reloads the list
+// through errorNotice.
 func (m *Model) updateTask(task *domain.Task) tea.Cmd {
 	return func() tea.Msg {
-		err := m.repo.Update(context.Background(), task)
-		if err != nil {
+		if err := m.repo.Update(context.Background(), task); err != nil {
 			return errMsg{err: err}
 		}
 		return taskUpdatedMsg{task: task}
@@ -740,7 +945,12 @@ func (m *Model) updateTask(task *domain.Task) tea.Cmd {
 // View renders the application
 func (m *Model) View() string {
 	if m.err != nil {
-		return "Error: " + m.err.Error() + "\n\nPress q to quit.\n"
+		return "Error: " + m.err.Error() + "\n" + repository.ErrorHint(m.err) + "\nPress q to quit.\n"
+	}
+
+	// Confirmation dialog overlay
+	if m.confirmOpen {
+		return m.confirm.view()
 	}
 
 	// Help modal overlay
@@ -763,6 +973,16 @@ func (m *Model) View() string {
 		return m.viewEdit()
 	}
 
+	// Bulk action modal
+	if m.mode == viewModeBulk {
+		return m.viewBulk()
+	}
+
+	// Detail pane over the list or kanban view
+	if m.detailOpen {
+		return m.viewDetail()
+	}
+
 	// Kanban mode view
 	if m.mode == viewModeKanban {
 		return m.viewKanban()
@@ -779,6 +999,9 @@ func (m *Model) viewList() string {
 	}
 	s += "\n\n"
 
+	s += m.viewReminderBanner()
+	s += m.viewNotice()
+
 	// Show sort menu if open
 	if m.sortMenuOpen {
 		s += m.viewSortMenu() + "\n"
@@ -796,6 +1019,8 @@ func (m *Model) viewList() string {
 			s += "No tasks match the filter.\n\n"
 		}
 	} else {
+		now := time.Now()
+		staleAfter := time.Duration(m.config.StaleAfter)
 		for i, task := range sortedTasks {
 			// Status icon
 			var statusIcon string
@@ -840,6 +1065,14 @@ func (m *Model) viewList() string {
 				task.Title,
 				catDisplay,
 			)
+			if progress := checklistProgress(task); progress != "" {
+				line += " " + renderChecklistProgress(task)
+			}
+
+			// Flag tasks stuck in working
+			if task.IsStale(now, staleAfter) {
+				line += styles.Stale.Render(fmt.Sprintf(" ⚠ stale %dd", int(now.Sub(*task.StartedAt).Hours()/24)))
+			}
 
 			// Highlight selected
 			if i == m.cursor {
@@ -847,6 +1080,11 @@ func (m *Model) viewList() string {
 			} else {
 				line = "  " + line
 			}
+			if m.marked[task.ID] {
+				line = styles.Marked.Render("*") + line
+			} else {
+				line = " " + line
+			}
 
 			s += line + "\n"
 		}
@@ -854,6 +1092,9 @@ func (m *Model) viewList() string {
 	}
 
 	// Status bar
+	if len(m.marked) > 0 {
+		return s + m.viewMarkedBar()
+	}
 	helpText := "[n]New [e]Edit [d]Delete [Space]Status [f]Filter [s]Sort [v]Kanban [?]Help [q]Quit"
 	s += styles.StatusBar.Render(helpText) + "\n"
 
@@ -904,6 +1145,9 @@ func (m *Model) viewKanban() string {
 		s = "(Filter Active)\n"
 	}
 
+	s += m.viewReminderBanner()
+	s += m.viewNotice()
+
 	// Show sort menu if open
 	if m.sortMenuOpen {
 		s += m.viewSortMenu() + "\n"
@@ -962,6 +1206,9 @@ func (m *Model) viewKanban() string {
 	)
 
 	// Status bar
+	if len(m.marked) > 0 {
+		return s + "\n" + m.viewMarkedBar()
+	}
 	helpText := "[h/l]Column [j/k]Up/Down [Enter]Advance [e]Edit [f]Filter [s]Sort [v]List [?]Help [q]Quit"
 	s += "\n" + styles.StatusBar.Render(helpText) + "\n"
 
@@ -1005,8 +1252,17 @@ func (m *Model) renderKanbanCell(tasks []*domain.Task, row, col, width int) stri
 
 	// Truncate title if needed
 	title := task.Title
-	// Account for priority [P] + space + category
+	if m.marked[task.ID] {
+		title = "* " + title
+	}
+	// Checklist progress, e.g. "3/7"
+	progress := checklistProgress(task)
+
+	// Account for priority [P] + space + progress + category
 	maxTitleLen := width - 5 - len(catDisplay)
+	if progress != "" {
+		maxTitleLen -= 1 + len(progress)
+	}
 	if maxTitleLen < 5 {
 		maxTitleLen = 5
 	}
@@ -1016,6 +1272,9 @@ func (m *Model) renderKanbanCell(tasks []*domain.Task, row, col, width int) stri
 	}
 
 	cell := fmt.Sprintf("[%s] %s", priorityStyle.Render(priorityText), title)
+	if progress != "" {
+		cell += " " + renderChecklistProgress(task)
+	}
 	if catDisplay != "" {
 		cell += " " + catDisplay
 	}
@@ -1025,6 +1284,9 @@ func (m *Model) renderKanbanCell(tasks []*domain.Task, row, col, width int) stri
 	if catDisplay != "" {
 		cellLen++ // space before category
 	}
+	if progress != "" {
+		cellLen += 1 + len(progress)
+	}
 	if cellLen < width {
 		cell += strings.Repeat(" ", width-cellLen)
 	}
@@ -1050,9 +1312,18 @@ func (m *Model) viewHelp() string {
 │                                        │
 │ Task Actions:                          │
 │   Enter    : Advance to next status    │
+│   o        : Show details              │
 │   e        : Edit task                 │
 │   n        : Create new task           │
 │   d        : Delete task               │
+│   Z        : Snooze reminder           │
+│                                        │
+│ Bulk Actions:                          │
+│   x        : Mark task                 │
+│   X        : Mark all shown tasks      │
+│   b        : Act on marked tasks       │
+│   u        : Undo last bulk action     │
+│   P        : Purge archived tasks      │
 │                                        │
 │ View:                                  │
 │   v        : Switch to list view       │
@@ -1072,9 +1343,18 @@ func (m *Model) viewHelp() string {
 │                                        │
 │ Task Actions:                          │
 │   Space    : Toggle status             │
+│   Enter/o  : Show details              │
 │   e        : Edit task                 │
 │   n        : Create new task           │
 │   d        : Delete task               │
+│   Z        : Snooze reminder           │
+│                                        │
+│ Bulk Actions:                          │
+│   x        : Mark task                 │
+│   X        : Mark all shown tasks      │
+│   b        : Act on marked tasks       │
+│   u        : Undo last bulk action     │
+│   P        : Purge archived tasks      │
 │                                        │
 │ View:                                  │
 │   v        : Switch to kanban view     │
@@ -1090,9 +1370,78 @@ func (m *Model) viewHelp() string {
 	return s
 }
 
+// Filter modal rows. Category rows follow the fixed rows, then search and clear.
+const (
+	filterRowStatus     = 0  // 0-2
+	filterRowPriority   = 3  // 3-5
+	filterRowDateField  = 6  // Which timestamp the date range applies to
+	filterRowDateRange  = 7  // One row per dateRangeOptions entry
+	filterRowRangeFrom  = 15 // Custom range start
+	filterRowRangeTo    = 16 // Custom range end
+	filterRowRetro      = 17 // One row per retroPresets entry
+	filterRowCategories = 21
+)
+
+// dateRangeOptions lists the date range radio buttons in display order
+var dateRangeOptions = []domain.DateRange{
+	domain.DateRangeAll,
+	domain.DateRangeToday,
+	domain.DateRangeThisWeek,
+	domain.DateRangeThisMonth,
+	domain.DateRangeNextDays,
+	domain.DateRangeOverdue,
+	domain.DateRangeNoDueDate,
+	domain.DateRangeCustom,
+}
+
+// dateRangeLabel names a date range option for the selected date field
+func (m *Model) dateRangeLabel(r domain.DateRange) string {
+	switch r {
+	case domain.DateRangeToday:
+		return "Today"
+	case domain.DateRangeThisWeek:
+		return "This Week"
+	case domain.DateRangeThisMonth:
+		return "This Month"
+	case domain.DateRangeNextDays:
+		days := m.filter.NextDays
+		if days <= 0 {
+			days = domain.DefaultNextDays
+		}
+		return fmt.Sprintf("Next %d Days [+/-]", days)
+	case domain.DateRangeOverdue:
+		if m.filter.DateField == domain.DateFieldDue {
+			return "Overdue"
+		}
+		return "Before Today"
+	case domain.DateRangeNoDueDate:
+		switch {
+		case m.filter.DateField == domain.DateFieldDue:
+			return "No Due Date"
+		case !m.filter.DateField.CanBeUnset():
+			return "Not Set (always set)"
+		}
+		return "Not Set"
+	case domain.DateRangeCustom:
+		return "Custom (From/To)"
+	default:
+		return "All"
+	}
+}
+
+// filterLine pads a filter modal row to the box width
+func filterLine(line string) string {
+	// Display width, so wide characters such as 仕事 stay aligned
+	padding := 38 - lipgloss.Width(line)
+	if padding < 0 {
+		padding = 0
+	}
+	return fmt.Sprintf("│ %s%s │\n", line, strings.Repeat(" ", padding))
+}
+
 func (m *Model) viewFilter() string {
 	// Dynamic cursor positions
-	categoryStartCursor := 11
+	categoryStartCursor := filterRowCategories
 	searchCursor := categoryStartCursor + len(m.categories)
 	clearCursor := searchCursor + 1
 
@@ -1110,15 +1459,10 @@ func (m *Model) viewFilter() string {
 			checkbox = "[x]"
 		}
 		cursor := "  "
-		if m.filterCursor == i {
+		if m.filterCursor == filterRowStatus+i {
 			cursor = "> "
 		}
-		line := fmt.Sprintf("%s%s %s", cursor, checkbox, label)
-		padding := 38 - len(line)
-		if padding < 0 {
-			padding = 0
-		}
-		s += fmt.Sprintf("│ %s%s │\n", line, strings.Repeat(" ", padding))
+		s += filterLine(fmt.Sprintf("%s%s %s", cursor, checkbox, label))
 	}
 
 	s += "│                                        │\n"
@@ -1134,46 +1478,89 @@ func (m *Model) viewFilter() string {
 			checkbox = "[x]"
 		}
 		cursor := "  "
-		if m.filterCursor == 3+i {
+		if m.filterCursor == filterRowPriority+i {
 			cursor = "> "
 		}
-		line := fmt.Sprintf("%s%s %s", cursor, checkbox, label)
-		padding := 38 - len(line)
-		if padding < 0 {
-			padding = 0
-		}
-		s += fmt.Sprintf("│ %s%s │\n", line, strings.Repeat(" ", padding))
+		s += filterLine(fmt.Sprintf("%s%s %s", cursor, checkbox, label))
 	}
 
 	s += "│                                        │\n"
 
-	// Date range radio buttons (cursor 6-10)
-	s += "│ Due Date:                              │\n"
-	dateLabels := []string{"All", "Today", "This Week", "Overdue", "No Due Date"}
-	dateValues := []domain.DateRange{domain.DateRangeAll, domain.DateRangeToday, domain.DateRangeThisWeek, domain.DateRangeOverdue, domain.DateRangeNoDueDate}
-	for i, label := range dateLabels {
-		selected := m.filter.DateRange == dateValues[i]
+	// Date field selector and date range radio buttons
+	s += "│ Date:                                  │\n"
+	cursor := "  "
+	if m.filterCursor == filterRowDateField {
+		cursor = "> "
+	}
+	fieldLine := cursor + "Field:"
+	for _, field := range []domain.DateField{domain.DateFieldDue, domain.DateFieldCreated, domain.DateFieldCompleted} {
+		if m.filter.DateField == field {
+			fieldLine += " [" + field.String() + "]"
+		} else {
+			fieldLine += " " + field.String()
+		}
+	}
+	s += filterLine(fieldLine)
+
+	for i, value := range dateRangeOptions {
 		radio := "( )"
-		if selected {
+		if m.filter.DateRange == value {
 			radio = "(o)"
 		}
 		cursor := "  "
-		if m.filterCursor == 6+i {
+		if m.filterCursor == filterRowDateRange+i {
 			cursor = "> "
 		}
-		line := fmt.Sprintf("%s%s %s", cursor, radio, label)
-		// Account for multi-byte characters in padding calculation
-		runeCount := len(cursor) + len(radio) + 1 + len([]rune(label))
-		padding := 38 - runeCount
-		if padding < 0 {
-			padding = 0
+		s += filterLine(fmt.Sprintf("%s%s %s", cursor, radio, m.dateRangeLabel(value)))
+	}
+
+	// Custom range inputs
+	rangeInputs := []struct {
+		row   int
+		label string
+		input string
+	}{
+		{filterRowRangeFrom, "From", m.filterFromInput},
+		{filterRowRangeTo, "To", m.filterToInput},
+	}
+	for _, in := range rangeInputs {
+		cursor := "      "
+		if m.filterCursor == in.row {
+			cursor = "    > "
 		}
-		s += fmt.Sprintf("│ %s%s │\n", line, strings.Repeat(" ", padding))
+		line := fmt.Sprintf("%s%-5s %s", cursor, in.label+":", in.input)
+		if m.filterCursor == in.row && m.filterTyping {
+			line += "█"
+		}
+		if strings.TrimSpace(in.input) != "" {
+			if date, err := domain.ParseDueDateWeekStart(in.input, time.Now(), m.weekStart()); err == nil {
+				line += " → " + date.Format("01-02")
+			} else {
+				line += " → ?"
+			}
+		}
+		s += filterLine(line)
 	}
 
 	s += "│                                        │\n"
 
-	// Category checkboxes (cursor 11 + i for each category)
+	// Retrospective presets
+	s += "│ Retro:                                 │\n"
+	for i, preset := range retroPresets {
+		radio := "( )"
+		if m.filterRetro == preset {
+			radio = "(o)"
+		}
+		cursor := "  "
+		if m.filterCursor == filterRowRetro+i {
+			cursor = "> "
+		}
+		s += filterLine(fmt.Sprintf("%s%s %s", cursor, radio, m.retroLabel(preset)))
+	}
+
+	s += "│                                        │\n"
+
+	// Category checkboxes (one row per category)
 	s += "│ Category:                              │\n"
 	for i, cat := range m.categories {
 		checked := m.hasFilterCategory(cat.ID)
@@ -1185,14 +1572,7 @@ func (m *Model) viewFilter() string {
 		if m.filterCursor == categoryStartCursor+i {
 			cursor = "> "
 		}
-		line := fmt.Sprintf("%s%s %s", cursor, checkbox, cat.Name)
-		// Use rune count for Unicode support
-		runeCount := len(cursor) + len(checkbox) + 1 + utf8.RuneCountInString(cat.Name)
-		padding := 38 - runeCount
-		if padding < 0 {
-			padding = 0
-		}
-		s += fmt.Sprintf("│ %s%s │\n", line, strings.Repeat(" ", padding))
+		s += filterLine(fmt.Sprintf("%s%s %s", cursor, checkbox, cat.Name))
 	}
 
 	s += "│                                        │\n"
@@ -1202,13 +1582,11 @@ func (m *Model) viewFilter() string {
 	if m.filterCursor == searchCursor {
 		searchCursorStr = "> "
 	}
-	searchLine := fmt.Sprintf("%sSearch: %s█", searchCursorStr, m.filter.SearchText)
-	searchRuneCount := len(searchCursorStr) + len("Search: ") + len([]rune(m.filter.SearchText)) + 1
-	searchPadding := 38 - searchRuneCount
-	if searchPadding < 0 {
-		searchPadding = 0
+	searchLine := fmt.Sprintf("%sSearch: %s", searchCursorStr, m.filter.SearchText)
+	if m.filterCursor == searchCursor && m.filterTyping {
+		searchLine += "█"
 	}
-	s += fmt.Sprintf("│ %s%s │\n", searchLine, strings.Repeat(" ", searchPadding))
+	s += filterLine(searchLine)
 
 	s += "│                                        │\n"
 
@@ -1217,17 +1595,11 @@ func (m *Model) viewFilter() string {
 	if m.filterCursor == clearCursor {
 		clearCursorStr = "> "
 	}
-	clearLine := fmt.Sprintf("%s[Clear]", clearCursorStr)
-	clearRuneCount := len(clearCursorStr) + len("[Clear]")
-	clearPadding := 38 - clearRuneCount
-	if clearPadding < 0 {
-		clearPadding = 0
-	}
-	s += fmt.Sprintf("│ %s%s │\n", clearLine, strings.Repeat(" ", clearPadding))
+	s += filterLine(fmt.Sprintf("%s[Clear]", clearCursorStr))
 
 	s += "│                                        │\n"
 	s += "│   [j/k]Move [Space]Select [Enter]Apply │\n"
-	s += "│   [Esc]Cancel                          │\n"
+	s += "│   [Tab]Type [Esc]Cancel                │\n"
 	s += "└────────────────────────────────────────┘"
 
 	return s
@@ -1236,11 +1608,47 @@ func (m *Model) viewFilter() string {
 // updateFilterMode handles input in filter mode
 func (m *Model) updateFilterMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Dynamic cursor positions
-	categoryStartCursor := 11
+	categoryStartCursor := filterRowCategories
 	searchCursor := categoryStartCursor + len(m.categories)
 	clearCursor := searchCursor + 1
 	maxCursor := clearCursor
 
+	// On a text row j/k still move until typing starts, with tab or any
+	// other printable key. While typing, esc or up/down stop it.
+	textRow := m.filterCursor == filterRowRangeFrom || m.filterCursor == filterRowRangeTo || m.filterCursor == searchCursor
+	if m.filterTyping {
+		switch msg.Type {
+		case tea.KeyEsc:
+			m.filterTyping = false
+			return m, nil
+		case tea.KeyEnter, tea.KeyUp, tea.KeyDown:
+			m.filterTyping = false
+		}
+	} else if textRow && msg.Type == tea.KeyTab {
+		m.filterTyping = true
+		return m, nil
+	} else if textRow && msg.Type == tea.KeyRunes && (msg.String() == "j" || msg.String() == "k") {
+		textRow = false
+	}
+	if textRow && (msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace) {
+		m.filterTyping = true
+		char := string(msg.Runes)
+		if msg.Type == tea.KeySpace {
+			char = " "
+		}
+		switch m.filterCursor {
+		case filterRowRangeFrom:
+			m.filterFromInput += char
+			m.applyCustomRange()
+		case filterRowRangeTo:
+			m.filterToInput += char
+			m.applyCustomRange()
+		default:
+			m.filter.SearchText += char
+		}
+		return m, nil
+	}
+
 	switch msg.String() {
 	case "j", "down":
 		if m.filterCursor < maxCursor {
@@ -1252,21 +1660,48 @@ func (m *Model) updateFilterMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.filterCursor--
 		}
 
+	case "+", "-":
+		// Adjust the "Next N Days" window
+		if m.filterCursor == filterRowDateRange+indexOfDateRange(domain.DateRangeNextDays) {
+			days := m.filter.NextDays
+			if days <= 0 {
+				days = domain.DefaultNextDays
+			}
+			if msg.String() == "+" && days < 365 {
+				days++
+			} else if msg.String() == "-" && days > 1 {
+				days--
+			}
+			m.filter.NextDays = days
+			m.filter.DateRange = domain.DateRangeNextDays
+		}
+
 	case " ":
 		// Toggle selection based on cursor position
 		switch {
-		case m.filterCursor >= 0 && m.filterCursor <= 2:
+		case m.filterCursor >= filterRowStatus && m.filterCursor < filterRowStatus+3:
 			// Status toggle
 			statusValues := []domain.TaskStatus{domain.TaskStatusNew, domain.TaskStatusWorking, domain.TaskStatusCompleted}
-			m.toggleFilterStatus(statusValues[m.filterCursor])
-		case m.filterCursor >= 3 && m.filterCursor <= 5:
+			m.toggleFilterStatus(statusValues[m.filterCursor-filterRowStatus])
+		case m.filterCursor >= filterRowPriority && m.filterCursor < filterRowPriority+3:
 			// Priority toggle
 			priorityValues := []domain.Priority{domain.PriorityHigh, domain.PriorityMedium, domain.PriorityLow}
-			m.toggleFilterPriority(priorityValues[m.filterCursor-3])
-		case m.filterCursor >= 6 && m.filterCursor <= 10:
+			m.toggleFilterPriority(priorityValues[m.filterCursor-filterRowPriority])
+		case m.filterCursor == filterRowDateField:
+			// Cycle date field
+			m.filter.DateField = (m.filter.DateField + 1) % 3
+			if m.filter.DateRange == domain.DateRangeNoDueDate && !m.filter.DateField.CanBeUnset() {
+				m.filter.DateRange = domain.DateRangeAll
+			}
+		case m.filterCursor >= filterRowDateRange && m.filterCursor < filterRowDateRange+len(dateRangeOptions):
 			// Date range selection (radio button)
-			dateValues := []domain.DateRange{domain.DateRangeAll, domain.DateRangeToday, domain.DateRangeThisWeek, domain.DateRangeOverdue, domain.DateRangeNoDueDate}
-			m.filter.DateRange = dateValues[m.filterCursor-6]
+			value := dateRangeOptions[m.filterCursor-filterRowDateRange]
+			if value != domain.DateRangeNoDueDate || m.filter.DateField.CanBeUnset() {
+				m.filter.DateRange = value
+			}
+		case m.filterCursor >= filterRowRetro && m.filterCursor < filterRowRetro+len(retroPresets):
+			// Retrospective preset (radio button)
+			m.applyRetroPreset(retroPresets[m.filterCursor-filterRowRetro])
 		case m.filterCursor >= categoryStartCursor && m.filterCursor < searchCursor:
 			// Category toggle
 			catIdx := m.filterCursor - categoryStartCursor
@@ -1275,7 +1710,7 @@ func (m *Model) updateFilterMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		case m.filterCursor == clearCursor:
 			// Clear filter
-			m.filter = domain.Filter{}
+			m.clearFilter()
 		}
 
 	case "enter":
@@ -1287,27 +1722,70 @@ func (m *Model) updateFilterMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.mode = m.previousMode
 
 	case "backspace":
-		// Delete character from search text
-		if m.filterCursor == searchCursor && len(m.filter.SearchText) > 0 {
-			// Handle multi-byte characters properly
-			runes := []rune(m.filter.SearchText)
-			m.filter.SearchText = string(runes[:len(runes)-1])
+		// Delete character from the text row under the cursor
+		switch m.filterCursor {
+		case filterRowRangeFrom:
+			m.filterFromInput = trimLastRune(m.filterFromInput)
+			m.applyCustomRange()
+		case filterRowRangeTo:
+			m.filterToInput = trimLastRune(m.filterToInput)
+			m.applyCustomRange()
+		case searchCursor:
+			m.filter.SearchText = trimLastRune(m.filter.SearchText)
 		}
+	}
 
-	default:
-		// Character input for search text
-		if m.filterCursor == searchCursor {
-			if len(msg.String()) == 1 {
-				m.filter.SearchText += msg.String()
-			} else if msg.Type == tea.KeySpace {
-				m.filter.SearchText += " "
-			} else if msg.Type == tea.KeyRunes {
-				m.filter.SearchText += string(msg.Runes)
-			}
+	return m, nil
+}
+
+// applyCustomRange parses the From/To inputs into the filter and selects the
+// custom range. Unparsable input leaves that side of the range open.
+func (m *Model) applyCustomRange() {
+	parse := func(input string) *time.Time {
+		if strings.TrimSpace(input) == "" {
+			return nil
+		}
+		date, err := domain.ParseDueDateWeekStart(input, time.Now(), m.weekStart())
+		if err != nil {
+			return nil
+		}
+		return &date
+	}
+	m.filter.RangeFrom = parse(m.filterFromInput)
+	m.filter.RangeTo = parse(m.filterToInput)
+	m.filter.DateRange = domain.DateRangeCustom
+}
+
+// clearFilter resets all filter criteria, keeping the configured week start
+func (m *Model) clearFilter() {
+	m.filter = domain.Filter{WeekStart: m.weekStart()}
+	m.filterFromInput = ""
+	m.filterToInput = ""
+	m.filterRetro = retroNone
+}
+
+// weekStart is the configured first day of the week
+func (m *Model) weekStart() time.Weekday {
+	return time.Weekday(m.config.WeekStart)
+}
+
+// indexOfDateRange returns the position of r in dateRangeOptions
+func indexOfDateRange(r domain.DateRange) int {
+	for i, option := range dateRangeOptions {
+		if option == r {
+			return i
 		}
 	}
+	return -1
+}
 
-	return m, nil
+// trimLastRune removes the last character, handling multi-byte characters
+func trimLastRune(s string) string {
+	runes := []rune(s)
+	if len(runes) == 0 {
+		return s
+	}
+	return string(runes[:len(runes)-1])
 }
 
 // hasFilterStatus checks if a status is in the filter
@@ -1411,6 +1889,10 @@ func (m *Model) viewSortMenu() string {
 }
 
 func (m *Model) viewEdit() string {
+	if m.datePickerOpen {
+		return m.datePicker.view(m.dueDateCounts())
+	}
+
 	s := "┌─ Edit Task ───────────────────────────┐\n"
 	s += "│                                        │\n"
 
@@ -1420,10 +1902,11 @@ func (m *Model) viewEdit() string {
 		value string
 	}{
 		{"Title", m.editTitle},
-		{"Description", m.editDesc},
+		{"Description", descSummary(m.editDesc.String())},
 		{"Priority", ""},    // Rendered specially
 		{"Category", ""},    // Rendered specially
 		{"Due Date", m.editDueDate},
+		{"Remind", m.editRemind},
 	}
 
 	for i, field := range fields {
@@ -1441,7 +1924,7 @@ func (m *Model) viewEdit() string {
 			// Category - show as selector
 			value = m.renderCategorySelector()
 		}
-		if m.editCursor == i && m.editingField && i != 2 && i != 3 {
+		if m.editCursor == i && m.editingField && i != 1 && i != 2 && i != 3 {
 			value += "█"
 		}
 		if value == "" && i != 2 && i != 3 {
@@ -1464,6 +1947,22 @@ func (m *Model) viewEdit() string {
 			padding = 0
 		}
 		s += fmt.Sprintf("│ %s%s │\n", line, strings.Repeat(" ", padding))
+
+		// The description opens into a text area while it is edited
+		if i == 1 && m.editCursor == 1 && m.editingField {
+			s += m.viewDescEditor()
+		}
+
+		// Live preview of the resolved due date or reminder
+		if i == m.editCursor && (i == 4 || i == 5) {
+			if preview := m.editInputPreview(); preview != "" {
+				padding := 38 - 15 - utf8.RuneCountInString(preview)
+				if padding < 0 {
+					padding = 0
+				}
+				s += fmt.Sprintf("│ %s%s%s │\n", strings.Repeat(" ", 15), preview, strings.Repeat(" ", padding))
+			}
+		}
 	}
 
 	s += "│                                        │\n"
@@ -1471,10 +1970,10 @@ func (m *Model) viewEdit() string {
 	// Save and Cancel buttons
 	saveCursor := "  "
 	cancelCursor := "  "
-	if m.editCursor == 5 {
+	if m.editCursor == 6 {
 		saveCursor = "> "
 	}
-	if m.editCursor == 6 {
+	if m.editCursor == 7 {
 		cancelCursor = "> "
 	}
 	s += fmt.Sprintf("│   %s[Save]  %s[Cancel]                 │\n", saveCursor, cancelCursor)
@@ -1487,12 +1986,69 @@ func (m *Model) viewEdit() string {
 	}
 
 	s += "│                                        │\n"
-	s += "│ [j/k]Move [Enter]Edit [Tab]Cycle [Esc] │\n"
+	if m.editCursor == 1 && m.editingField {
+		s += "│ [Esc]Done [Enter]Newline [^O]$EDITOR   │\n"
+	} else if m.editCursor == 1 {
+		s += "│ [j/k]Move [Enter]Type [^O]$EDITOR      │\n"
+	} else if m.editCursor == 4 {
+		s += "│ [j/k]Move [Enter]Type [Tab]Calendar    │\n"
+	} else {
+		s += "│ [j/k]Move [Enter]Edit [Tab]Cycle [Esc] │\n"
+	}
 	s += "└────────────────────────────────────────┘"
 
 	return s
 }
 
+// viewDescEditor renders the description text area and its counter
+func (m *Model) viewDescEditor() string {
+	var s string
+	for _, line := range m.editDesc.view(true) {
+		s += "│   " + line + " │\n"
+	}
+	counter := m.editDesc.counter()
+	padding := strings.Repeat(" ", 38-len(counter))
+	if m.editDesc.overLimit() {
+		counter = styles.Notice.Render(counter)
+	}
+	return s + "│ " + padding + counter + " │\n"
+}
+
+// descSummary is the first line of a description, marking that more follow
+func descSummary(desc string) string {
+	first, rest, found := strings.Cut(desc, "\n")
+	if found && strings.TrimSpace(rest) != "" {
+		return first + " ↵"
+	}
+	return first
+}
+
+// editInputPreview shows what the due date or reminder input under the
+// cursor resolves to, or empty if there is no input
+func (m *Model) editInputPreview() string {
+	switch m.editCursor {
+	case 4:
+		if strings.TrimSpace(m.editDueDate) == "" {
+			return ""
+		}
+		due, err := domain.ParseDueDateWeekStart(m.editDueDate, time.Now(), m.weekStart())
+		if err != nil {
+			return "→ (unrecognized)"
+		}
+		return "→ " + due.Format("Mon 2006-01-02")
+	case 5:
+		if strings.TrimSpace(m.editRemind) == "" {
+			return ""
+		}
+		at, err := domain.ParseReminder(m.editRemind, time.Now())
+		if err != nil {
+			return "→ (unrecognized)"
+		}
+		return "→ " + at.Format("Mon 01-02 15:04")
+	}
+	return ""
+}
+
 func (m *Model) renderPrioritySelector() string {
 	switch m.editPriority {
 	case domain.PriorityHigh:
@@ -1528,3 +2084,11 @@ func (m *Model) getCategoryName(task *domain.Task) string {
 	}
 	return ""
 }
+
+// viewNotice renders the notice line, or empty if there is none
+func (m *Model) viewNotice() string {
+	if m.notice == "" {
+		return ""
+	}
+	return styles.Notice.Render("⚠ "+m.notice) + "\n\n"
+}
@@ -0,0 +1,194 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hitsumabushi845/task-management/internal/domain"
+	"github.com/hitsumabushi845/task-management/internal/repository"
+)
+
+// cmdTimeout bounds how long run waits for a command. Ticks and event
+// subscriptions wait far longer and are dropped.
+const cmdTimeout = 100 * time.Millisecond
+
+// newTestModel creates a model on repo with its tasks and categories loaded
+func newTestModel(t *testing.T, repo domain.TaskRepository, opts ...Option) *Model {
+	t.Helper()
+	m := New(repo, opts...)
+	run(m, m.loadCategories())
+	run(m, m.loadTasks())
+	return m
+}
+
+// createTask stores a new task with the given title
+func createTask(t *testing.T, repo domain.TaskRepository, title string) *domain.Task {
+	t.Helper()
+	task := &domain.Task{Title: title, Status: domain.TaskStatusNew, Priority: domain.PriorityMedium}
+	if err := repo.Create(context.Background(), task); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	return task
+}
+
+// getTask reads a stored task
+func getTask(t *testing.T, repo domain.TaskRepository, id int64) *domain.Task {
+	t.Helper()
+	task, err := repo.GetByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetByID(%d) error = %v", id, err)
+	}
+	return task
+}
+
+// listedTask returns the model's copy of a task
+func listedTask(t *testing.T, m *Model, id int64) *domain.Task {
+	t.Helper()
+	for _, task := range m.tasks {
+		if task.ID == id {
+			return task
+		}
+	}
+	t.Fatalf("task %d is not listed", id)
+	return nil
+}
+
+// changeElsewhere updates a stored task behind the model's back
+func changeElsewhere(t *testing.T, repo domain.TaskRepository, id int64, change func(*domain.Task)) {
+	t.Helper()
+	task := getTask(t, repo, id)
+	change(task)
+	if err := repo.Update(context.Background(), task); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+}
+
+// update feeds msg to m, then every message its commands produce
+func update(m *Model, msg tea.Msg) {
+	_, cmd := m.Update(msg)
+	run(m, cmd)
+}
+
+// press sends a key to m, e.g. "x", "enter", "esc" or "down"
+func press(m *Model, key string) {
+	update(m, keyMsg(key))
+}
+
+// keyMsg is the message for a key named as in press
+func keyMsg(key string) tea.KeyMsg {
+	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	switch key {
+	case "enter":
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		msg = tea.KeyMsg{Type: tea.KeyEsc}
+	case "tab":
+		msg = tea.KeyMsg{Type: tea.KeyTab}
+	case "up":
+		msg = tea.KeyMsg{Type: tea.KeyUp}
+	case "down":
+		msg = tea.KeyMsg{Type: tea.KeyDown}
+	case " ":
+		msg = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(key)}
+	}
+	return msg
+}
+
+// run runs cmd and feeds its result to m
+func run(m *Model, cmd tea.Cmd) {
+	if cmd == nil {
+		return
+	}
+	result := make(chan tea.Msg, 1)
+	go func() { result <- cmd() }()
+
+	select {
+	case msg := <-result:
+		switch msg := msg.(type) {
+		case nil:
+		case tea.BatchMsg:
+			for _, cmd := range msg {
+				run(m, cmd)
+			}
+		default:
+			update(m, msg)
+		}
+	case <-time.After(cmdTimeout):
+	}
+}
+
+func TestFilterMode_TextRows(t *testing.T) {
+	repo := repository.NewMemoryRepository()
+	m := newTestModel(t, repo)
+	press(m, "f")
+	m.filterCursor = filterRowRangeFrom
+
+	// j/k move off a text row until typing starts
+	press(m, "j")
+	if m.filterCursor != filterRowRangeTo {
+		t.Fatalf("j moved to row %d, want %d", m.filterCursor, filterRowRangeTo)
+	}
+	press(m, "k")
+	if m.filterCursor != filterRowRangeFrom || m.filterFromInput != "" {
+		t.Fatalf("k: row %d, input %q; want row %d and no input", m.filterCursor, m.filterFromInput, filterRowRangeFrom)
+	}
+
+	// Once typing, j/k are characters; esc stops typing but stays open
+	searchRow := filterRowCategories + len(m.categories)
+	m.filterCursor = searchRow
+	press(m, "tab")
+	for _, key := range []string{"j", "o", "k", "e"} {
+		press(m, key)
+	}
+	if m.filter.SearchText != "joke" {
+		t.Errorf("SearchText = %q, want %q", m.filter.SearchText, "joke")
+	}
+	press(m, "esc")
+	if m.mode != viewModeFilter || m.filterTyping {
+		t.Fatalf("esc while typing: mode %d, typing %v; want the modal open and typing stopped", m.mode, m.filterTyping)
+	}
+	press(m, "k")
+	if m.filterCursor != searchRow-1 {
+		t.Errorf("k after typing moved to row %d, want %d", m.filterCursor, searchRow-1)
+	}
+
+	// Another key starts typing, and enter stops it and applies
+	m.filterCursor = searchRow
+	press(m, "s")
+	press(m, "enter")
+	if m.mode == viewModeFilter || m.filterTyping {
+		t.Errorf("enter while typing left the modal open")
+	}
+	if m.filter.SearchText != "jokes" {
+		t.Errorf("SearchText = %q, want %q", m.filter.SearchText, "jokes")
+	}
+}
+
+func TestFilterMode_NoDateForCreated(t *testing.T) {
+	repo := repository.NewMemoryRepository()
+	m := newTestModel(t, repo)
+	press(m, "f")
+
+	noDateRow := filterRowDateRange + indexOfDateRange(domain.DateRangeNoDueDate)
+	m.filterCursor = noDateRow
+	press(m, " ")
+	if m.filter.DateRange != domain.DateRangeNoDueDate {
+		t.Fatalf("DateRange = %d, want no due date", m.filter.DateRange)
+	}
+
+	// Switching to Created, which every task has, drops the range
+	m.filterCursor = filterRowDateField
+	press(m, " ")
+	if m.filter.DateField != domain.DateFieldCreated || m.filter.DateRange != domain.DateRangeAll {
+		t.Errorf("field %s, range %d; want Created and all", m.filter.DateField, m.filter.DateRange)
+	}
+
+	// And it can't be selected again
+	m.filterCursor = noDateRow
+	press(m, " ")
+	if m.filter.DateRange != domain.DateRangeAll {
+		t.Errorf("DateRange = %d after selecting not set for Created, want all", m.filter.DateRange)
+	}
+}
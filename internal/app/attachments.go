@@ -0,0 +1,144 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hitsumabushi845/task-management/internal/attachment"
+	"github.com/hitsumabushi845/task-management/internal/domain"
+	"github.com/hitsumabushi845/task-management/internal/ui/styles"
+)
+
+// WithAttachmentDir sets where attached files are copied when the config
+// asks for it. Without it files are always attached where they are.
+func WithAttachmentDir(dir string) Option {
+	return func(m *Model) {
+		m.attachmentDir = dir
+	}
+}
+
+// attachmentNoticeMsg reports an attachment that could not be added or
+// opened. Unlike errMsg it never ends the program.
+type attachmentNoticeMsg struct {
+	text string
+}
+
+// addAttachment attaches the file or URL typed in the detail pane to task,
+// copying files into the attachment directory if configured
+func (m *Model) addAttachment(task *domain.Task) tea.Cmd {
+	input := strings.TrimSpace(m.detailInput)
+	if input == "" {
+		return nil
+	}
+	copyFiles := m.config.CopyAttachments && m.attachmentDir != ""
+	dir := m.attachmentDir
+	return func() tea.Msg {
+		location, err := attachment.Resolve(input)
+		if err != nil {
+			return attachmentNoticeMsg{text: "Not attached: " + err.Error()}
+		}
+		copied := copyFiles && !domain.IsURL(location)
+		if copied {
+			if location, err = attachment.Copy(location, dir, task.ID); err != nil {
+				return attachmentNoticeMsg{text: "Not attached: " + err.Error()}
+			}
+		}
+
+		added := domain.Attachment{Location: location, AddedAt: time.Now()}
+		return detailEditMsg{edit: detailEdit{
+			id: task.ID,
+			apply: func(task *domain.Task) error {
+				task.Attachments = append(task.Attachments, added)
+				return nil
+			},
+			after: func(saved bool) {
+				if !saved && copied {
+					attachment.Discard(dir, location)
+				}
+			},
+		}}
+	}
+}
+
+// removeAttachment asks before removing the nth attachment of task,
+// counting from 1. A file copied into the attachment directory is deleted
+// with it; files attached where they are stay.
+func (m *Model) removeAttachment(task *domain.Task, n int) tea.Cmd {
+	if n < 1 || n > len(task.Attachments) {
+		return nil
+	}
+	i := n - 1
+	removed := task.Attachments[i]
+	var dropped bool
+	edit := detailEdit{
+		id: task.ID,
+		apply: func(task *domain.Task) error {
+			// Saves that finished while the dialog was open may have moved it
+			if i < len(task.Attachments) && task.Attachments[i].Location == removed.Location {
+				task.Attachments = append(task.Attachments[:i], task.Attachments[i+1:]...)
+				dropped = true
+			}
+			return nil
+		},
+		after: func(saved bool) {
+			if saved && dropped {
+				m.discardCopies([]domain.Attachment{removed})
+			}
+		},
+	}
+	return m.confirmThen("Remove attachment", fmt.Sprintf("Remove %s from the task?", removed.Name()), "Remove", sendEdit(edit))
+}
+
+// discardCopies deletes the files copied into the attachment directory for
+// attachments nothing refers to any more
+func (m *Model) discardCopies(attachments []domain.Attachment) {
+	for _, a := range attachments {
+		if !a.IsURL() {
+			attachment.Discard(m.attachmentDir, a.Location)
+		}
+	}
+}
+
+// openAttachment opens the nth attachment of task, counting from 1, with
+// the configured open command
+func (m *Model) openAttachment(task *domain.Task, n int) tea.Cmd {
+	if n < 1 || n > len(task.Attachments) {
+		return nil
+	}
+	command := m.config.OpenCommand
+	location := task.Attachments[n-1].Location
+	return func() tea.Msg {
+		if err := attachment.Open(command, location); err != nil {
+			return attachmentNoticeMsg{text: "Could not open attachment: " + err.Error()}
+		}
+		return nil
+	}
+}
+
+// viewAttachments renders the attachments section of the detail pane,
+// numbered for opening with the digit keys
+func (m *Model) viewAttachments(task *domain.Task) []string {
+	adding := m.detailAdding == detailInputAttachment
+	if len(task.Attachments) == 0 && !adding {
+		return []string{fmt.Sprintf("%-12s %s", "Attachments:", styles.MarkdownRule.Render("none; press f to attach a file or URL"))}
+	}
+
+	lines := []string{fmt.Sprintf("%-12s %d", "Attachments:", len(task.Attachments))}
+	for i, a := range task.Attachments {
+		key := " "
+		if i < 9 {
+			key = fmt.Sprint(i + 1)
+		}
+		line := "  " + styles.Selected.Render(key) + " " + a.Name()
+		if !a.IsURL() {
+			line += " " + styles.MarkdownRule.Render(a.Location)
+		}
+		lines = append(lines, line)
+	}
+	if adding {
+		lines = append(lines, m.viewDetailInput())
+	}
+	return lines
+}
@@ -0,0 +1,94 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hitsumabushi845/task-management/internal/config"
+	"github.com/hitsumabushi845/task-management/internal/domain"
+	"github.com/hitsumabushi845/task-management/internal/repository"
+)
+
+// newAttachmentModel opens the detail pane of a new task with attached
+// files copied into a temporary directory. It returns the directory and a
+// file to attach.
+func newAttachmentModel(t *testing.T, repo domain.TaskRepository) (m *Model, task *domain.Task, dir, src string) {
+	t.Helper()
+	src = filepath.Join(t.TempDir(), "spec.pdf")
+	if err := os.WriteFile(src, []byte("spec"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	dir = t.TempDir()
+	task = createTask(t, repo, "Review")
+	m = newTestModel(t, repo, WithConfig(config.Config{CopyAttachments: true}), WithAttachmentDir(dir))
+	press(m, "o")
+	return m, task, dir, src
+}
+
+// attach types src into the detail pane's attachment input
+func attach(m *Model, src string) {
+	press(m, "f")
+	press(m, src)
+	press(m, "enter")
+}
+
+func TestAttachments_RemoveDeletesCopy(t *testing.T) {
+	repo := repository.NewMemoryRepository()
+	m, task, dir, src := newAttachmentModel(t, repo)
+
+	attach(m, src)
+	got := getTask(t, repo, task.ID)
+	if len(got.Attachments) != 1 || filepath.Dir(filepath.Dir(got.Attachments[0].Location)) != dir {
+		t.Fatalf("attachments = %+v, want one copy in %s", got.Attachments, dir)
+	}
+	copied := got.Attachments[0].Location
+
+	press(m, "F")
+	press(m, "1")
+	if !m.confirmOpen {
+		t.Fatalf("removing an attachment did not ask first")
+	}
+	press(m, "y")
+	if got := getTask(t, repo, task.ID); len(got.Attachments) != 0 {
+		t.Errorf("attachments = %+v after removing, want none", got.Attachments)
+	}
+	if _, err := os.Stat(copied); !os.IsNotExist(err) {
+		t.Errorf("copy after removing the attachment: %v, want it deleted", err)
+	}
+	if _, err := os.Stat(src); err != nil {
+		t.Errorf("original file: %v, want it kept", err)
+	}
+}
+
+func TestAttachments_FailedSaveDeletesCopy(t *testing.T) {
+	repo := repository.NewMemoryRepository()
+	m, task, dir, src := newAttachmentModel(t, repo)
+
+	// The pane's task is out of date, so saving the attachment conflicts
+	changeElsewhere(t, repo, task.ID, func(task *domain.Task) { task.Title = "Renamed" })
+	attach(m, src)
+	if got := getTask(t, repo, task.ID); len(got.Attachments) != 0 {
+		t.Fatalf("attachments = %+v, want the conflicting save refused", got.Attachments)
+	}
+	if entries, err := os.ReadDir(dir); err != nil || len(entries) != 0 {
+		t.Errorf("attachment directory = %v, %v; want it empty", entries, err)
+	}
+}
+
+func TestAttachments_DeleteTaskDeletesCopies(t *testing.T) {
+	repo := repository.NewMemoryRepository()
+	m, task, dir, src := newAttachmentModel(t, repo)
+
+	attach(m, src)
+	press(m, "esc")
+	press(m, "d")
+	press(m, "y")
+	if len(m.tasks) != 0 {
+		t.Fatalf("listed %d tasks after deleting, want 0", len(m.tasks))
+	}
+	if _, err := os.Stat(filepath.Join(dir, fmt.Sprint(task.ID))); !os.IsNotExist(err) {
+		t.Errorf("copies after deleting the task: %v, want them deleted", err)
+	}
+}
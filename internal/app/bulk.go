@@ -0,0 +1,504 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hitsumabushi845/task-management/internal/domain"
+	"github.com/hitsumabushi845/task-management/internal/ui/styles"
+)
+
+// maxUndo bounds the undo history
+const maxUndo = 20
+
+// bulkMenu is the page of the bulk action modal that is open
+type bulkMenu int
+
+const (
+	bulkMenuActions bulkMenu = iota
+	bulkMenuStatus
+	bulkMenuPriority
+	bulkMenuCategory
+	bulkMenuDueDate
+)
+
+// bulkOp is a change applied to every marked task in one transaction
+type bulkOp struct {
+	verb   string // Past tense for the notice, e.g. "Archived"
+	delete bool   // Delete the tasks rather than apply
+	apply  func(task *domain.Task, now time.Time) error
+}
+
+// undoEntry restores the tasks changed by one bulk action
+type undoEntry struct {
+	verb    string
+	before  []*domain.Task  // The tasks as they were before the action
+	after   map[int64]int64 // The version each updated task was left at
+	deleted bool            // The action deleted them
+}
+
+// bulkDoneMsg reports a committed bulk action
+type bulkDoneMsg struct {
+	entry undoEntry
+}
+
+// undoneMsg reports a committed undo
+type undoneMsg struct {
+	entry   undoEntry
+	skipped int // Tasks left alone because they changed after the action
+}
+
+// purgedMsg reports how many archived tasks were deleted for good
+type purgedMsg struct {
+	count int
+}
+
+// undoFailedMsg reports an undo that was rolled back, so its entry can be
+// put back for another try
+type undoFailedMsg struct {
+	entry undoEntry
+	err   error
+}
+
+// updateBulkKeys handles marking and bulk action keys shared by the list
+// and kanban views. It reports whether it handled msg.
+func (m *Model) updateBulkKeys(msg tea.KeyMsg) (tea.Cmd, bool) {
+	switch msg.String() {
+	case "x":
+		// Toggle the mark on the selected task and move on to the next
+		if task := m.selectedTask(); task != nil {
+			m.toggleMark(task.ID)
+			if m.mode == viewModeKanban {
+				col := m.kanbanColumn
+				if m.kanbanCursors[col] < len(m.kanbanColumns()[col])-1 {
+					m.kanbanCursors[col]++
+				}
+			} else if m.cursor < len(m.visibleTasks())-1 {
+				m.cursor++
+			}
+		}
+		return nil, true
+
+	case "X":
+		// Mark every task matching the filter, or clear the marks if they
+		// all are already
+		visible := m.visibleTasks()
+		all := len(visible) > 0
+		for _, task := range visible {
+			all = all && m.marked[task.ID]
+		}
+		m.marked = map[int64]bool{}
+		if !all {
+			for _, task := range visible {
+				m.marked[task.ID] = true
+			}
+		}
+		return nil, true
+
+	case "b":
+		if len(m.marked) == 0 {
+			m.notice = "Mark tasks with x or X first"
+			return nil, true
+		}
+		m.previousMode = m.mode
+		m.mode = viewModeBulk
+		m.bulkMenu = bulkMenuActions
+		m.bulkDueDate = ""
+		return nil, true
+
+	case "u":
+		return m.undo(), true
+
+	case "P":
+		return m.confirmThen("Purge archive", "Delete every archived task? This cannot be undone.", "Purge", m.purgeArchived()), true
+
+	case "esc":
+		if len(m.marked) > 0 {
+			m.marked = nil
+			return nil, true
+		}
+	}
+	return nil, false
+}
+
+func (m *Model) toggleMark(id int64) {
+	if m.marked == nil {
+		m.marked = map[int64]bool{}
+	}
+	if m.marked[id] {
+		delete(m.marked, id)
+	} else {
+		m.marked[id] = true
+	}
+}
+
+// pruneMarks forgets marks on tasks that are no longer listed
+func (m *Model) pruneMarks() {
+	if len(m.marked) == 0 {
+		return
+	}
+	listed := make(map[int64]bool, len(m.tasks))
+	for _, task := range m.tasks {
+		listed[task.ID] = true
+	}
+	for id := range m.marked {
+		if !listed[id] {
+			delete(m.marked, id)
+		}
+	}
+}
+
+// markedIDs returns the marked tasks that are still in the list, in list order
+func (m *Model) markedIDs() []int64 {
+	var ids []int64
+	for _, task := range m.tasks {
+		if m.marked[task.ID] {
+			ids = append(ids, task.ID)
+		}
+	}
+	return ids
+}
+
+// updateBulkMode handles input in the bulk action modal
+func (m *Model) updateBulkMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	if key == "esc" {
+		if m.bulkMenu == bulkMenuActions {
+			m.mode = m.previousMode
+		} else {
+			m.bulkMenu = bulkMenuActions
+		}
+		return m, nil
+	}
+
+	var op *bulkOp
+	switch m.bulkMenu {
+	case bulkMenuActions:
+		switch key {
+		case "s":
+			m.bulkMenu = bulkMenuStatus
+		case "p":
+			m.bulkMenu = bulkMenuPriority
+		case "c":
+			m.bulkMenu = bulkMenuCategory
+		case "t":
+			m.bulkMenu = bulkMenuDueDate
+		case "a":
+			op = &bulkOp{verb: "Archived", apply: func(task *domain.Task, now time.Time) error {
+				task.ArchivedAt = &now
+				return nil
+			}}
+		case "D":
+			op = &bulkOp{verb: "Deleted", delete: true}
+		}
+
+	case bulkMenuStatus:
+		statuses := map[string]domain.TaskStatus{"n": domain.TaskStatusNew, "w": domain.TaskStatusWorking, "c": domain.TaskStatusCompleted}
+		if status, ok := statuses[key]; ok {
+			op = &bulkOp{verb: "Moved to " + string(status), apply: func(task *domain.Task, now time.Time) error {
+				return task.SetStatus(status, now)
+			}}
+		}
+
+	case bulkMenuPriority:
+		priorities := map[string]domain.Priority{"l": domain.PriorityLow, "m": domain.PriorityMedium, "h": domain.PriorityHigh}
+		if priority, ok := priorities[key]; ok {
+			op = &bulkOp{verb: "Set " + string(priority) + " priority on", apply: func(task *domain.Task, now time.Time) error {
+				task.Priority = priority
+				return nil
+			}}
+		}
+
+	case bulkMenuCategory:
+		// 0 removes the category, 1-9 pick one
+		if len(key) == 1 && key[0] >= '0' && key[0] <= '9' {
+			idx := int(key[0]-'0') - 1
+			if idx >= len(m.categories) {
+				return m, nil
+			}
+			var categoryID *int64
+			verb := "Removed the category of"
+			if idx >= 0 {
+				id := m.categories[idx].ID
+				categoryID = &id
+				verb = "Moved to @" + m.categories[idx].Name
+			}
+			op = &bulkOp{verb: verb, apply: func(task *domain.Task, now time.Time) error {
+				task.CategoryID = categoryID
+				return nil
+			}}
+		}
+
+	case bulkMenuDueDate:
+		switch msg.Type {
+		case tea.KeyEnter:
+			op = m.bulkDueDateOp()
+		case tea.KeyBackspace:
+			if runes := []rune(m.bulkDueDate); len(runes) > 0 {
+				m.bulkDueDate = string(runes[:len(runes)-1])
+			}
+		case tea.KeySpace:
+			m.bulkDueDate += " "
+		case tea.KeyRunes:
+			if len(m.bulkDueDate) < maxDueDateInputLen {
+				m.bulkDueDate += string(msg.Runes)
+			}
+		}
+	}
+
+	if op == nil {
+		return m, nil
+	}
+	m.mode = m.previousMode
+	if op.delete {
+		n := len(m.markedIDs())
+		return m, m.confirmThen("Delete tasks", fmt.Sprintf("Delete %s? Undo restores them and their notes with new IDs.", pluralTasks(n)), "Delete", m.runBulk(*op))
+	}
+	return m, m.runBulk(*op)
+}
+
+// bulkDueDateOp parses the due date input; empty clears the due date
+func (m *Model) bulkDueDateOp() *bulkOp {
+	input := strings.TrimSpace(m.bulkDueDate)
+	if input == "" {
+		return &bulkOp{verb: "Cleared the due date of", apply: func(task *domain.Task, now time.Time) error {
+			task.DueDate = nil
+			return nil
+		}}
+	}
+	due, err := domain.ParseDueDateWeekStart(input, time.Now(), m.weekStart())
+	if err != nil {
+		m.notice = "Invalid date (e.g. fri, +3d)"
+		return nil
+	}
+	return &bulkOp{verb: "Set the due date of", apply: func(task *domain.Task, now time.Time) error {
+		d := due
+		task.DueDate = &d
+		return nil
+	}}
+}
+
+// runBulk applies op to the marked tasks in one transaction, so either all
+// of them change or none do
+func (m *Model) runBulk(op bulkOp) tea.Cmd {
+	ids := m.markedIDs()
+	return func() tea.Msg {
+		ctx := context.Background()
+		now := time.Now()
+		var before []*domain.Task
+		var after map[int64]int64
+		err := m.repo.WithTx(ctx, func(tx domain.TaskRepository) error {
+			before = nil
+			after = map[int64]int64{}
+			for _, id := range ids {
+				task, err := tx.GetByID(ctx, id)
+				if errors.Is(err, domain.ErrNotFound) {
+					continue // Deleted elsewhere since it was marked
+				}
+				if err != nil {
+					return err
+				}
+				original := *task
+				before = append(before, &original)
+
+				if op.delete {
+					err = tx.Delete(ctx, id)
+				} else if err = op.apply(task, now); err == nil {
+					err = tx.Update(ctx, task)
+				}
+				if err != nil {
+					return fmt.Errorf("task #%d: %w", id, err)
+				}
+				after[id] = task.Version
+			}
+			return nil
+		})
+		if err != nil {
+			return errMsg{err: err}
+		}
+		return bulkDoneMsg{entry: undoEntry{verb: op.verb, before: before, after: after, deleted: op.delete}}
+	}
+}
+
+// undo reverts the most recent bulk action. Deleted tasks are restored
+// with new IDs. Tasks edited since the action are skipped rather than have
+// those edits overwritten. The entry is taken off the stack while the undo
+// runs, so a second u cannot apply it twice, and goes back if it fails.
+func (m *Model) undo() tea.Cmd {
+	if len(m.undoStack) == 0 {
+		m.notice = "Nothing to undo"
+		return nil
+	}
+	entry := m.undoStack[len(m.undoStack)-1]
+	m.undoStack = m.undoStack[:len(m.undoStack)-1]
+
+	return func() tea.Msg {
+		ctx := context.Background()
+		var skipped int
+		err := m.repo.WithTx(ctx, func(tx domain.TaskRepository) error {
+			skipped = 0
+			// Recreate deleted tasks oldest first, keeping their order
+			for i := range entry.before {
+				before := entry.before[i]
+				if entry.deleted {
+					before = entry.before[len(entry.before)-1-i]
+				}
+				restored := *before
+				if entry.deleted {
+					if err := tx.Restore(ctx, &restored); err != nil {
+						return err
+					}
+					continue
+				}
+
+				current, err := tx.GetByID(ctx, before.ID)
+				if errors.Is(err, domain.ErrNotFound) {
+					skipped++ // Deleted since; nothing to restore
+					continue
+				}
+				if err != nil {
+					return err
+				}
+				if current.Version != entry.after[before.ID] {
+					skipped++
+					continue
+				}
+				restored.Version = current.Version
+				if err := tx.Update(ctx, &restored); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return undoFailedMsg{entry: entry, err: err}
+		}
+		return undoneMsg{entry: entry, skipped: skipped}
+	}
+}
+
+// purgeArchived deletes every archived task in one transaction, then the
+// attachment copies nothing refers to any more. It cannot be undone.
+func (m *Model) purgeArchived() tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		var purged []*domain.Task
+		err := m.repo.WithTx(ctx, func(tx domain.TaskRepository) error {
+			purged = nil
+			tasks, err := tx.List(ctx)
+			if err != nil {
+				return err
+			}
+			for _, task := range tasks {
+				if task.ArchivedAt == nil {
+					continue
+				}
+				if err := tx.Delete(ctx, task.ID); err != nil {
+					return err
+				}
+				purged = append(purged, task)
+			}
+			return nil
+		})
+		if err != nil {
+			return errMsg{err: err}
+		}
+		for _, task := range purged {
+			m.discardCopies(task.Attachments)
+		}
+		return purgedMsg{count: len(purged)}
+	}
+}
+
+// finishBulk records a committed bulk action for undo
+func (m *Model) finishBulk(entry undoEntry) tea.Cmd {
+	m.marked = nil
+	m.undoStack = append(m.undoStack, entry)
+	if len(m.undoStack) > maxUndo {
+		m.undoStack = m.undoStack[1:]
+	}
+	m.notice = fmt.Sprintf("%s %s; press u to undo", entry.verb, pluralTasks(len(entry.before)))
+	if m.events == nil {
+		return m.loadTasks()
+	}
+	return nil
+}
+
+func pluralTasks(n int) string {
+	if n == 1 {
+		return "1 task"
+	}
+	return fmt.Sprintf("%d tasks", n)
+}
+
+// viewMarkedBar replaces the status bar while tasks are marked
+func (m *Model) viewMarkedBar() string {
+	text := fmt.Sprintf("%s marked  [x]Mark [X]All [b]Bulk actions [u]Undo [Esc]Clear", pluralTasks(len(m.marked)))
+	return styles.StatusBar.Render(text) + "\n"
+}
+
+// viewBulk renders the bulk action modal
+func (m *Model) viewBulk() string {
+	s := fmt.Sprintf("┌─ Bulk: %-13s ─────────────────┐\n", pluralTasks(len(m.marked)))
+	line := func(text string) {
+		s += fmt.Sprintf("│ %-37s │\n", text)
+	}
+
+	switch m.bulkMenu {
+	case bulkMenuActions:
+		line("s  Change status")
+		line("p  Change priority")
+		line("c  Change category")
+		line("t  Set due date")
+		line("a  Archive")
+		line("D  Delete")
+	case bulkMenuStatus:
+		line("n  New")
+		line("w  Working")
+		line("c  Completed")
+	case bulkMenuPriority:
+		line("l  Low")
+		line("m  Medium")
+		line("h  High")
+	case bulkMenuCategory:
+		line("0  (none)")
+		for i, cat := range m.categories {
+			if i == 9 {
+				break
+			}
+			line(fmt.Sprintf("%d  %s", i+1, cat.Name))
+		}
+	case bulkMenuDueDate:
+		line("Due date: " + m.bulkDueDate + "█")
+		if preview := m.bulkDueDatePreview(); preview != "" {
+			line("          " + preview)
+		}
+		line("")
+		line("Enter applies; empty clears")
+	}
+
+	line("")
+	if m.bulkMenu == bulkMenuActions {
+		line("[Esc] Close")
+	} else {
+		line("[Esc] Back")
+	}
+	s += "└───────────────────────────────────────┘"
+	return m.viewNotice() + s
+}
+
+// bulkDueDatePreview shows what the bulk due date input resolves to
+func (m *Model) bulkDueDatePreview() string {
+	if strings.TrimSpace(m.bulkDueDate) == "" {
+		return ""
+	}
+	due, err := domain.ParseDueDateWeekStart(m.bulkDueDate, time.Now(), m.weekStart())
+	if err != nil {
+		return "→ (unrecognized)"
+	}
+	return "→ " + due.Format("Mon 2006-01-02")
+}
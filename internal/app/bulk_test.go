@@ -0,0 +1,192 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hitsumabushi845/task-management/internal/config"
+	"github.com/hitsumabushi845/task-management/internal/domain"
+	"github.com/hitsumabushi845/task-management/internal/repository"
+)
+
+// bulk marks every listed task and runs the bulk action picked by keys
+func bulk(m *Model, keys ...string) {
+	press(m, "X")
+	press(m, "b")
+	for _, key := range keys {
+		press(m, key)
+	}
+}
+
+func TestBulk_UndoUpdate(t *testing.T) {
+	repo := repository.NewMemoryRepository()
+	a := createTask(t, repo, "A")
+	b := createTask(t, repo, "B")
+	m := newTestModel(t, repo)
+
+	bulk(m, "p", "h")
+	for _, id := range []int64{a.ID, b.ID} {
+		if got := getTask(t, repo, id); got.Priority != domain.PriorityHigh {
+			t.Fatalf("task %d priority = %s after the bulk action, want high", id, got.Priority)
+		}
+	}
+
+	press(m, "u")
+	for _, id := range []int64{a.ID, b.ID} {
+		if got := getTask(t, repo, id); got.Priority != domain.PriorityMedium {
+			t.Errorf("task %d priority = %s after undo, want medium", id, got.Priority)
+		}
+	}
+	if !strings.HasPrefix(m.notice, "Undid") {
+		t.Errorf("notice = %q, want the undo reported", m.notice)
+	}
+}
+
+func TestBulk_UndoSkipsTasksChangedSince(t *testing.T) {
+	repo := repository.NewMemoryRepository()
+	a := createTask(t, repo, "A")
+	b := createTask(t, repo, "B")
+	m := newTestModel(t, repo)
+
+	bulk(m, "p", "h")
+	changeElsewhere(t, repo, a.ID, func(task *domain.Task) { task.Title = "Edited" })
+
+	press(m, "u")
+	if got := getTask(t, repo, a.ID); got.Title != "Edited" || got.Priority != domain.PriorityHigh {
+		t.Errorf("edited task = %q %s after undo, want the edit kept", got.Title, got.Priority)
+	}
+	if got := getTask(t, repo, b.ID); got.Priority != domain.PriorityMedium {
+		t.Errorf("untouched task priority = %s after undo, want medium", got.Priority)
+	}
+	if !strings.Contains(m.notice, "skipped 1 task") {
+		t.Errorf("notice = %q, want the skipped task reported", m.notice)
+	}
+}
+
+func TestBulk_UndoDeleteKeepsTimestampsAndNotes(t *testing.T) {
+	repo := repository.NewMemoryRepository()
+	a := createTask(t, repo, "A")
+	created := getTask(t, repo, a.ID).CreatedAt
+	if err := repo.AddNote(context.Background(), &domain.Note{TaskID: a.ID, Text: "Keep me"}); err != nil {
+		t.Fatalf("AddNote() error = %v", err)
+	}
+	m := newTestModel(t, repo)
+
+	bulk(m, "D", "y")
+	if _, err := repo.GetByID(context.Background(), a.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("GetByID() after the bulk delete error = %v, want ErrNotFound", err)
+	}
+
+	press(m, "u")
+	if len(m.tasks) != 1 {
+		t.Fatalf("listed %d tasks after undo, want 1", len(m.tasks))
+	}
+	restored := getTask(t, repo, m.tasks[0].ID)
+	if restored.Title != "A" || !restored.CreatedAt.Equal(created) {
+		t.Errorf("restored %q created %v, want %q created %v", restored.Title, restored.CreatedAt, "A", created)
+	}
+	if len(restored.Notes) != 1 || restored.Notes[0].Text != "Keep me" {
+		t.Errorf("restored notes = %+v, want the note back", restored.Notes)
+	}
+}
+
+// failingTxRepository fails every transaction while fail is set
+type failingTxRepository struct {
+	domain.TaskRepository
+	fail bool
+}
+
+func (r *failingTxRepository) WithTx(ctx context.Context, fn func(domain.TaskRepository) error) error {
+	if r.fail {
+		return errors.New("disk I/O error")
+	}
+	return r.TaskRepository.WithTx(ctx, fn)
+}
+
+func TestBulk_FailedUndoCanBeRetried(t *testing.T) {
+	repo := &failingTxRepository{TaskRepository: repository.NewMemoryRepository()}
+	a := createTask(t, repo, "A")
+	m := newTestModel(t, repo)
+
+	bulk(m, "p", "h")
+	repo.fail = true
+	press(m, "u")
+	if m.err == nil {
+		t.Fatalf("failed undo reported no error")
+	}
+	if got := getTask(t, repo, a.ID); got.Priority != domain.PriorityHigh {
+		t.Fatalf("priority = %s after the failed undo, want high", got.Priority)
+	}
+
+	repo.fail = false
+	press(m, "u")
+	if got := getTask(t, repo, a.ID); got.Priority != domain.PriorityMedium {
+		t.Errorf("priority = %s after retrying the undo, want medium", got.Priority)
+	}
+}
+
+func TestBulk_ArchiveHidesTasks(t *testing.T) {
+	repo := repository.NewMemoryRepository()
+	a := createTask(t, repo, "A")
+	m := newTestModel(t, repo)
+
+	bulk(m, "a")
+	if len(m.tasks) != 0 {
+		t.Errorf("listed %d tasks after archiving, want 0", len(m.tasks))
+	}
+	if got := getTask(t, repo, a.ID); got.ArchivedAt == nil {
+		t.Errorf("ArchivedAt not set")
+	}
+
+	press(m, "u")
+	listedTask(t, m, a.ID)
+}
+
+func TestPurgeArchived(t *testing.T) {
+	repo := repository.NewMemoryRepository()
+	a := createTask(t, repo, "Archived")
+	b := createTask(t, repo, "Kept")
+	changeElsewhere(t, repo, a.ID, func(task *domain.Task) {
+		now := time.Now()
+		task.ArchivedAt = &now
+	})
+	m := newTestModel(t, repo)
+
+	press(m, "P")
+	if !m.confirmOpen {
+		t.Fatalf("purging did not ask first")
+	}
+	press(m, "n")
+	getTask(t, repo, a.ID)
+
+	press(m, "P")
+	press(m, "y")
+	if _, err := repo.GetByID(context.Background(), a.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("GetByID() of the archived task error = %v, want ErrNotFound", err)
+	}
+	getTask(t, repo, b.ID)
+	if m.notice != "Purged 1 task from the archive" {
+		t.Errorf("notice = %q, want the purge reported", m.notice)
+	}
+}
+
+func TestPurgeArchived_SkipConfirm(t *testing.T) {
+	repo := repository.NewMemoryRepository()
+	a := createTask(t, repo, "Archived")
+	changeElsewhere(t, repo, a.ID, func(task *domain.Task) {
+		now := time.Now()
+		task.ArchivedAt = &now
+	})
+	m := newTestModel(t, repo, WithConfig(config.Config{SkipConfirm: true}))
+
+	press(m, "P")
+	if m.confirmOpen {
+		t.Errorf("purging asked with skip_confirm set")
+	}
+	if _, err := repo.GetByID(context.Background(), a.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("GetByID() of the archived task error = %v, want ErrNotFound", err)
+	}
+}
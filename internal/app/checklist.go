@@ -0,0 +1,108 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hitsumabushi845/task-management/internal/domain"
+	"github.com/hitsumabushi845/task-management/internal/ui/styles"
+)
+
+// checklistProgress is the "3/7" shown in list rows and kanban cells, or
+// empty for a task without a checklist
+func checklistProgress(task *domain.Task) string {
+	done, total := task.ChecklistProgress()
+	if total == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d", done, total)
+}
+
+// renderChecklistProgress styles the progress, green once every item is done
+func renderChecklistProgress(task *domain.Task) string {
+	progress := checklistProgress(task)
+	if task.ChecklistDone() {
+		return styles.StatusCompleted.Render(progress)
+	}
+	return styles.MarkdownRule.Render(progress)
+}
+
+// toggleChecklistItem checks or unchecks the selected item of the detail
+// pane's task, completing the task when the last item is checked if the
+// config asks for it
+func (m *Model) toggleChecklistItem(task *domain.Task) tea.Cmd {
+	if m.detailItem >= len(task.Checklist) {
+		return nil
+	}
+	i := m.detailItem
+	return m.saveEdit(detailEdit{id: task.ID, apply: func(task *domain.Task) error {
+		if i >= len(task.Checklist) {
+			return nil
+		}
+		task.Checklist[i].Done = !task.Checklist[i].Done
+		if m.config.AutoCompleteChecklist && task.ChecklistDone() && task.Status != domain.TaskStatusCompleted {
+			if err := task.SetStatus(domain.TaskStatusCompleted, time.Now()); err != nil {
+				return err
+			}
+			m.notice = "All items done; task completed"
+		}
+		return nil
+	}})
+}
+
+// addChecklistItem appends the item typed in the detail pane
+func (m *Model) addChecklistItem(task *domain.Task) tea.Cmd {
+	text := strings.TrimSpace(m.detailInput)
+	if text == "" {
+		return nil
+	}
+	m.detailItem = len(task.Checklist)
+	return m.saveEdit(detailEdit{id: task.ID, apply: func(task *domain.Task) error {
+		task.Checklist = append(task.Checklist, domain.ChecklistItem{Text: text})
+		return nil
+	}})
+}
+
+// removeChecklistItem asks before dropping the selected item
+func (m *Model) removeChecklistItem(task *domain.Task) tea.Cmd {
+	if m.detailItem >= len(task.Checklist) {
+		return nil
+	}
+	i := m.detailItem
+	text := task.Checklist[i].Text
+	edit := detailEdit{id: task.ID, apply: func(task *domain.Task) error {
+		// Saves that finished while the dialog was open may have moved it
+		if i < len(task.Checklist) && task.Checklist[i].Text == text {
+			task.Checklist = append(task.Checklist[:i], task.Checklist[i+1:]...)
+		}
+		return nil
+	}}
+	return m.confirmThen("Remove item", fmt.Sprintf("Remove %q from the checklist?", text), "Remove", sendEdit(edit))
+}
+
+// viewChecklist renders the checklist section of the detail pane
+func (m *Model) viewChecklist(task *domain.Task) []string {
+	adding := m.detailAdding == detailInputItem
+	if len(task.Checklist) == 0 && !adding {
+		return []string{fmt.Sprintf("%-12s %s", "Checklist:", styles.MarkdownRule.Render("none; press a to add an item"))}
+	}
+
+	lines := []string{fmt.Sprintf("%-12s %s", "Checklist:", renderChecklistProgress(task))}
+	for i, item := range task.Checklist {
+		box, style := "☐", styles.Normal
+		if item.Done {
+			box, style = "☑", styles.MarkdownDone
+		}
+		line := "  " + box + " " + style.Render(item.Text)
+		if i == clamp(m.detailItem, len(task.Checklist)) && !adding {
+			line = styles.Selected.Render("> " + box + " " + item.Text)
+		}
+		lines = append(lines, line)
+	}
+	if adding {
+		lines = append(lines, m.viewDetailInput())
+	}
+	return lines
+}
@@ -0,0 +1,80 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hitsumabushi845/task-management/internal/domain"
+	"github.com/hitsumabushi845/task-management/internal/repository"
+)
+
+func TestChecklist_RemoveAsksFirst(t *testing.T) {
+	repo := repository.NewMemoryRepository()
+	task := &domain.Task{
+		Title:     "Release",
+		Status:    domain.TaskStatusNew,
+		Priority:  domain.PriorityMedium,
+		Checklist: []domain.ChecklistItem{{Text: "Tag"}, {Text: "Publish"}},
+	}
+	if err := repo.Create(context.Background(), task); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	m := newTestModel(t, repo)
+	press(m, "o")
+	press(m, "tab")
+
+	// Cancelling keeps the item
+	press(m, "d")
+	if !m.confirmOpen {
+		t.Fatalf("removing an item did not ask first")
+	}
+	press(m, "n")
+	if got := getTask(t, repo, task.ID); len(got.Checklist) != 2 {
+		t.Fatalf("checklist = %+v after cancelling, want both items", got.Checklist)
+	}
+
+	press(m, "d")
+	press(m, "y")
+	got := getTask(t, repo, task.ID)
+	if len(got.Checklist) != 1 || got.Checklist[0].Text != "Tag" {
+		t.Errorf("checklist = %+v, want only Tag", got.Checklist)
+	}
+	if !m.detailOpen {
+		t.Errorf("detail pane closed after removing an item")
+	}
+}
+
+func TestChecklist_QuickTogglesSaveInTurn(t *testing.T) {
+	repo := repository.NewMemoryRepository()
+	task := &domain.Task{
+		Title:     "Release",
+		Status:    domain.TaskStatusNew,
+		Priority:  domain.PriorityMedium,
+		Checklist: []domain.ChecklistItem{{Text: "Tag"}, {Text: "Publish"}},
+	}
+	if err := repo.Create(context.Background(), task); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	m := newTestModel(t, repo)
+	press(m, "o")
+
+	// Both keys arrive before the first save has finished
+	_, first := m.Update(keyMsg("x"))
+	m.Update(keyMsg("tab"))
+	_, second := m.Update(keyMsg("x"))
+	if second != nil {
+		t.Fatalf("second toggle saved while the first was running")
+	}
+	run(m, first)
+
+	if m.err != nil || m.notice != "" {
+		t.Fatalf("err %v, notice %q after two toggles; want neither", m.err, m.notice)
+	}
+	got := getTask(t, repo, task.ID)
+	if !got.Checklist[0].Done || !got.Checklist[1].Done {
+		t.Errorf("checklist = %+v, want both items done", got.Checklist)
+	}
+	if listed := listedTask(t, m, task.ID); listed.Version != got.Version {
+		t.Errorf("listed version %d, want the stored %d", listed.Version, got.Version)
+	}
+}
@@ -0,0 +1,137 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hitsumabushi845/task-management/internal/ui/styles"
+)
+
+// confirmWidth is the text width inside the confirmation dialog
+const confirmWidth = 37
+
+// confirmDialog asks before a destructive action runs. The action is a
+// command prepared when the dialog opens, so it acts on what the user saw.
+type confirmDialog struct {
+	title   string  // e.g. "Delete task"
+	message string  // e.g. `Delete "Write docs"?`
+	label   string  // Confirm button, e.g. "Delete"
+	action  tea.Cmd // Runs on confirmation
+	yes     bool    // Confirm button highlighted; the dialog opens on Cancel
+}
+
+// newConfirmDialog creates a dialog that runs action when confirmed
+func newConfirmDialog(title, message, label string, action tea.Cmd) confirmDialog {
+	return confirmDialog{title: title, message: message, label: label, action: action}
+}
+
+// update handles a key. done reports that the dialog should close; cmd is
+// the action if it was confirmed.
+func (d *confirmDialog) update(msg tea.KeyMsg) (done bool, cmd tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		return true, d.action
+	case "n", "N", "esc", "q":
+		return true, nil
+	case "enter":
+		if d.yes {
+			return true, d.action
+		}
+		return true, nil
+	case "tab", "shift+tab", "left", "right", "h", "l":
+		d.yes = !d.yes
+	}
+	return false, nil
+}
+
+// view renders the dialog
+func (d confirmDialog) view() string {
+	title := "─ " + d.title + " "
+	fill := confirmWidth + 2 - len([]rune(title))
+	if fill < 0 {
+		fill = 0
+	}
+	s := "┌" + title + strings.Repeat("─", fill) + "┐\n"
+	line := func(text string) {
+		s += fmt.Sprintf("│ %-*s │\n", confirmWidth, text)
+	}
+
+	line("")
+	for _, l := range wrapText(d.message, confirmWidth) {
+		line(l)
+	}
+	line("")
+
+	confirm, cancel := styles.Normal, styles.Selected
+	if d.yes {
+		confirm, cancel = styles.Selected, styles.Normal
+	}
+	buttons := "[y] " + d.label + "   [n] Cancel"
+	rendered := confirm.Render("[y] "+d.label) + "   " + cancel.Render("[n] Cancel")
+	pad := confirmWidth - len([]rune(buttons))
+	if pad < 0 {
+		pad = 0
+	}
+	s += "│ " + rendered + strings.Repeat(" ", pad) + " │\n"
+	s += "└" + strings.Repeat("─", confirmWidth+2) + "┘\n"
+	return s
+}
+
+// wrapText breaks text into lines of at most width runes at spaces,
+// splitting words that are longer than a line
+func wrapText(text string, width int) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		current := ""
+		for _, word := range strings.Fields(paragraph) {
+			for len([]rune(word)) > width {
+				if current != "" {
+					lines = append(lines, current)
+					current = ""
+				}
+				runes := []rune(word)
+				lines = append(lines, string(runes[:width]))
+				word = string(runes[width:])
+			}
+			switch {
+			case current == "":
+				current = word
+			case len([]rune(current))+1+len([]rune(word)) <= width:
+				current += " " + word
+			default:
+				lines = append(lines, current)
+				current = word
+			}
+		}
+		lines = append(lines, current)
+	}
+	return lines
+}
+
+// confirmThen returns action straight away when confirmations are turned
+// off in the config; otherwise it opens a dialog that runs action if the
+// user agrees
+func (m *Model) confirmThen(title, message, label string, action tea.Cmd) tea.Cmd {
+	if m.config.SkipConfirm {
+		return action
+	}
+	m.confirm = newConfirmDialog(title, message, label, action)
+	m.confirmOpen = true
+	return nil
+}
+
+// updateConfirm handles input while the confirmation dialog is open
+func (m *Model) updateConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	done, cmd := m.confirm.update(msg)
+	if done {
+		m.confirmOpen = false
+		m.confirm = confirmDialog{}
+	}
+	return m, cmd
+}
+
+// confirmDelete asks before deleting task
+func (m *Model) confirmDelete(id int64, title string) tea.Cmd {
+	return m.confirmThen("Delete task", fmt.Sprintf("Delete %q? This cannot be undone.", title), "Delete", m.deleteTask(id))
+}
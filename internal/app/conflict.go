@@ -0,0 +1,144 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hitsumabushi845/task-management/internal/domain"
+)
+
+// editConflictMsg is sent when saving the edit form failed because the task
+// was changed elsewhere. latest is the stored version; base and mine are
+// the form as opened and as saved.
+type editConflictMsg struct {
+	latest     *domain.Task
+	base, mine editForm
+}
+
+// storeEditedTask stores the task of the edit form. If it was changed
+// elsewhere since the form was opened, the form is reopened on the latest
+// version with the user's changes merged in.
+func (m *Model) storeEditedTask(task *domain.Task) tea.Cmd {
+	base := m.editFormOf(&m.editBase)
+	mine := m.currentEditForm()
+	return func() tea.Msg {
+		err := m.repo.Update(context.Background(), task)
+		if errors.Is(err, domain.ErrConflict) {
+			latest, err := m.repo.GetByID(context.Background(), task.ID)
+			if err != nil {
+				return errMsg{err: err}
+			}
+			return editConflictMsg{latest: latest, base: base, mine: mine}
+		}
+		if err != nil {
+			return errMsg{err: err}
+		}
+		return taskUpdatedMsg{task: task}
+	}
+}
+
+// editForm holds the values of the edit form's fields
+type editForm struct {
+	title       string
+	desc        string
+	priority    domain.Priority
+	categoryIdx int
+	dueDate     string
+	remind      string
+}
+
+// editFormOf returns the form values that show task
+func (m *Model) editFormOf(task *domain.Task) editForm {
+	f := editForm{
+		title:       task.Title,
+		desc:        task.Description,
+		priority:    task.Priority,
+		categoryIdx: -1,
+	}
+	if task.CategoryID != nil {
+		for i, cat := range m.categories {
+			if cat.ID == *task.CategoryID {
+				f.categoryIdx = i
+				break
+			}
+		}
+	}
+	if task.DueDate != nil {
+		f.dueDate = task.DueDate.Format("2006-01-02")
+	}
+	if task.RemindAt != nil {
+		f.remind = task.RemindAt.Local().Format("2006-01-02 15:04")
+	}
+	return f
+}
+
+func (m *Model) currentEditForm() editForm {
+	return editForm{
+		title:       m.editTitle,
+		desc:        m.editDesc.String(),
+		priority:    m.editPriority,
+		categoryIdx: m.editCategoryIdx,
+		dueDate:     m.editDueDate,
+		remind:      m.editRemind,
+	}
+}
+
+func (m *Model) setEditForm(f editForm) {
+	m.editTitle = f.title
+	m.editDesc = newTextArea(f.desc, domain.MaxDescriptionLen, descWidth, descHeight)
+	m.editPriority = f.priority
+	m.editCategoryIdx = f.categoryIdx
+	m.editDueDate = f.dueDate
+	m.editRemind = f.remind
+}
+
+// mergeEditForms keeps the fields changed in mine since base and takes the
+// rest from theirs
+func mergeEditForms(base, mine, theirs editForm) editForm {
+	merged := theirs
+	if mine.title != base.title {
+		merged.title = mine.title
+	}
+	if mine.desc != base.desc {
+		merged.desc = mine.desc
+	}
+	if mine.priority != base.priority {
+		merged.priority = mine.priority
+	}
+	if mine.categoryIdx != base.categoryIdx {
+		merged.categoryIdx = mine.categoryIdx
+	}
+	if mine.dueDate != base.dueDate {
+		merged.dueDate = mine.dueDate
+	}
+	if mine.remind != base.remind {
+		merged.remind = mine.remind
+	}
+	return merged
+}
+
+// resolveEditConflict reopens the edit form on the latest version of the
+// task, keeping the user's changes, so they can review and save again
+func (m *Model) resolveEditConflict(msg editConflictMsg) {
+	latest := msg.latest
+	// Saving wrote the rejected values into the listed task; replace it
+	for i, task := range m.tasks {
+		if task.ID == latest.ID {
+			m.tasks[i] = latest
+			break
+		}
+	}
+
+	// Keep a form the user has opened since saving, and tell them there
+	if m.mode == viewModeEdit {
+		m.editError = fmt.Sprintf("#%d changed elsewhere; edit not saved", latest.ID)
+		return
+	}
+
+	m.startEditMode(latest)
+	m.setEditForm(mergeEditForms(msg.base, msg.mine, m.editFormOf(latest)))
+	m.editCursor = 6 // Save
+	m.editError = "Changed elsewhere; reloaded"
+}
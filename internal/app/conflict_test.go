@@ -0,0 +1,126 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hitsumabushi845/task-management/internal/domain"
+	"github.com/hitsumabushi845/task-management/internal/repository"
+)
+
+func TestMergeEditForms(t *testing.T) {
+	base := editForm{title: "Title", desc: "Desc", priority: domain.PriorityMedium, categoryIdx: -1, dueDate: "2026-11-03", remind: ""}
+
+	tests := []struct {
+		name   string
+		mine   func(*editForm)
+		theirs func(*editForm)
+		want   func(*editForm)
+	}{
+		{
+			name:   "only theirs changed",
+			mine:   func(f *editForm) {},
+			theirs: func(f *editForm) { f.title = "Their title" },
+			want:   func(f *editForm) { f.title = "Their title" },
+		},
+		{
+			name:   "different fields changed",
+			mine:   func(f *editForm) { f.priority = domain.PriorityHigh; f.remind = "+1h" },
+			theirs: func(f *editForm) { f.desc = "Their desc"; f.categoryIdx = 2 },
+			want: func(f *editForm) {
+				f.priority = domain.PriorityHigh
+				f.remind = "+1h"
+				f.desc = "Their desc"
+				f.categoryIdx = 2
+			},
+		},
+		{
+			name:   "same field changed keeps mine",
+			mine:   func(f *editForm) { f.title = "My title"; f.dueDate = "" },
+			theirs: func(f *editForm) { f.title = "Their title"; f.dueDate = "2026-12-01" },
+			want:   func(f *editForm) { f.title = "My title"; f.dueDate = "" },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mine, theirs, want := base, base, base
+			tt.mine(&mine)
+			tt.theirs(&theirs)
+			tt.want(&want)
+			if got := mergeEditForms(base, mine, theirs); got != want {
+				t.Errorf("mergeEditForms() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestSaveEdit_Conflict(t *testing.T) {
+	repo := repository.NewMemoryRepository()
+	task := createTask(t, repo, "Title")
+	m := newTestModel(t, repo)
+
+	m.startEditMode(listedTask(t, m, task.ID))
+	m.editTitle = "My title"
+	changeElsewhere(t, repo, task.ID, func(task *domain.Task) { task.Description = "Their desc" })
+	_, cmd := m.saveEditedTask()
+	run(m, cmd)
+
+	// The form is back on the latest version with both changes
+	if m.mode != viewModeEdit {
+		t.Fatalf("mode = %v after a conflict, want the edit form", m.mode)
+	}
+	if m.editTitle != "My title" || m.editDesc.String() != "Their desc" {
+		t.Errorf("form title %q, description %q; want both changes", m.editTitle, m.editDesc.String())
+	}
+	if m.editTask.Version != getTask(t, repo, task.ID).Version {
+		t.Errorf("form version %d, want the stored %d", m.editTask.Version, getTask(t, repo, task.ID).Version)
+	}
+}
+
+func TestSaveEdit_ConflictWhileEditingAnotherTask(t *testing.T) {
+	repo := repository.NewMemoryRepository()
+	first := createTask(t, repo, "First")
+	second := createTask(t, repo, "Second")
+	m := newTestModel(t, repo)
+
+	m.startEditMode(listedTask(t, m, first.ID))
+	m.editTitle = "My first"
+	update(m, editConflictMsg{latest: getTask(t, repo, second.ID)})
+
+	if m.mode != viewModeEdit || m.editTask.ID != first.ID || m.editTitle != "My first" {
+		t.Errorf("editing #%d with title %q, want the form of #%d kept", m.editTask.ID, m.editTitle, first.ID)
+	}
+	if !strings.Contains(m.editError, "not saved") {
+		t.Errorf("editError = %q, want the lost edit reported", m.editError)
+	}
+}
+
+func TestSnoozeConflict_DoesNotOpenEditForm(t *testing.T) {
+	repo := repository.NewMemoryRepository()
+	edited := createTask(t, repo, "Edited")
+	reminded := createTask(t, repo, "Reminded")
+	m := newTestModel(t, repo)
+
+	// A cancelled edit leaves its form behind
+	m.startEditMode(listedTask(t, m, edited.ID))
+	m.editTitle = "Leftover title"
+	press(m, "esc")
+
+	changeElsewhere(t, repo, reminded.ID, func(task *domain.Task) { task.Title = "Changed elsewhere" })
+	m.activeReminders = []*domain.Task{listedTask(t, m, reminded.ID)}
+	run(m, m.snoozeReminder())
+
+	if m.mode == viewModeEdit {
+		t.Fatalf("a snooze conflict opened the edit form")
+	}
+	if m.notice == "" {
+		t.Errorf("no notice after a snooze conflict")
+	}
+	if got := getTask(t, repo, edited.ID).Title; got != "Edited" {
+		t.Errorf("edited task title = %q, want it unchanged", got)
+	}
+	if got := listedTask(t, m, reminded.ID).Title; got != "Changed elsewhere" {
+		t.Errorf("listed title = %q, want the reloaded one", got)
+	}
+}
@@ -0,0 +1,183 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hitsumabushi845/task-management/internal/domain"
+	"github.com/hitsumabushi845/task-management/internal/ui/styles"
+)
+
+// datePicker is a month-grid calendar for choosing a due date
+type datePicker struct {
+	selected  time.Time // Highlighted day (midnight, local time)
+	today     time.Time
+	weekStart time.Weekday
+}
+
+// newDatePicker opens the picker on initial, or on today if initial is zero
+func newDatePicker(initial, now time.Time, weekStart time.Weekday) datePicker {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	selected := today
+	if !initial.IsZero() {
+		selected = time.Date(initial.Year(), initial.Month(), initial.Day(), 0, 0, 0, 0, now.Location())
+	}
+	return datePicker{
+		selected:  selected,
+		today:     today,
+		weekStart: weekStart,
+	}
+}
+
+// moveDays moves the highlight by n days
+func (p *datePicker) moveDays(n int) {
+	p.selected = p.selected.AddDate(0, 0, n)
+}
+
+// moveMonths moves the highlight by n months, keeping the day where possible
+func (p *datePicker) moveMonths(n int) {
+	first := time.Date(p.selected.Year(), p.selected.Month()+time.Month(n), 1, 0, 0, 0, 0, p.selected.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+	day := p.selected.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	p.selected = time.Date(first.Year(), first.Month(), day, 0, 0, 0, 0, first.Location())
+}
+
+// view renders the month grid; dueCounts maps "2006-01-02" to the number of tasks due that day
+func (p datePicker) view(dueCounts map[string]int) string {
+	const innerWidth = 22
+
+	line := func(content string, width int) string {
+		padding := innerWidth - width
+		if padding < 0 {
+			padding = 0
+		}
+		return "│" + content + strings.Repeat(" ", padding) + "│\n"
+	}
+
+	s := "┌─ Due Date " + strings.Repeat("─", innerWidth-11) + "┐\n"
+
+	title := p.selected.Format("January 2006")
+	left := (innerWidth - len(title)) / 2
+	s += line(strings.Repeat(" ", left)+title, left+len(title))
+
+	// Weekday header
+	header := " "
+	for i := 0; i < 7; i++ {
+		wd := time.Weekday((int(p.weekStart) + i) % 7)
+		header += wd.String()[:2]
+		if i < 6 {
+			header += " "
+		}
+	}
+	s += line(header, len(header))
+
+	// Day grid
+	first := time.Date(p.selected.Year(), p.selected.Month(), 1, 0, 0, 0, 0, p.selected.Location())
+	offset := (int(first.Weekday()) - int(p.weekStart) + 7) % 7
+	daysInMonth := first.AddDate(0, 1, -1).Day()
+
+	row := " " + strings.Repeat("   ", offset)
+	width := 1 + 3*offset
+	col := offset
+	for day := 1; day <= daysInMonth; day++ {
+		date := first.AddDate(0, 0, day-1)
+		cell := fmt.Sprintf("%2d", day)
+
+		style := styles.Normal
+		if dueCounts[date.Format("2006-01-02")] > 0 {
+			style = styles.CalendarHasTasks
+		}
+		if date.Equal(p.today) {
+			style = styles.CalendarToday.Inherit(style)
+		}
+		if date.Equal(p.selected) {
+			style = styles.CalendarSelected
+		}
+		cell = style.Render(cell)
+
+		row += cell
+		width += 2
+		col++
+		if col == 7 || day == daysInMonth {
+			s += line(row, width)
+			row = " "
+			width = 1
+			col = 0
+		} else {
+			row += " "
+			width++
+		}
+	}
+
+	s += line("", 0)
+
+	selectedInfo := " " + p.selected.Format("Mon 2006-01-02")
+	if n := dueCounts[p.selected.Format("2006-01-02")]; n > 0 {
+		selectedInfo += fmt.Sprintf(" (%d due)", n)
+	}
+	s += line(selectedInfo, len(selectedInfo))
+
+	s += line("", 0)
+	s += line(" [h/l]Day [j/k]Week", 19)
+	s += line(" [H/L]Month [t]Today", 20)
+	s += line(" [Enter]Pick [x]Clear", 21)
+	s += line(" [Esc]Cancel", 12)
+	s += "└" + strings.Repeat("─", innerWidth) + "┘"
+
+	return s
+}
+
+// openDatePicker shows the calendar for the due date being edited
+func (m *Model) openDatePicker() {
+	var initial time.Time
+	if due, err := domain.ParseDueDateWeekStart(m.editDueDate, time.Now(), m.weekStart()); err == nil {
+		initial = due
+	}
+	m.datePicker = newDatePicker(initial, time.Now(), m.weekStart())
+	m.datePickerOpen = true
+}
+
+// updateDatePicker handles input while the calendar is open
+func (m *Model) updateDatePicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "h", "left":
+		m.datePicker.moveDays(-1)
+	case "l", "right":
+		m.datePicker.moveDays(1)
+	case "k", "up":
+		m.datePicker.moveDays(-7)
+	case "j", "down":
+		m.datePicker.moveDays(7)
+	case "H", "pgup", "[":
+		m.datePicker.moveMonths(-1)
+	case "L", "pgdown", "]":
+		m.datePicker.moveMonths(1)
+	case "t":
+		m.datePicker.selected = m.datePicker.today
+	case "enter":
+		m.editDueDate = m.datePicker.selected.Format("2006-01-02")
+		m.datePickerOpen = false
+	case "x", "delete":
+		m.editDueDate = ""
+		m.datePickerOpen = false
+	case "esc":
+		m.datePickerOpen = false
+	}
+	return m, nil
+}
+
+// dueDateCounts counts tasks per due day, keyed by "2006-01-02"
+func (m *Model) dueDateCounts() map[string]int {
+	counts := make(map[string]int)
+	for _, task := range m.tasks {
+		if task.DueDate != nil {
+			counts[task.DueDate.Format("2006-01-02")]++
+		}
+	}
+	return counts
+}
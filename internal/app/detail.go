@@ -0,0 +1,535 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hitsumabushi845/task-management/internal/domain"
+	"github.com/hitsumabushi845/task-management/internal/ui/markdown"
+	"github.com/hitsumabushi845/task-management/internal/ui/styles"
+)
+
+// Size of the detail pane when the terminal size is not known yet, and the
+// widest it gets
+const (
+	detailDefaultWidth  = 80
+	detailDefaultHeight = 24
+	detailMaxWidth      = 100
+)
+
+// detailInputKind is what the detail pane's input line is adding, if anything
+type detailInputKind int
+
+const (
+	detailInputNone       detailInputKind = iota
+	detailInputItem                       // A checklist item
+	detailInputNote                       // A note
+	detailInputAttachment                 // A file path or URL
+)
+
+// detailEdit is a change made in the detail pane. Edits are saved one at a
+// time, each applied to the task the previous one stored, so that quick key
+// presses neither conflict with nor undo each other.
+type detailEdit struct {
+	id    int64
+	apply func(task *domain.Task) error // Changes a copy of the listed task
+	after func(saved bool)              // Optional; runs once the save has finished or been dropped
+}
+
+// detailEditMsg hands saveEdit an edit made in a command, such as a
+// confirmed removal
+type detailEditMsg struct {
+	edit detailEdit
+}
+
+// detailSavedMsg reports a finished detail pane save
+type detailSavedMsg struct {
+	task *domain.Task
+	err  error
+}
+
+// sendEdit returns a command that hands edit to saveEdit
+func sendEdit(edit detailEdit) tea.Cmd {
+	return func() tea.Msg {
+		return detailEditMsg{edit: edit}
+	}
+}
+
+// saveEdit applies edit to a copy of the listed task and stores it, or
+// queues the edit while an earlier one is being saved
+func (m *Model) saveEdit(edit detailEdit) tea.Cmd {
+	if m.detailSaving {
+		m.detailEdits = append(m.detailEdits, edit)
+		return nil
+	}
+	finish := func(saved bool) {
+		if edit.after != nil {
+			edit.after(saved)
+		}
+	}
+
+	var listed *domain.Task
+	for _, task := range m.tasks {
+		if task.ID == edit.id {
+			listed = task
+			break
+		}
+	}
+	if listed == nil {
+		// Deleted or archived since the edit was made
+		return func() tea.Msg {
+			finish(false)
+			return nil
+		}
+	}
+	updated := *listed
+	updated.Checklist = append([]domain.ChecklistItem(nil), listed.Checklist...)
+	updated.Attachments = append([]domain.Attachment(nil), listed.Attachments...)
+	if err := edit.apply(&updated); err != nil {
+		return func() tea.Msg {
+			finish(false)
+			return errMsg{err: err}
+		}
+	}
+
+	m.detailSaving = true
+	return func() tea.Msg {
+		err := m.repo.Update(context.Background(), &updated)
+		finish(err == nil)
+		return detailSavedMsg{task: &updated, err: err}
+	}
+}
+
+// finishDetailSave lists the task a detail pane save stored and starts the
+// next queued edit on it. Edits queued behind a failed save are dropped, as
+// they were made on top of it.
+func (m *Model) finishDetailSave(msg detailSavedMsg) tea.Cmd {
+	m.detailSaving = false
+	queued := m.detailEdits
+	m.detailEdits = nil
+
+	var cmds []tea.Cmd
+	if msg.err != nil {
+		for _, edit := range queued {
+			if after := edit.after; after != nil {
+				cmds = append(cmds, func() tea.Msg {
+					after(false)
+					return nil
+				})
+			}
+		}
+		_, cmd := m.Update(errMsg{err: msg.err})
+		return tea.Batch(append(cmds, cmd)...)
+	}
+
+	for i, task := range m.tasks {
+		// An event may already have listed a later change
+		if task.ID == msg.task.ID && task.Version <= msg.task.Version {
+			m.tasks[i] = msg.task
+		}
+	}
+	for _, edit := range queued {
+		cmds = append(cmds, m.saveEdit(edit))
+	}
+	return tea.Batch(cmds...)
+}
+
+// openDetail shows the detail pane for task over the list or kanban view
+func (m *Model) openDetail(task *domain.Task) {
+	m.detailOpen = true
+	m.detailID = task.ID
+	m.detailScroll = 0
+	m.detailItem = 0
+	m.detailAdding = detailInputNone
+	m.detailInput = ""
+	m.detailDetach = false
+}
+
+// detailTask is the task shown in the detail pane, or nil if it has been
+// deleted or archived since it was opened
+func (m *Model) detailTask() *domain.Task {
+	for _, task := range m.tasks {
+		if task.ID == m.detailID {
+			return task
+		}
+	}
+	return nil
+}
+
+// updateDetail handles input while the detail pane is open
+func (m *Model) updateDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	task := m.detailTask()
+	if task != nil {
+		// The checklist may have shrunk since the item was selected
+		m.detailItem = clamp(m.detailItem, len(task.Checklist))
+	}
+	if m.detailAdding != detailInputNone && task != nil {
+		cmd := m.updateDetailInput(msg, task)
+		m.followDetailInput(task)
+		return m, cmd
+	}
+
+	if m.detailDetach {
+		// The key after F picks the attachment to remove, or cancels
+		m.detailDetach = false
+		if key := msg.String(); task != nil && len(key) == 1 && key[0] >= '1' && key[0] <= '9' {
+			return m, m.removeAttachment(task, int(key[0]-'0'))
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	switch msg.String() {
+	case "q", "esc", "enter", "o":
+		m.detailOpen = false
+	case "ctrl+c":
+		return m, tea.Quit
+	case "j", "down":
+		m.detailScroll++
+	case "k", "up":
+		m.detailScroll--
+	case "pgdown":
+		m.detailScroll += m.detailBodyHeight()
+	case "pgup":
+		m.detailScroll -= m.detailBodyHeight()
+	case "g", "home":
+		m.detailScroll = 0
+	case "e":
+		// Editing returns here when it is saved or cancelled
+		if task != nil {
+			m.startEditMode(task)
+		}
+	case "tab", "]":
+		if task != nil && len(task.Checklist) > 0 {
+			m.detailItem = (m.detailItem + 1) % len(task.Checklist)
+			m.followDetailInput(task)
+		}
+	case "shift+tab", "[":
+		if task != nil && len(task.Checklist) > 0 {
+			m.detailItem = (m.detailItem + len(task.Checklist) - 1) % len(task.Checklist)
+			m.followDetailInput(task)
+		}
+	case "x", " ":
+		if task != nil {
+			cmd = m.toggleChecklistItem(task)
+		}
+	case "a":
+		if task != nil && len(task.Checklist) < domain.MaxChecklistItems {
+			m.detailAdding = detailInputItem
+			m.followDetailInput(task)
+		}
+	case "n":
+		if task != nil {
+			m.detailAdding = detailInputNote
+			m.followDetailInput(task)
+		}
+	case "f":
+		if task != nil && len(task.Attachments) < domain.MaxAttachments {
+			m.detailAdding = detailInputAttachment
+			m.followDetailInput(task)
+		}
+	case "F":
+		if task != nil && len(task.Attachments) > 0 {
+			m.detailDetach = true
+		}
+	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		if task != nil {
+			cmd = m.openAttachment(task, int(msg.String()[0]-'0'))
+		}
+	case "d":
+		if task != nil {
+			cmd = m.removeChecklistItem(task)
+		}
+	}
+	m.detailScroll = clamp(m.detailScroll, len(m.detailBody(task))-m.detailBodyHeight()+1)
+	return m, cmd
+}
+
+// updateDetailInput handles typing a new checklist item, note or attachment
+func (m *Model) updateDetailInput(msg tea.KeyMsg, task *domain.Task) tea.Cmd {
+	limit := domain.MaxTitleLen
+	switch m.detailAdding {
+	case detailInputNote:
+		limit = domain.MaxNoteLen
+	case detailInputAttachment:
+		limit = domain.MaxAttachmentLen
+	}
+
+	switch msg.Type {
+	case tea.KeyEnter:
+		var cmd tea.Cmd
+		switch m.detailAdding {
+		case detailInputNote:
+			cmd = m.addNote(task)
+		case detailInputAttachment:
+			cmd = m.addAttachment(task)
+		default:
+			cmd = m.addChecklistItem(task)
+		}
+		m.detailAdding = detailInputNone
+		m.detailInput = ""
+		return cmd
+	case tea.KeyEsc:
+		m.detailAdding = detailInputNone
+		m.detailInput = ""
+	case tea.KeyBackspace:
+		m.detailInput = trimLastRune(m.detailInput)
+	case tea.KeySpace:
+		if len(m.detailInput) < limit {
+			m.detailInput += " "
+		}
+	case tea.KeyRunes:
+		if len(m.detailInput)+len(string(msg.Runes)) <= limit {
+			m.detailInput += string(msg.Runes)
+		}
+	}
+	return nil
+}
+
+// viewDetailInput renders the input line, showing the end of long input
+func (m *Model) viewDetailInput() string {
+	input := []rune(m.detailInput + "█")
+	if room := m.detailWidth() - 4; len(input) > room {
+		input = input[len(input)-room:]
+	}
+	return styles.Selected.Render("+ ") + string(input)
+}
+
+// followDetailInput scrolls so that the input line is visible, or the
+// selected checklist item when nothing is being typed
+func (m *Model) followDetailInput(task *domain.Task) {
+	var line int
+	switch m.detailAdding {
+	case detailInputNote:
+		line = len(m.detailBody(task)) - 1
+	case detailInputItem:
+		line = m.checklistStart(task) + 1 + len(task.Checklist)
+	case detailInputAttachment:
+		line = m.attachmentsStart(task) + 1 + len(task.Attachments)
+	default:
+		line = m.checklistStart(task) + 1 + m.detailItem
+	}
+	if line < m.detailScroll {
+		m.detailScroll = line
+	}
+	if line >= m.detailScroll+m.detailBodyHeight() {
+		m.detailScroll = line - m.detailBodyHeight() + 1
+	}
+}
+
+// detailWidth is the width of the pane's text
+func (m *Model) detailWidth() int {
+	width := m.width
+	if width <= 0 {
+		width = detailDefaultWidth
+	}
+	if width > detailMaxWidth {
+		width = detailMaxWidth
+	}
+	return width - 2
+}
+
+// detailBodyHeight is how many body lines fit between the title and the
+// status bar
+func (m *Model) detailBodyHeight() int {
+	height := m.height
+	if height <= 0 {
+		height = detailDefaultHeight
+	}
+	if height < 8 {
+		return 4
+	}
+	return height - 4
+}
+
+// viewDetail renders the detail pane, scrolled to detailScroll
+func (m *Model) viewDetail() string {
+	task := m.detailTask()
+	if task == nil {
+		return "Task Management\n\n" + styles.Notice.Render("⚠ This task was deleted or archived") + "\n\n" +
+			styles.StatusBar.Render("[Esc]Back") + "\n"
+	}
+
+	s := styles.Selected.Render(fmt.Sprintf("#%d %s", task.ID, task.Title)) + "\n"
+	// The notice takes the blank line under the title
+	if m.notice != "" {
+		s += styles.Notice.Render("⚠ " + m.notice)
+	}
+	s += "\n"
+
+	// The body may have shrunk since it was scrolled
+	body := m.detailBody(task)
+	start := m.detailScroll
+	if start > len(body) {
+		start = len(body)
+	}
+	end := start + m.detailBodyHeight()
+	if end > len(body) {
+		end = len(body)
+	}
+	for _, line := range body[start:end] {
+		if line != "" {
+			s += " " + line
+		}
+		s += "\n"
+	}
+	for i := end - start; i < m.detailBodyHeight(); i++ {
+		s += "\n"
+	}
+
+	help := "[j/k]Scroll [Tab]Item [x]Check [a]Add [d]Remove [n]Note [f/F]Attach/Detach [1-9]Open [e]Edit [Esc]Back"
+	if m.detailDetach {
+		help = "[1-9]Remove attachment [Esc]Cancel"
+	}
+	switch m.detailAdding {
+	case detailInputItem:
+		help = "[Enter]Add item [Esc]Cancel"
+	case detailInputNote:
+		help = "[Enter]Add note [Esc]Cancel"
+	case detailInputAttachment:
+		help = "[Enter]Attach file path or URL [Esc]Cancel"
+	}
+	if len(body) > m.detailBodyHeight() {
+		help = fmt.Sprintf("%d-%d of %d  ", start+1, end, len(body)) + help
+	}
+	return s + styles.StatusBar.Render(help) + "\n"
+}
+
+// detailBody renders the metadata, checklist, attachments, description and
+// notes of task as lines
+func (m *Model) detailBody(task *domain.Task) []string {
+	if task == nil {
+		return nil
+	}
+	width := m.detailWidth()
+	now := time.Now()
+
+	var lines []string
+	field := func(label, value string) {
+		lines = append(lines, fmt.Sprintf("%-12s %s", label+":", value))
+	}
+
+	field("Status", string(task.Status))
+	field("Priority", string(task.Priority))
+	if name := m.getCategoryName(task); name != "" {
+		field("Category", "@"+name)
+	} else {
+		field("Category", "(none)")
+	}
+	if task.DueDate != nil {
+		field("Due", task.DueDate.Format("Mon 2006-01-02")+" "+dueRelative(*task.DueDate, now))
+	}
+	if task.RemindAt != nil {
+		field("Remind", task.RemindAt.Local().Format("Mon 2006-01-02 15:04"))
+	}
+	lines = append(lines, "")
+
+	stamp := func(t time.Time) string {
+		return t.Local().Format("2006-01-02 15:04")
+	}
+	field("Created", stamp(task.CreatedAt))
+	if task.StartedAt != nil {
+		field("Started", stamp(*task.StartedAt))
+	}
+	if task.CompletedAt != nil {
+		field("Completed", stamp(*task.CompletedAt))
+	}
+	if task.ArchivedAt != nil {
+		field("Archived", stamp(*task.ArchivedAt))
+	}
+
+	// Time spent waiting, in progress and overall; open spans run to now
+	switch {
+	case task.StartedAt != nil && task.CompletedAt != nil:
+		field("Waited", elapsed(task.CreatedAt, *task.StartedAt)+" before starting")
+		field("Worked", elapsed(*task.StartedAt, *task.CompletedAt)+" from start to completion")
+		field("Total", elapsed(task.CreatedAt, *task.CompletedAt)+" from creation to completion")
+	case task.StartedAt != nil:
+		field("Waited", elapsed(task.CreatedAt, *task.StartedAt)+" before starting")
+		field("Working", elapsed(*task.StartedAt, now)+" so far")
+	case task.CompletedAt != nil:
+		field("Total", elapsed(task.CreatedAt, *task.CompletedAt)+" from creation to completion")
+	default:
+		field("Open", elapsed(task.CreatedAt, now)+" so far")
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, m.viewChecklist(task)...)
+	lines = append(lines, "")
+	lines = append(lines, m.viewAttachments(task)...)
+
+	lines = append(lines, "", styles.MarkdownRule.Render(strings.Repeat("─", width)), "")
+	if strings.TrimSpace(task.Description) == "" {
+		lines = append(lines, styles.MarkdownRule.Render("No description. Press e to add one."))
+	} else {
+		lines = append(lines, strings.Split(markdown.Render(task.Description, width), "\n")...)
+	}
+
+	lines = append(lines, "", styles.MarkdownRule.Render(strings.Repeat("─", width)), "")
+	lines = append(lines, m.viewNotes(task)...)
+	return lines
+}
+
+// checklistStart is the line of detailBody holding the checklist heading
+func (m *Model) checklistStart(task *domain.Task) int {
+	return m.detailHeading(task, "Checklist:")
+}
+
+// attachmentsStart is the line of detailBody holding the attachments heading
+func (m *Model) attachmentsStart(task *domain.Task) int {
+	return m.detailHeading(task, "Attachments:")
+}
+
+// detailHeading finds the line of detailBody starting with heading
+func (m *Model) detailHeading(task *domain.Task, heading string) int {
+	body := m.detailBody(task)
+	for i, line := range body {
+		if strings.HasPrefix(line, heading) {
+			return i
+		}
+	}
+	return 0
+}
+
+// elapsed formats the time from start to end in days, hours and minutes,
+// e.g. "2d 3h" or "45m"
+func elapsed(start, end time.Time) string {
+	d := end.Sub(start)
+	if d < time.Minute {
+		return "<1m"
+	}
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
+
+// dueRelative describes a due date against today, e.g. "(in 3 days)"
+func dueRelative(due, now time.Time) string {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	day := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, now.Location())
+	days := int(math.Round(day.Sub(today).Hours() / 24)) // Days can be 23 or 25 hours
+	switch {
+	case days == 0:
+		return "(today)"
+	case days == 1:
+		return "(tomorrow)"
+	case days > 1:
+		return fmt.Sprintf("(in %d days)", days)
+	case days == -1:
+		return "(overdue by 1 day)"
+	default:
+		return fmt.Sprintf("(overdue by %d days)", -days)
+	}
+}
@@ -0,0 +1,75 @@
+package app
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// editorDoneMsg carries the description back from the external editor
+type editorDoneMsg struct {
+	text string
+	err  error
+}
+
+// externalEditor is the command in $VISUAL or $EDITOR, split into words so
+// that settings such as "code --wait" work, falling back to vi
+func externalEditor() []string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(env)); len(fields) > 0 {
+			return fields
+		}
+	}
+	return []string{"vi"}
+}
+
+// openEditor suspends the TUI and edits the description in the external
+// editor through a temporary file
+func (m *Model) openEditor() tea.Cmd {
+	f, err := os.CreateTemp("", "task-description-*.md")
+	if err != nil {
+		return func() tea.Msg { return editorDoneMsg{err: err} }
+	}
+	path := f.Name()
+	_, err = f.WriteString(m.editDesc.String())
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return func() tea.Msg { return editorDoneMsg{err: err} }
+	}
+
+	editor := externalEditor()
+	cmd := exec.Command(editor[0], append(editor[1:], path)...)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		defer os.Remove(path)
+		if err != nil {
+			return editorDoneMsg{err: err}
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return editorDoneMsg{err: err}
+		}
+		// Editors end the file with a newline the description never had
+		return editorDoneMsg{text: strings.TrimRight(string(data), "\r\n")}
+	})
+}
+
+// finishEditor puts the edited text into the form
+func (m *Model) finishEditor(msg editorDoneMsg) {
+	if m.mode != viewModeEdit {
+		return
+	}
+	if msg.err != nil {
+		m.editError = "Editor failed: " + msg.err.Error()
+		return
+	}
+	m.editDesc.setValue(msg.text)
+	m.editError = ""
+	if m.editDesc.overLimit() {
+		m.editError = "Description is too long"
+	}
+}
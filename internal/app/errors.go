@@ -0,0 +1,42 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hitsumabushi845/task-management/internal/domain"
+	"github.com/hitsumabushi845/task-management/internal/hooks"
+)
+
+// errorNotice returns the notice for an error the user can recover from:
+// the change was not saved, but the app can carry on after reloading
+func errorNotice(err error) (string, bool) {
+	var veto *hooks.VetoError
+	var verr *domain.ValidationError
+	switch {
+	case errors.As(err, &veto):
+		return veto.Error(), true
+	case errors.Is(err, domain.ErrConflict):
+		return "The task was changed elsewhere; reloaded the latest version", true
+	case errors.Is(err, domain.ErrNotFound):
+		return "The task no longer exists; it may have been deleted elsewhere", true
+	case errors.Is(err, domain.ErrDuplicateCategory):
+		return "A category with that name already exists", true
+	case errors.As(err, &verr):
+		return fmt.Sprintf("Not saved: %s", verr.Message), true
+	}
+	return "", false
+}
+
+// editFieldCursor maps a validated field to its row in the edit form
+func editFieldCursor(field string) (int, bool) {
+	switch field {
+	case "title":
+		return 0, true
+	case "description":
+		return 1, true
+	case "priority":
+		return 2, true
+	}
+	return 0, false
+}
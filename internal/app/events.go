@@ -0,0 +1,81 @@
+package app
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hitsumabushi845/task-management/internal/domain"
+)
+
+// taskEventMsg carries a change published by the repository
+type taskEventMsg struct {
+	event domain.Event
+}
+
+// WithEvents keeps the task list current from repository events instead of
+// reloading it after every change. events is usually a subscription to a
+// repository.EventBus installed with repository.WithEvents.
+func WithEvents(events <-chan domain.Event) Option {
+	return func(m *Model) {
+		m.events = events
+	}
+}
+
+// waitForEvent delivers the next repository event, or nothing once the
+// subscription is closed
+func (m *Model) waitForEvent() tea.Cmd {
+	if m.events == nil {
+		return nil
+	}
+	events := m.events
+	return func() tea.Msg {
+		e, ok := <-events
+		if !ok {
+			return nil
+		}
+		return taskEventMsg{event: e}
+	}
+}
+
+// applyEvent updates the in-memory list the way a reload would
+func (m *Model) applyEvent(e domain.Event) {
+	switch e.Type {
+	case domain.EventTaskCreated:
+		// A reload after the event was published may already list it
+		for i, task := range m.tasks {
+			if task.ID == e.Task.ID {
+				m.tasks[i] = e.Task
+				return
+			}
+		}
+		// List returns the newest task first
+		m.tasks = append([]*domain.Task{e.Task}, m.tasks...)
+	case domain.EventTaskUpdated, domain.EventTaskStatusChanged:
+		if e.Task.ArchivedAt != nil {
+			m.removeTask(e.Task.ID)
+			return
+		}
+		for i, task := range m.tasks {
+			if task.ID == e.Task.ID {
+				// A detail pane save may already have listed a later version
+				if e.Task.Version >= task.Version {
+					m.tasks[i] = e.Task
+				}
+				return
+			}
+		}
+		m.tasks = append(m.tasks, e.Task)
+	case domain.EventTaskDeleted:
+		m.removeTask(e.Task.ID)
+	}
+}
+
+// removeTask drops a task from the in-memory list
+func (m *Model) removeTask(id int64) {
+	for i, task := range m.tasks {
+		if task.ID == id {
+			m.tasks = append(m.tasks[:i], m.tasks[i+1:]...)
+			break
+		}
+	}
+	delete(m.marked, id)
+	m.clampCursors()
+}
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hitsumabushi845/task-management/internal/domain"
+	"github.com/hitsumabushi845/task-management/internal/repository"
+)
+
+func TestTaskEvent_DroppedReloads(t *testing.T) {
+	repo := repository.NewMemoryRepository()
+	events := make(chan domain.Event)
+	m := newTestModel(t, repo, WithEvents(events))
+
+	// Changes whose events were missed show up after the drop notice
+	task := createTask(t, repo, "Missed")
+	if len(m.tasks) != 0 {
+		t.Fatalf("listed %d tasks before the notice, want 0", len(m.tasks))
+	}
+	update(m, taskEventMsg{event: domain.Event{Type: domain.EventsDropped}})
+	listedTask(t, m, task.ID)
+
+	// A creation event for a task the reload already listed is not doubled
+	update(m, taskEventMsg{event: domain.Event{Type: domain.EventTaskCreated, Task: getTask(t, repo, task.ID)}})
+	if len(m.tasks) != 1 {
+		t.Errorf("listed %d tasks, want 1", len(m.tasks))
+	}
+}
+
+func TestTaskEvent_CategoryDeletedElsewhere(t *testing.T) {
+	bus := repository.NewEventBus()
+	events, cancel := bus.Subscribe(8)
+	defer cancel()
+	repo := repository.Chain(repository.NewMemoryRepository(), repository.WithEvents(bus))
+	ctx := context.Background()
+
+	category := &domain.Category{Name: "Work", Color: "red"}
+	if err := repo.CreateCategory(ctx, category); err != nil {
+		t.Fatalf("CreateCategory() error = %v", err)
+	}
+	task := &domain.Task{Title: "Filed", Status: domain.TaskStatusNew, Priority: domain.PriorityMedium, CategoryID: &category.ID}
+	if err := repo.Create(ctx, task); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	<-events
+	m := newTestModel(t, repo, WithEvents(events))
+
+	// Another process deletes the category, so the listed task loses it
+	if err := repo.DeleteCategory(ctx, category.ID); err != nil {
+		t.Fatalf("DeleteCategory() error = %v", err)
+	}
+	select {
+	case e := <-events:
+		update(m, taskEventMsg{event: e})
+	default:
+		t.Fatal("deleting the category published no event")
+	}
+	if got := listedTask(t, m, task.ID); got.CategoryID != nil {
+		t.Errorf("listed CategoryID = %d after the event, want nil", *got.CategoryID)
+	}
+}
@@ -0,0 +1,51 @@
+package app
+
+import (
+	"context"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hitsumabushi845/task-management/internal/domain"
+	"github.com/hitsumabushi845/task-management/internal/ui/styles"
+)
+
+// noteAddedMsg is sent when a note has been added. Notes publish no
+// events, so the list is reloaded to show it.
+type noteAddedMsg struct{}
+
+// addNote appends the note typed in the detail pane to task
+func (m *Model) addNote(task *domain.Task) tea.Cmd {
+	text := strings.TrimSpace(m.detailInput)
+	if text == "" {
+		return nil
+	}
+	note := &domain.Note{TaskID: task.ID, Text: text}
+	return func() tea.Msg {
+		if err := m.repo.AddNote(context.Background(), note); err != nil {
+			return errMsg{err: err}
+		}
+		return noteAddedMsg{}
+	}
+}
+
+// viewNotes renders the notes timeline of the detail pane, oldest first,
+// each note wrapped under its timestamp
+func (m *Model) viewNotes(task *domain.Task) []string {
+	adding := m.detailAdding == detailInputNote
+	if len(task.Notes) == 0 && !adding {
+		return []string{styles.MarkdownRule.Render("No notes. Press n to add one.")}
+	}
+
+	lines := []string{styles.MarkdownHeading.Render("Notes")}
+	for _, note := range task.Notes {
+		stamp := note.CreatedAt.Local().Format("2006-01-02 15:04")
+		lines = append(lines, styles.MarkdownRule.Render(stamp))
+		for _, line := range wrapText(note.Text, m.detailWidth()-2) {
+			lines = append(lines, "  "+line)
+		}
+	}
+	if adding {
+		lines = append(lines, m.viewDetailInput())
+	}
+	return lines
+}
@@ -0,0 +1,136 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hitsumabushi845/task-management/internal/domain"
+	"github.com/hitsumabushi845/task-management/internal/reminder"
+	"github.com/hitsumabushi845/task-management/internal/ui/styles"
+)
+
+// reminderCheckInterval is how often the TUI looks for due reminders
+const reminderCheckInterval = 30 * time.Second
+
+// reminderTickMsg triggers a reminder check
+type reminderTickMsg struct {
+	now time.Time
+}
+
+// reminderTick schedules the next reminder check
+func reminderTick() tea.Cmd {
+	return tea.Tick(reminderCheckInterval, func(t time.Time) tea.Msg {
+		return reminderTickMsg{now: t}
+	})
+}
+
+// reminderFiredMsg is sent when the TUI has cleared a due reminder and so
+// is the one to show and deliver it
+type reminderFiredMsg struct {
+	task *domain.Task
+}
+
+// checkReminders clears every due reminder so that it fires only once.
+// Each one cleared is then shown in the banner and delivered; one that
+// another checker such as "task remind --watch" cleared first is left to it.
+func (m *Model) checkReminders(now time.Time) tea.Cmd {
+	cmds := []tea.Cmd{reminderTick()}
+	for _, task := range m.tasks {
+		if task.ReminderDue(now) {
+			cmds = append(cmds, m.clearReminder(task))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// clearReminder clears the reminder of task without editing it: no hooks
+// run. The stored version moves on, so edits begun before it fired
+// conflict rather than bring the reminder back.
+func (m *Model) clearReminder(task *domain.Task) tea.Cmd {
+	fired := *task
+	return func() tea.Msg {
+		err := m.repo.ClearReminder(context.Background(), &fired)
+		if errors.Is(err, domain.ErrConflict) {
+			// Delivered elsewhere, or changed and due again after a reload
+			return nil
+		}
+		if err != nil {
+			return errMsg{err: err}
+		}
+		return reminderFiredMsg{task: &fired}
+	}
+}
+
+// fireReminder shows a cleared reminder in the banner and runs the
+// configured notify command if any
+func (m *Model) fireReminder(task *domain.Task) tea.Cmd {
+	for _, listed := range m.tasks {
+		if listed.ID == task.ID && listed.Version == task.Version-1 {
+			listed.RemindAt = nil
+			listed.Version = task.Version
+		}
+	}
+	m.activeReminders = append(m.activeReminders, task)
+	if m.notifier != nil {
+		return m.notify(task)
+	}
+	return nil
+}
+
+// notify delivers a reminder through the configured command
+func (m *Model) notify(task *domain.Task) tea.Cmd {
+	notifier := m.notifier
+	return func() tea.Msg {
+		if err := notifier.Notify(context.Background(), task); err != nil {
+			return errMsg{err: err}
+		}
+		return nil
+	}
+}
+
+// snoozeReminder pushes the oldest shown reminder forward by the configured duration
+func (m *Model) snoozeReminder() tea.Cmd {
+	if len(m.activeReminders) == 0 {
+		return nil
+	}
+	task := m.activeReminders[0]
+	m.activeReminders = m.activeReminders[1:]
+	task.Snooze(time.Duration(m.config.SnoozeDuration), time.Now())
+	return m.updateTask(task)
+}
+
+// dismissReminder hides the oldest shown reminder
+func (m *Model) dismissReminder() {
+	if len(m.activeReminders) > 0 {
+		m.activeReminders = m.activeReminders[1:]
+	}
+}
+
+// viewReminderBanner renders the oldest active reminder, or empty if none
+func (m *Model) viewReminderBanner() string {
+	if len(m.activeReminders) == 0 {
+		return ""
+	}
+	task := m.activeReminders[0]
+	text := fmt.Sprintf("⏰ %s  [Z]Snooze %s [Esc]Dismiss",
+		reminder.Body(task), shortDuration(time.Duration(m.config.SnoozeDuration)))
+	if more := len(m.activeReminders) - 1; more > 0 {
+		text += fmt.Sprintf(" (+%d more)", more)
+	}
+	return styles.Reminder.Render(text) + "\n\n"
+}
+
+// shortDuration formats whole hours or minutes compactly ("10m" rather than "10m0s")
+func shortDuration(d time.Duration) string {
+	switch {
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	default:
+		return d.String()
+	}
+}
@@ -0,0 +1,94 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hitsumabushi845/task-management/internal/domain"
+	"github.com/hitsumabushi845/task-management/internal/repository"
+)
+
+// createReminder stores a new task whose reminder is already due
+func createReminder(t *testing.T, repo domain.TaskRepository, title string) *domain.Task {
+	t.Helper()
+	past := time.Now().Add(-time.Minute).Truncate(time.Second)
+	task := &domain.Task{Title: title, Status: domain.TaskStatusNew, Priority: domain.PriorityMedium, RemindAt: &past}
+	if err := repo.Create(context.Background(), task); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	return task
+}
+
+func TestCheckReminders_ClearsOnce(t *testing.T) {
+	repo := repository.NewMemoryRepository()
+	task := createReminder(t, repo, "Due")
+	m := newTestModel(t, repo)
+
+	update(m, reminderTickMsg{now: time.Now()})
+
+	if len(m.activeReminders) != 1 {
+		t.Fatalf("%d reminders shown, want 1", len(m.activeReminders))
+	}
+	got := getTask(t, repo, task.ID)
+	if got.RemindAt != nil {
+		t.Errorf("stored RemindAt = %v, want it cleared", got.RemindAt)
+	}
+	if listed := listedTask(t, m, task.ID); listed.RemindAt != nil || listed.Version != got.Version {
+		t.Errorf("listed RemindAt %v version %d, want nil and the stored %d", listed.RemindAt, listed.Version, got.Version)
+	}
+
+	// The listed task is cleared too, so the next check fires nothing
+	update(m, reminderTickMsg{now: time.Now()})
+	if len(m.activeReminders) != 1 {
+		t.Errorf("%d reminders shown after a second check, want 1", len(m.activeReminders))
+	}
+}
+
+func TestCheckReminders_AlreadyFiredElsewhere(t *testing.T) {
+	repo := repository.NewMemoryRepository()
+	task := createReminder(t, repo, "Due")
+	m := newTestModel(t, repo)
+
+	if err := repo.ClearReminder(context.Background(), getTask(t, repo, task.ID)); err != nil {
+		t.Fatalf("ClearReminder() error = %v", err)
+	}
+	update(m, reminderTickMsg{now: time.Now()})
+
+	if len(m.activeReminders) != 0 {
+		t.Errorf("%d reminders shown, want none", len(m.activeReminders))
+	}
+	if m.err != nil || m.notice != "" {
+		t.Errorf("err = %v, notice = %q; want neither", m.err, m.notice)
+	}
+}
+
+func TestReminderBanner_Keys(t *testing.T) {
+	repo := repository.NewMemoryRepository()
+	task := createReminder(t, repo, "Due")
+	m := newTestModel(t, repo)
+	update(m, reminderTickMsg{now: time.Now()})
+
+	// Esc clears marks before it dismisses the reminder
+	press(m, "x")
+	press(m, "esc")
+	if len(m.marked) != 0 {
+		t.Errorf("esc left %d tasks marked", len(m.marked))
+	}
+	if len(m.activeReminders) != 1 {
+		t.Fatalf("esc dismissed the reminder while tasks were marked")
+	}
+
+	// Only the shifted key snoozes
+	press(m, "z")
+	if getTask(t, repo, task.ID).RemindAt != nil {
+		t.Errorf("z snoozed the reminder")
+	}
+	press(m, "Z")
+	if len(m.activeReminders) != 0 {
+		t.Errorf("Z left the reminder shown")
+	}
+	if getTask(t, repo, task.ID).RemindAt == nil {
+		t.Errorf("Z did not snooze the reminder")
+	}
+}
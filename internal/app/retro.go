@@ -0,0 +1,59 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hitsumabushi845/task-management/internal/domain"
+)
+
+// notStartedAge is how old an unstarted task must be for the retro preset
+const notStartedAge = 14 * 24 * time.Hour
+
+// retroPreset is a canned timestamp filter for weekly retrospectives
+type retroPreset int
+
+const (
+	retroNone              retroPreset = iota
+	retroCompletedLastWeek             // Completed during the previous calendar week
+	retroNotStarted                    // Created over notStartedAge ago and never started
+	retroStale                         // In working longer than the stale threshold
+)
+
+// retroPresets lists the presets in filter modal order
+var retroPresets = []retroPreset{retroNone, retroCompletedLastWeek, retroNotStarted, retroStale}
+
+// retroLabel names a preset in the filter modal
+func (m *Model) retroLabel(p retroPreset) string {
+	switch p {
+	case retroCompletedLastWeek:
+		return "Completed Last Week"
+	case retroNotStarted:
+		return fmt.Sprintf("Not Started > %dd", int(notStartedAge.Hours()/24))
+	case retroStale:
+		return "Stale (working > " + shortDuration(time.Duration(m.config.StaleAfter)) + ")"
+	default:
+		return "Off"
+	}
+}
+
+// applyRetroPreset replaces the filter's timestamp criteria with the preset's
+func (m *Model) applyRetroPreset(p retroPreset) {
+	m.filterRetro = p
+	m.filter.Created = domain.TimeCriterion{}
+	m.filter.Started = domain.TimeCriterion{}
+	m.filter.Completed = domain.TimeCriterion{}
+
+	switch p {
+	case retroCompletedLastWeek:
+		start, end := domain.LastWeek(time.Now(), m.weekStart())
+		m.filter.Completed = domain.Within(start, end)
+	case retroNotStarted:
+		m.filter.Created = domain.TimeCriterion{OlderThan: notStartedAge}
+		m.filter.Started = domain.TimeCriterion{Unset: true}
+	case retroStale:
+		// Working means started but not completed
+		m.filter.Started = domain.TimeCriterion{OlderThan: time.Duration(m.config.StaleAfter)}
+		m.filter.Completed = domain.TimeCriterion{Unset: true}
+	}
+}
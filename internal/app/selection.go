@@ -0,0 +1,79 @@
+package app
+
+import "github.com/hitsumabushi845/task-management/internal/domain"
+
+// visibleTasks returns the tasks the list view shows, filtered and sorted
+func (m *Model) visibleTasks() []*domain.Task {
+	return m.taskSort.Apply(m.filter.Apply(m.tasks))
+}
+
+// kanbanColumns returns the tasks the kanban view shows, by column
+func (m *Model) kanbanColumns() [3][]*domain.Task {
+	return [3][]*domain.Task{
+		m.tasksByStatus(domain.TaskStatusNew),
+		m.tasksByStatus(domain.TaskStatusWorking),
+		m.tasksByStatus(domain.TaskStatusCompleted),
+	}
+}
+
+// selectedTask returns the task under the cursor in the list or kanban
+// view, or nil if there is none
+func (m *Model) selectedTask() *domain.Task {
+	if m.baseMode() == viewModeKanban {
+		col := m.kanbanColumns()[m.kanbanColumn]
+		if i := m.kanbanCursors[m.kanbanColumn]; i >= 0 && i < len(col) {
+			return col[i]
+		}
+		return nil
+	}
+	tasks := m.visibleTasks()
+	if m.cursor >= 0 && m.cursor < len(tasks) {
+		return tasks[m.cursor]
+	}
+	return nil
+}
+
+// baseMode is the list or kanban view shown under any open form or modal
+func (m *Model) baseMode() viewMode {
+	if m.mode == viewModeList || m.mode == viewModeKanban {
+		return m.mode
+	}
+	return m.previousMode
+}
+
+// restoreSelection moves the cursors back onto the task with the given ID
+// after the list changed, or keeps them in place, within bounds, if the
+// task is no longer shown
+func (m *Model) restoreSelection(id int64) {
+	for i, task := range m.visibleTasks() {
+		if task.ID == id {
+			m.cursor = i
+		}
+	}
+	columns := m.kanbanColumns()
+	for i, task := range columns[m.kanbanColumn] {
+		if task.ID == id {
+			m.kanbanCursors[m.kanbanColumn] = i
+		}
+	}
+	m.clampCursors()
+}
+
+// clampCursors keeps the list and kanban cursors within their lists
+func (m *Model) clampCursors() {
+	m.cursor = clamp(m.cursor, len(m.visibleTasks()))
+	for col, tasks := range m.kanbanColumns() {
+		m.kanbanCursors[col] = clamp(m.kanbanCursors[col], len(tasks))
+	}
+}
+
+// clamp limits a cursor to [0, n)
+func clamp(cursor, n int) int {
+	if cursor >= n {
+		cursor = n - 1
+	}
+	if cursor < 0 {
+		cursor = 0
+	}
+	return cursor
+}
@@ -0,0 +1,270 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hitsumabushi845/task-management/internal/ui/styles"
+)
+
+// textArea is a multi-line text input that wraps at word boundaries and
+// scrolls to keep the cursor in view
+type textArea struct {
+	value  []rune
+	cursor int // Rune offset into value
+	limit  int // Maximum length in bytes, as Task.Validate counts
+	width  int // Runes per line
+	height int // Visible lines
+	scroll int // First visible line
+}
+
+// newTextArea creates a text area holding value with the cursor at its end
+func newTextArea(value string, limit, width, height int) textArea {
+	t := textArea{limit: limit, width: width, height: height}
+	t.setValue(value)
+	return t
+}
+
+// String returns the text
+func (t textArea) String() string {
+	return string(t.value)
+}
+
+// setValue replaces the text, moving the cursor to its end. Unlike typed
+// input it is not cut at the limit, so that nothing is lost silently.
+func (t *textArea) setValue(value string) {
+	t.value = []rune(normalizeNewlines(value))
+	t.cursor = len(t.value)
+	t.follow()
+}
+
+// overLimit reports whether the text is longer than the limit
+func (t textArea) overLimit() bool {
+	return len(string(t.value)) > t.limit
+}
+
+// counter shows the length against the limit, e.g. "120/1000"
+func (t textArea) counter() string {
+	return fmt.Sprintf("%d/%d", len(string(t.value)), t.limit)
+}
+
+// update handles an editing key, reporting whether it was used
+func (t *textArea) update(msg tea.KeyMsg) bool {
+	switch msg.Type {
+	case tea.KeyRunes:
+		t.insert(string(msg.Runes))
+	case tea.KeySpace:
+		t.insert(" ")
+	case tea.KeyEnter:
+		t.insert("\n")
+	case tea.KeyBackspace:
+		if t.cursor > 0 {
+			t.value = append(t.value[:t.cursor-1], t.value[t.cursor:]...)
+			t.cursor--
+		}
+	case tea.KeyDelete:
+		if t.cursor < len(t.value) {
+			t.value = append(t.value[:t.cursor], t.value[t.cursor+1:]...)
+		}
+	case tea.KeyLeft:
+		if msg.Alt {
+			t.cursor = t.wordStart()
+		} else if t.cursor > 0 {
+			t.cursor--
+		}
+	case tea.KeyRight:
+		if msg.Alt {
+			t.cursor = t.wordEnd()
+		} else if t.cursor < len(t.value) {
+			t.cursor++
+		}
+	case tea.KeyCtrlLeft:
+		t.cursor = t.wordStart()
+	case tea.KeyCtrlRight:
+		t.cursor = t.wordEnd()
+	case tea.KeyUp:
+		t.moveLines(-1)
+	case tea.KeyDown:
+		t.moveLines(1)
+	case tea.KeyPgUp:
+		t.moveLines(-t.height)
+	case tea.KeyPgDown:
+		t.moveLines(t.height)
+	case tea.KeyHome, tea.KeyCtrlA:
+		t.cursor = t.lines()[t.cursorLine()].start
+	case tea.KeyEnd, tea.KeyCtrlE:
+		lines := t.lines()
+		t.cursor = t.lineEnd(lines, t.cursorLine())
+	default:
+		return false
+	}
+	t.follow()
+	return true
+}
+
+// insert types s at the cursor, dropping what does not fit in the limit
+func (t *textArea) insert(s string) {
+	s = normalizeNewlines(s)
+	room := t.limit - len(string(t.value))
+	var runes []rune
+	for _, r := range s {
+		if r == '\t' {
+			r = ' '
+		}
+		if r != '\n' && unicode.IsControl(r) {
+			continue
+		}
+		if room -= len(string(r)); room < 0 {
+			break
+		}
+		runes = append(runes, r)
+	}
+	value := make([]rune, 0, len(t.value)+len(runes))
+	value = append(value, t.value[:t.cursor]...)
+	value = append(value, runes...)
+	t.value = append(value, t.value[t.cursor:]...)
+	t.cursor += len(runes)
+}
+
+// lineSpan is one displayed line, value[start:end] without its newline
+type lineSpan struct {
+	start, end int
+}
+
+// lines wraps the text to the width, breaking after the last space that
+// fits or, in a word longer than a line, at the width
+func (t textArea) lines() []lineSpan {
+	var spans []lineSpan
+	start := 0
+	for i := 0; i <= len(t.value); i++ {
+		if i < len(t.value) && t.value[i] != '\n' {
+			continue
+		}
+		for i-start > t.width {
+			brk := start + t.width
+			for j := brk; j > start; j-- {
+				if t.value[j-1] == ' ' {
+					brk = j
+					break
+				}
+			}
+			spans = append(spans, lineSpan{start, brk})
+			start = brk
+		}
+		spans = append(spans, lineSpan{start, i})
+		start = i + 1
+	}
+	return spans
+}
+
+// cursorLine is the displayed line holding the cursor. At a soft wrap the
+// cursor belongs to the start of the next line.
+func (t textArea) cursorLine() int {
+	line := 0
+	for i, span := range t.lines() {
+		if span.start <= t.cursor {
+			line = i
+		}
+	}
+	return line
+}
+
+// lineEnd is the last cursor position on line i; on a wrapped line that is
+// before the break, which otherwise shows on the next line
+func (t textArea) lineEnd(lines []lineSpan, i int) int {
+	span := lines[i]
+	if i+1 < len(lines) && lines[i+1].start == span.end && span.end > span.start {
+		return span.end - 1
+	}
+	return span.end
+}
+
+// moveLines moves the cursor n displayed lines, keeping its column
+func (t *textArea) moveLines(n int) {
+	lines := t.lines()
+	from := t.cursorLine()
+	col := t.cursor - lines[from].start
+	to := clamp(from+n, len(lines))
+	t.cursor = lines[to].start + col
+	if end := t.lineEnd(lines, to); t.cursor > end {
+		t.cursor = end
+	}
+}
+
+func (t textArea) wordStart() int {
+	i := t.cursor
+	for i > 0 && unicode.IsSpace(t.value[i-1]) {
+		i--
+	}
+	for i > 0 && !unicode.IsSpace(t.value[i-1]) {
+		i--
+	}
+	return i
+}
+
+func (t textArea) wordEnd() int {
+	i := t.cursor
+	for i < len(t.value) && unicode.IsSpace(t.value[i]) {
+		i++
+	}
+	for i < len(t.value) && !unicode.IsSpace(t.value[i]) {
+		i++
+	}
+	return i
+}
+
+// follow scrolls so that the cursor line is visible
+func (t *textArea) follow() {
+	line := t.cursorLine()
+	if line < t.scroll {
+		t.scroll = line
+	}
+	if line >= t.scroll+t.height {
+		t.scroll = line - t.height + 1
+	}
+}
+
+// view renders the visible lines, each padded to width+1 columns to leave
+// room for the cursor at the end of a full line. The cursor is only drawn
+// when focused.
+func (t textArea) view(focused bool) []string {
+	lines := t.lines()
+	cursorLine := t.cursorLine()
+	var out []string
+	for i := t.scroll; i < t.scroll+t.height; i++ {
+		if i >= len(lines) {
+			out = append(out, strings.Repeat(" ", t.width+1))
+			continue
+		}
+		span := lines[i]
+		text := t.value[span.start:span.end]
+		var s string
+		if focused && i == cursorLine {
+			col := t.cursor - span.start
+			under := " "
+			rest := ""
+			if col < len(text) {
+				under = string(text[col])
+				rest = string(text[col+1:])
+			}
+			s = string(text[:col]) + styles.Cursor.Render(under) + rest
+			if col == len(text) {
+				s += strings.Repeat(" ", t.width-len(text))
+			} else {
+				s += strings.Repeat(" ", t.width+1-len(text))
+			}
+		} else {
+			s = string(text) + strings.Repeat(" ", t.width+1-len(text))
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+// normalizeNewlines turns Windows and old Mac line endings into "\n"
+func normalizeNewlines(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.ReplaceAll(s, "\r", "\n")
+}
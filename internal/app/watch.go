@@ -0,0 +1,57 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// changePollInterval is how often the TUI asks whether the data changed
+// outside it
+const changePollInterval = time.Second
+
+// ChangeDetector reports whether the stored data changed since it was last
+// asked, e.g. a repository.ChangeWatcher noticing the CLI or another TUI
+// writing to the same database
+type ChangeDetector interface {
+	Changed(ctx context.Context) (bool, error)
+}
+
+// changePollMsg triggers a change check
+type changePollMsg struct{}
+
+// externalChangeMsg reports the result of a change check
+type externalChangeMsg struct {
+	changed bool
+}
+
+// WithChangeDetector reloads tasks and categories whenever d reports a
+// change, keeping the cursor on the selected task
+func WithChangeDetector(d ChangeDetector) Option {
+	return func(m *Model) {
+		m.changes = d
+	}
+}
+
+// changePoll schedules the next change check
+func (m *Model) changePoll() tea.Cmd {
+	if m.changes == nil {
+		return nil
+	}
+	return tea.Tick(changePollInterval, func(time.Time) tea.Msg {
+		return changePollMsg{}
+	})
+}
+
+// checkChanges asks the change detector whether to reload
+func (m *Model) checkChanges() tea.Cmd {
+	changes := m.changes
+	return func() tea.Msg {
+		changed, err := changes.Changed(context.Background())
+		if err != nil {
+			return errMsg{err: err}
+		}
+		return externalChangeMsg{changed: changed}
+	}
+}
@@ -0,0 +1,181 @@
+// Package attachment resolves, copies and opens the files and URLs attached
+// to tasks.
+package attachment
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/hitsumabushi845/task-management/internal/domain"
+)
+
+// DefaultOpenCommand opens attachments when no open_command is configured
+const DefaultOpenCommand = "xdg-open"
+
+// openSchemes are the URL schemes Open hands to the open command. Others,
+// such as file: or an application's own scheme, could make the open
+// command run anything.
+var openSchemes = map[string]bool{"http": true, "https": true, "mailto": true}
+
+// Resolve turns what the user typed into an attachment location. URLs are
+// kept as they are; file paths may start with "~/" or be relative to the
+// working directory, are made absolute and must exist.
+func Resolve(input string) (string, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return "", errors.New("no file or URL given")
+	}
+	if domain.IsURL(input) {
+		return input, nil
+	}
+
+	path := input
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(home, path[1:])
+	}
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// Copy copies the file at src into dir/<taskID>/ and returns the copy's
+// path. An existing file of the same name is kept; the copy gets a
+// numbered name such as "spec-2.pdf" instead.
+func Copy(src, dir string, taskID int64) (string, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return "", err
+	}
+	defer in.Close()
+	if info, err := in.Stat(); err != nil {
+		return "", err
+	} else if info.IsDir() {
+		return "", fmt.Errorf("%s is a directory; only files can be copied", src)
+	}
+
+	taskDir := filepath.Join(dir, fmt.Sprint(taskID))
+	if err := os.MkdirAll(taskDir, 0o755); err != nil {
+		return "", err
+	}
+
+	base := filepath.Base(src)
+	ext := filepath.Ext(base)
+	stem := strings.TrimSuffix(base, ext)
+	for n := 1; ; n++ {
+		name := base
+		if n > 1 {
+			name = fmt.Sprintf("%s-%d%s", stem, n, ext)
+		}
+		dst := filepath.Join(taskDir, name)
+		out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if errors.Is(err, os.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		if _, err := io.Copy(out, in); err != nil {
+			out.Close()
+			os.Remove(dst)
+			return "", err
+		}
+		if err := out.Close(); err != nil {
+			os.Remove(dst)
+			return "", err
+		}
+		return dst, nil
+	}
+}
+
+// Open opens location with command, e.g. "xdg-open" or "open", appending
+// the location as the last argument. It does not wait for the viewer.
+func Open(command, location string) error {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		args = []string{DefaultOpenCommand}
+	}
+	if domain.IsURL(location) {
+		u, err := url.Parse(location)
+		if err != nil {
+			return err
+		}
+		if !openSchemes[strings.ToLower(u.Scheme)] {
+			return fmt.Errorf("%s: URLs are not opened; only http, https and mailto are", u.Scheme)
+		}
+	} else if _, err := os.Stat(location); err != nil {
+		return err
+	}
+
+	cmd := exec.Command(args[0], append(args[1:], location)...)
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("%s: %w", args[0], err)
+	}
+	go cmd.Wait() // Reap the process whenever the viewer exits
+	return nil
+}
+
+// Discard removes path if it is a copy made by Copy into dir, and its task
+// directory once empty. Files attached where they are, outside dir, are
+// never touched.
+func Discard(dir, path string) error {
+	if !inside(dir, path) {
+		return nil
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	os.Remove(filepath.Dir(path)) // Fails while other copies remain
+	return nil
+}
+
+// Orphans lists the files copied into dir that no attachment in locations
+// refers to, such as the copies of deleted tasks
+func Orphans(dir string, locations []string) ([]string, error) {
+	referenced := make(map[string]bool, len(locations))
+	for _, location := range locations {
+		referenced[filepath.Clean(location)] = true
+	}
+
+	var orphans []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) && path == dir {
+			return filepath.SkipDir // Nothing was ever copied
+		}
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && !referenced[path] {
+			orphans = append(orphans, path)
+		}
+		return nil
+	})
+	return orphans, err
+}
+
+// inside reports whether path is within dir
+func inside(dir, path string) bool {
+	if dir == "" {
+		return false
+	}
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	return rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,133 @@
+package attachment
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "spec.pdf")
+	if err := os.WriteFile(file, []byte("spec"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "URL", input: " https://example.com/a ", want: "https://example.com/a"},
+		{name: "absolute path", input: file, want: file},
+		{name: "relative path", input: "spec.pdf", want: file},
+		{name: "missing file", input: "missing.pdf", wantErr: true},
+		{name: "empty", input: "  ", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Resolve(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Resolve() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Resolve() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCopy(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "spec.pdf")
+	if err := os.WriteFile(src, []byte("spec"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+
+	// A second copy of the same name does not overwrite the first
+	for _, want := range []string{"spec.pdf", "spec-2.pdf"} {
+		got, err := Copy(src, dir, 7)
+		if err != nil {
+			t.Fatalf("Copy() error = %v", err)
+		}
+		if got != filepath.Join(dir, "7", want) {
+			t.Errorf("Copy() = %q, want %q", got, filepath.Join(dir, "7", want))
+		}
+		if data, err := os.ReadFile(got); err != nil || string(data) != "spec" {
+			t.Errorf("copied file = %q, %v; want spec", data, err)
+		}
+	}
+
+	if _, err := Copy(dir, dir, 7); err == nil {
+		t.Errorf("Copy() of a directory error = nil, want an error")
+	}
+}
+
+func TestOpen_Schemes(t *testing.T) {
+	tests := []struct {
+		location string
+		wantErr  bool
+	}{
+		{"https://example.com/spec", false},
+		{"http://example.com/spec", false},
+		{"mailto:someone@example.com", false},
+		{"HTTPS://example.com/spec", false},
+		{"file:///etc/passwd", true},
+		{"javascript:alert(1)", true},
+		{"vscode://open?file=x", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.location, func(t *testing.T) {
+			// true stands in for the viewer and ignores the location
+			if err := Open("true", tt.location); (err != nil) != tt.wantErr {
+				t.Errorf("Open(%q) error = %v, wantErr %v", tt.location, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDiscardAndOrphans(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "spec.pdf")
+	if err := os.WriteFile(src, []byte("spec"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	kept, err := Copy(src, dir, 1)
+	if err != nil {
+		t.Fatalf("Copy() error = %v", err)
+	}
+	orphan, err := Copy(src, dir, 2)
+	if err != nil {
+		t.Fatalf("Copy() error = %v", err)
+	}
+
+	orphans, err := Orphans(dir, []string{kept, src})
+	if err != nil {
+		t.Fatalf("Orphans() error = %v", err)
+	}
+	if len(orphans) != 1 || orphans[0] != orphan {
+		t.Errorf("Orphans() = %v, want [%s]", orphans, orphan)
+	}
+
+	if err := Discard(dir, orphan); err != nil {
+		t.Fatalf("Discard() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Dir(orphan)); !os.IsNotExist(err) {
+		t.Errorf("task directory after Discard() error = %v, want it removed", err)
+	}
+
+	// Files outside the directory are attached in place and never deleted
+	if err := Discard(dir, src); err != nil {
+		t.Fatalf("Discard() error = %v", err)
+	}
+	if _, err := os.Stat(src); err != nil {
+		t.Errorf("Discard() of a file outside the directory removed it: %v", err)
+	}
+
+	if orphans, err := Orphans(filepath.Join(dir, "missing"), nil); err != nil || len(orphans) != 0 {
+		t.Errorf("Orphans() of a missing directory = %v, %v; want none", orphans, err)
+	}
+}
@@ -0,0 +1,129 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Config holds user settings read from config.json in the data directory
+type Config struct {
+	// NotifyCommand delivers reminders, e.g. "notify-send". The summary and
+	// body are appended as arguments. Empty prints reminders to stdout.
+	NotifyCommand string `json:"notify_command"`
+
+	// SnoozeDuration is how far a snooze pushes a reminder
+	SnoozeDuration Duration `json:"snooze_duration"`
+
+	// WeekStart is the first day of calendar weeks in filters, the date
+	// picker and date parsing, e.g. "monday" or "sunday"
+	WeekStart Weekday `json:"week_start"`
+
+	// StaleAfter flags tasks in working for longer than this; "0s" disables
+	StaleAfter Duration `json:"stale_after"`
+
+	// WebhookURLs receive a POST for every task change
+	WebhookURLs []string `json:"webhook_urls"`
+
+	// WebhookSecret signs webhook payloads with HMAC-SHA256 when set
+	WebhookSecret string `json:"webhook_secret"`
+
+	// LogFile receives a JSON line for every repository call when set.
+	// A relative path is resolved against the data directory.
+	LogFile string `json:"log_file"`
+
+	// SkipConfirm runs deletes and other destructive actions in the TUI,
+	// and task import --replace, without asking first
+	SkipConfirm bool `json:"skip_confirm"`
+
+	// AutoCompleteChecklist completes a task when the last item of its
+	// checklist is checked in the TUI
+	AutoCompleteChecklist bool `json:"auto_complete_checklist"`
+
+	// OpenCommand opens attachments from the TUI, e.g. "open" on macOS.
+	// The file path or URL is appended as the last argument. Empty uses
+	// xdg-open.
+	OpenCommand string `json:"open_command"`
+
+	// CopyAttachments copies files attached in the TUI into the
+	// attachments directory, so they stay available if the original moves
+	CopyAttachments bool `json:"copy_attachments"`
+}
+
+// Default returns the settings used when no config file exists
+func Default() Config {
+	return Config{
+		SnoozeDuration: Duration(10 * time.Minute),
+		WeekStart:      Weekday(time.Monday),
+		StaleAfter:     Duration(7 * 24 * time.Hour),
+	}
+}
+
+// Load reads the config file at path. A missing file yields Default().
+// Unset fields keep their default values.
+func Load(path string) (Config, error) {
+	cfg := Default()
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Duration is a time.Duration written as a string such as "10m" in JSON
+type Duration time.Duration
+
+// MarshalJSON encodes the duration as a string
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON decodes a duration string such as "90s" or "1h30m"
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// Weekday is a time.Weekday written as a lowercase day name in JSON
+type Weekday time.Weekday
+
+// MarshalJSON encodes the weekday as a lowercase name
+func (w Weekday) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strings.ToLower(time.Weekday(w).String()))
+}
+
+// UnmarshalJSON decodes a day name such as "monday" or "Sun"
+func (w *Weekday) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	name := strings.ToLower(s)
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		full := strings.ToLower(d.String())
+		if name == full || name == full[:3] {
+			*w = Weekday(d)
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid weekday %q", s)
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLoad_MissingFile(t *testing.T) {
+	cfg, err := Load(filepath.Join(t.TempDir(), "config.json"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !reflect.DeepEqual(cfg, Default()) {
+		t.Errorf("Load() = %+v, want defaults %+v", cfg, Default())
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	data := `{"notify_command": "notify-send -u critical", "snooze_duration": "1h", "week_start": "Sunday", "stale_after": "72h", "webhook_urls": ["http://localhost:9000/hook"], "skip_confirm": true, "auto_complete_checklist": true, "open_command": "open", "copy_attachments": true}`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.NotifyCommand != "notify-send -u critical" {
+		t.Errorf("NotifyCommand = %q, want %q", cfg.NotifyCommand, "notify-send -u critical")
+	}
+	if time.Duration(cfg.SnoozeDuration) != time.Hour {
+		t.Errorf("SnoozeDuration = %v, want 1h", time.Duration(cfg.SnoozeDuration))
+	}
+	if time.Weekday(cfg.WeekStart) != time.Sunday {
+		t.Errorf("WeekStart = %v, want Sunday", time.Weekday(cfg.WeekStart))
+	}
+	if time.Duration(cfg.StaleAfter) != 72*time.Hour {
+		t.Errorf("StaleAfter = %v, want 72h", time.Duration(cfg.StaleAfter))
+	}
+	if !reflect.DeepEqual(cfg.WebhookURLs, []string{"http://localhost:9000/hook"}) {
+		t.Errorf("WebhookURLs = %v, want [http://localhost:9000/hook]", cfg.WebhookURLs)
+	}
+	if !cfg.SkipConfirm {
+		t.Errorf("SkipConfirm = false, want true")
+	}
+	if !cfg.AutoCompleteChecklist {
+		t.Errorf("AutoCompleteChecklist = false, want true")
+	}
+	if cfg.OpenCommand != "open" {
+		t.Errorf("OpenCommand = %q, want %q", cfg.OpenCommand, "open")
+	}
+	if !cfg.CopyAttachments {
+		t.Errorf("CopyAttachments = false, want true")
+	}
+}
+
+func TestLoad_KeepsDefaultsForUnsetFields(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"notify_command": "notify-send"}`), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.SnoozeDuration != Default().SnoozeDuration {
+		t.Errorf("SnoozeDuration = %v, want default %v", cfg.SnoozeDuration, Default().SnoozeDuration)
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"malformed json", `{"notify_command": `},
+		{"bad duration", `{"snooze_duration": "soon"}`},
+		{"bad weekday", `{"week_start": "someday"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.json")
+			if err := os.WriteFile(path, []byte(tt.data), 0644); err != nil {
+				t.Fatalf("failed to write config: %v", err)
+			}
+			if _, err := Load(path); err == nil {
+				t.Errorf("Load() expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// MaxAttachments bounds the number of attachments on a task, and
+// MaxAttachmentLen the length of each location in bytes
+const (
+	MaxAttachments   = 50
+	MaxAttachmentLen = 1000
+)
+
+// Attachment is a local file or a URL attached to a task. Like checklist
+// items, attachments are kept in order and saved together with the task.
+type Attachment struct {
+	Location string // An absolute file path or a URL
+	AddedAt  time.Time
+}
+
+// IsURL reports whether the attachment is a URL rather than a file path
+func (a Attachment) IsURL() bool {
+	return IsURL(a.Location)
+}
+
+// Name is the file name of a file attachment, or the URL of a URL
+func (a Attachment) Name() string {
+	if a.IsURL() {
+		return a.Location
+	}
+	return filepath.Base(a.Location)
+}
+
+// IsURL reports whether s is a URL such as https://example.com or
+// mailto:someone@example.com. Single-letter schemes are Windows drive
+// letters, not URLs.
+func IsURL(s string) bool {
+	u, err := url.Parse(s)
+	if err != nil || len(u.Scheme) < 2 {
+		return false
+	}
+	return u.Host != "" || u.Opaque != ""
+}
+
+func validateAttachments(attachments []Attachment) error {
+	if len(attachments) > MaxAttachments {
+		return &ValidationError{Field: "attachments", Message: fmt.Sprintf("a task can have %d attachments or fewer", MaxAttachments)}
+	}
+	for i, a := range attachments {
+		switch {
+		case strings.TrimSpace(a.Location) == "":
+			return &ValidationError{Field: "attachments", Message: fmt.Sprintf("attachment %d is empty", i+1)}
+		case len(a.Location) > MaxAttachmentLen:
+			return &ValidationError{Field: "attachments", Message: fmt.Sprintf("attachment %d must be %d characters or less", i+1, MaxAttachmentLen)}
+		case !a.IsURL() && !filepath.IsAbs(a.Location):
+			return &ValidationError{Field: "attachments", Message: fmt.Sprintf("attachment %d must be a URL or an absolute path", i+1)}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package domain
+
+import "testing"
+
+func TestAttachment_Name(t *testing.T) {
+	tests := []struct {
+		location string
+		wantURL  bool
+		wantName string
+	}{
+		{"https://example.com/issue/1", true, "https://example.com/issue/1"},
+		{"mailto:vendor@example.com", true, "mailto:vendor@example.com"},
+		{"/home/me/docs/spec.pdf", false, "spec.pdf"},
+		{"C:/docs/spec.pdf", false, "spec.pdf"},
+		{"/home/me/odd:name.txt", false, "odd:name.txt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.location, func(t *testing.T) {
+			a := Attachment{Location: tt.location}
+			if got := a.IsURL(); got != tt.wantURL {
+				t.Errorf("IsURL() = %v, want %v", got, tt.wantURL)
+			}
+			if got := a.Name(); got != tt.wantName {
+				t.Errorf("Name() = %q, want %q", got, tt.wantName)
+			}
+		})
+	}
+}
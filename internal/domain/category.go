@@ -1,9 +1,6 @@
 package domain
 
-import (
-	"errors"
-	"time"
-)
+import "time"
 
 // Category represents a category for organizing tasks
 type Category struct {
@@ -16,19 +13,19 @@ type Category struct {
 // Validate checks if the category has valid data
 func (c *Category) Validate() error {
 	if c.Name == "" {
-		return errors.New("name is required")
+		return &ValidationError{Field: "name", Message: "name is required"}
 	}
 
 	if len(c.Name) > 50 {
-		return errors.New("name must be 50 characters or less")
+		return &ValidationError{Field: "name", Message: "name must be 50 characters or less"}
 	}
 
 	if c.Color == "" {
-		return errors.New("color is required")
+		return &ValidationError{Field: "color", Message: "color is required"}
 	}
 
 	if !isValidColor(c.Color) {
-		return errors.New("invalid color: must be one of blue, green, red, yellow, purple, cyan, magenta, white, black")
+		return &ValidationError{Field: "color", Message: "invalid color: must be one of blue, green, red, yellow, purple, cyan, magenta, white, black"}
 	}
 
 	return nil
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxChecklistItems bounds the length of a task's checklist
+const MaxChecklistItems = 100
+
+// ChecklistItem is one step of a task, lighter than a task of its own. A
+// task stores its items in order and saves them together with the task.
+type ChecklistItem struct {
+	Text string
+	Done bool
+}
+
+// ChecklistProgress returns how many checklist items are done, and how many
+// there are
+func (t *Task) ChecklistProgress() (done, total int) {
+	for _, item := range t.Checklist {
+		if item.Done {
+			done++
+		}
+	}
+	return done, len(t.Checklist)
+}
+
+// ChecklistDone reports whether the task has a checklist with every item done
+func (t *Task) ChecklistDone() bool {
+	done, total := t.ChecklistProgress()
+	return total > 0 && done == total
+}
+
+func validateChecklist(items []ChecklistItem) error {
+	if len(items) > MaxChecklistItems {
+		return &ValidationError{Field: "checklist", Message: fmt.Sprintf("checklist must have %d items or fewer", MaxChecklistItems)}
+	}
+	for i, item := range items {
+		if strings.TrimSpace(item.Text) == "" {
+			return &ValidationError{Field: "checklist", Message: fmt.Sprintf("checklist item %d is empty", i+1)}
+		}
+		if len(item.Text) > MaxTitleLen {
+			return &ValidationError{Field: "checklist", Message: fmt.Sprintf("checklist item %d must be %d characters or less", i+1, MaxTitleLen)}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package domain
+
+import "testing"
+
+func TestTask_ChecklistProgress(t *testing.T) {
+	tests := []struct {
+		name      string
+		checklist []ChecklistItem
+		wantDone  int
+		wantTotal int
+		wantAll   bool
+	}{
+		{
+			name: "no checklist",
+		},
+		{
+			name:      "partly done",
+			checklist: []ChecklistItem{{Text: "a", Done: true}, {Text: "b"}, {Text: "c", Done: true}},
+			wantDone:  2,
+			wantTotal: 3,
+		},
+		{
+			name:      "all done",
+			checklist: []ChecklistItem{{Text: "a", Done: true}, {Text: "b", Done: true}},
+			wantDone:  2,
+			wantTotal: 2,
+			wantAll:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &Task{Checklist: tt.checklist}
+			done, total := task.ChecklistProgress()
+			if done != tt.wantDone || total != tt.wantTotal {
+				t.Errorf("ChecklistProgress() = %d, %d, want %d, %d", done, total, tt.wantDone, tt.wantTotal)
+			}
+			if got := task.ChecklistDone(); got != tt.wantAll {
+				t.Errorf("ChecklistDone() = %v, want %v", got, tt.wantAll)
+			}
+		})
+	}
+}
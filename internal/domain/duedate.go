@@ -0,0 +1,277 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseDueDate resolves a due date expression relative to now.
+//
+// Besides plain YYYY-MM-DD it accepts:
+//   - today, tomorrow, yesterday
+//   - weekday names (fri, friday): the next such day, today included
+//   - this/next <weekday>: that day in the current or following week
+//   - next week, next month, end of week (eow), end of month (eom)
+//   - relative offsets: +3d, +2w, +1m, +1y, "in 3 days"
+//   - month/day (11/3, rolled over to next year if already past) and YYYY/MM/DD
+//   - Japanese forms: 今日, 明日, 明後日, 金曜, 来週金曜, 月末, 3日後, 11月3日
+//
+// The result is midnight in now's location. Weeks start on Monday.
+func ParseDueDate(input string, now time.Time) (time.Time, error) {
+	return ParseDueDateWeekStart(input, now, time.Monday)
+}
+
+// ParseDueDateWeekStart is ParseDueDate with weeks starting on weekStart,
+// which affects "next <weekday>", "next week" and "end of week"
+func ParseDueDateWeekStart(input string, now time.Time, weekStart time.Weekday) (time.Time, error) {
+	s := normalizeDateInput(input)
+	if s == "" {
+		return time.Time{}, fmt.Errorf("empty date")
+	}
+
+	today := startOfDay(now)
+
+	switch s {
+	case "today", "tod", "now", "今日", "きょう", "本日":
+		return today, nil
+	case "tomorrow", "tom", "tmr", "明日", "あした", "あす":
+		return today.AddDate(0, 0, 1), nil
+	case "yesterday", "昨日", "きのう":
+		return today.AddDate(0, 0, -1), nil
+	case "明後日", "あさって":
+		return today.AddDate(0, 0, 2), nil
+	case "next week", "来週":
+		return startOfWeek(today, weekStart).AddDate(0, 0, 7), nil
+	case "next month", "来月":
+		return time.Date(today.Year(), today.Month()+1, 1, 0, 0, 0, 0, today.Location()), nil
+	case "end of week", "eow", "今週末":
+		return startOfWeek(today, weekStart).AddDate(0, 0, 6), nil
+	case "end of month", "eom", "月末", "今月末":
+		return endOfMonth(today), nil
+	case "end of next month", "来月末":
+		return endOfMonth(time.Date(today.Year(), today.Month()+1, 1, 0, 0, 0, 0, today.Location())), nil
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02", s, now.Location()); err == nil {
+		return t, nil
+	}
+
+	if m := relativeDateRe.FindStringSubmatch(s); m != nil {
+		n, err := dateCount(m[1])
+		if err != nil {
+			return time.Time{}, err
+		}
+		return addDateUnit(today, n, m[2]), nil
+	}
+	if m := inDateRe.FindStringSubmatch(s); m != nil {
+		n, err := dateCount(m[1])
+		if err != nil {
+			return time.Time{}, err
+		}
+		return addDateUnit(today, n, m[2]), nil
+	}
+	if m := jaRelativeRe.FindStringSubmatch(s); m != nil {
+		n, err := dateCount(m[1])
+		if err != nil {
+			return time.Time{}, err
+		}
+		switch {
+		case m[2] == "日":
+			return today.AddDate(0, 0, n), nil
+		case strings.HasPrefix(m[2], "週"):
+			return today.AddDate(0, 0, 7*n), nil
+		case strings.HasSuffix(m[2], "月"):
+			return addMonths(today, n), nil
+		default:
+			return today.AddDate(n, 0, 0), nil
+		}
+	}
+
+	if m := ymdSlashRe.FindStringSubmatch(s); m != nil {
+		return exactDate(m[1], m[2], m[3], today)
+	}
+	if m := jaYMDRe.FindStringSubmatch(s); m != nil {
+		return exactDate(m[1], m[2], m[3], today)
+	}
+	if m := mdSlashRe.FindStringSubmatch(s); m != nil {
+		return upcomingMonthDay(m[1], m[2], today)
+	}
+	if m := jaMDRe.FindStringSubmatch(s); m != nil {
+		return upcomingMonthDay(m[1], m[2], today)
+	}
+
+	if m := weekdayRe.FindStringSubmatch(s); m != nil {
+		if wd, ok := englishWeekdays[m[2]]; ok {
+			return resolveWeekday(today, wd, m[1], weekStart), nil
+		}
+	}
+	if m := jaWeekdayRe.FindStringSubmatch(s); m != nil {
+		wd := japaneseWeekdays[m[2]]
+		switch m[1] {
+		case "今週":
+			return resolveWeekday(today, wd, "this", weekStart), nil
+		case "来週":
+			return resolveWeekday(today, wd, "next", weekStart), nil
+		case "再来週":
+			return resolveWeekday(today, wd, "next", weekStart).AddDate(0, 0, 7), nil
+		default:
+			return resolveWeekday(today, wd, "", weekStart), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unrecognized date %q", strings.TrimSpace(input))
+}
+
+var (
+	relativeDateRe = regexp.MustCompile(`^\+\s*(\d+)\s*(d|days?|w|weeks?|m|mo|months?|y|years?)$`)
+	inDateRe       = regexp.MustCompile(`^in (\d+) (days?|weeks?|months?|years?)$`)
+	jaRelativeRe   = regexp.MustCompile(`^(\d+)\s*(日|週間|週|ヶ月|か月|カ月|ケ月|箇月|年)後$`)
+	ymdSlashRe     = regexp.MustCompile(`^(\d{4})/(\d{1,2})/(\d{1,2})$`)
+	jaYMDRe        = regexp.MustCompile(`^(\d{4})年(\d{1,2})月(\d{1,2})日$`)
+	mdSlashRe      = regexp.MustCompile(`^(\d{1,2})/(\d{1,2})$`)
+	jaMDRe         = regexp.MustCompile(`^(\d{1,2})月(\d{1,2})日$`)
+	weekdayRe      = regexp.MustCompile(`^(?:(this|next) )?([a-z]+)$`)
+	jaWeekdayRe    = regexp.MustCompile(`^(今週|来週|再来週)?の?([月火水木金土日])曜日?$`)
+)
+
+var englishWeekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+var japaneseWeekdays = map[string]time.Weekday{
+	"日": time.Sunday,
+	"月": time.Monday,
+	"火": time.Tuesday,
+	"水": time.Wednesday,
+	"木": time.Thursday,
+	"金": time.Friday,
+	"土": time.Saturday,
+}
+
+// normalizeDateInput lowercases the input, collapses whitespace and folds
+// full-width digits and symbols typed through a Japanese IME to ASCII.
+func normalizeDateInput(input string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(input) {
+		switch {
+		case r >= '０' && r <= '９':
+			r = '0' + (r - '０')
+		case r == '／':
+			r = '/'
+		case r == '＋':
+			r = '+'
+		case r == '－':
+			r = '-'
+		case r == '　':
+			r = ' '
+		}
+		b.WriteRune(r)
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// resolveWeekday finds the given weekday. With no qualifier it is the next
+// such day (today included); "this" and "next" pick the day within the
+// current or following week.
+func resolveWeekday(today time.Time, wd time.Weekday, qualifier string, weekStart time.Weekday) time.Time {
+	switch qualifier {
+	case "this", "next":
+		start := startOfWeek(today, weekStart)
+		if qualifier == "next" {
+			start = start.AddDate(0, 0, 7)
+		}
+		offset := (int(wd) - int(weekStart) + 7) % 7
+		return start.AddDate(0, 0, offset)
+	default:
+		offset := (int(wd) - int(today.Weekday()) + 7) % 7
+		return today.AddDate(0, 0, offset)
+	}
+}
+
+// dateCount parses the number in a relative offset, rejecting one too large
+// for an int rather than wrapping it
+func dateCount(digits string) (int, error) {
+	n, err := strconv.Atoi(digits)
+	if err != nil {
+		return 0, fmt.Errorf("offset %s is out of range", digits)
+	}
+	return n, nil
+}
+
+func addDateUnit(t time.Time, n int, unit string) time.Time {
+	switch unit[0] {
+	case 'd':
+		return t.AddDate(0, 0, n)
+	case 'w':
+		return t.AddDate(0, 0, 7*n)
+	case 'm':
+		return addMonths(t, n)
+	default:
+		return t.AddDate(n, 0, 0)
+	}
+}
+
+// addMonths adds n months, clamping to the last day of the target month so
+// that Jan 31 + 1 month is Feb 28/29 rather than early March.
+func addMonths(t time.Time, n int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(n), 1, 0, 0, 0, 0, t.Location())
+	last := endOfMonth(first)
+	if t.Day() > last.Day() {
+		return last
+	}
+	return time.Date(first.Year(), first.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func exactDate(year, month, day string, today time.Time) (time.Time, error) {
+	y, _ := strconv.Atoi(year)
+	m, _ := strconv.Atoi(month)
+	d, _ := strconv.Atoi(day)
+	t := time.Date(y, time.Month(m), d, 0, 0, 0, 0, today.Location())
+	if t.Month() != time.Month(m) || t.Day() != d {
+		return time.Time{}, fmt.Errorf("invalid date %s-%s-%s", year, month, day)
+	}
+	return t, nil
+}
+
+// upcomingMonthDay resolves a month/day without a year to its next
+// occurrence, today included. Feb 29 may be up to eight years away, as
+// century years such as 2100 are not leap years.
+func upcomingMonthDay(month, day string, today time.Time) (time.Time, error) {
+	var firstErr error
+	for year := today.Year(); year <= today.Year()+8; year++ {
+		t, err := exactDate(strconv.Itoa(year), month, day, today)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if !t.Before(today) {
+			return t, nil
+		}
+	}
+	return time.Time{}, firstErr
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func startOfWeek(t time.Time, weekStart time.Weekday) time.Time {
+	day := startOfDay(t)
+	offset := (int(day.Weekday()) - int(weekStart) + 7) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+func endOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDueDate(t *testing.T) {
+	// Wednesday afternoon
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"2026-11-03", date(2026, 11, 3)},
+		{"today", date(2026, 10, 14)},
+		{" Tomorrow ", date(2026, 10, 15)},
+		{"yesterday", date(2026, 10, 13)},
+		{"fri", date(2026, 10, 16)},
+		{"friday", date(2026, 10, 16)},
+		{"wed", date(2026, 10, 14)},
+		{"mon", date(2026, 10, 19)},
+		{"this monday", date(2026, 10, 12)},
+		{"next monday", date(2026, 10, 19)},
+		{"next fri", date(2026, 10, 23)},
+		{"next week", date(2026, 10, 19)},
+		{"next month", date(2026, 11, 1)},
+		{"end of week", date(2026, 10, 18)},
+		{"end of month", date(2026, 10, 31)},
+		{"eom", date(2026, 10, 31)},
+		{"+3d", date(2026, 10, 17)},
+		{"+2w", date(2026, 10, 28)},
+		{"+1m", date(2026, 11, 14)},
+		{"+1y", date(2027, 10, 14)},
+		{"in 3 days", date(2026, 10, 17)},
+		{"11/3", date(2026, 11, 3)},
+		{"10/14", date(2026, 10, 14)},
+		{"1/5", date(2027, 1, 5)},
+		{"2026/11/3", date(2026, 11, 3)},
+		{"１１／３", date(2026, 11, 3)},
+		{"今日", date(2026, 10, 14)},
+		{"明日", date(2026, 10, 15)},
+		{"明後日", date(2026, 10, 16)},
+		{"金曜", date(2026, 10, 16)},
+		{"金曜日", date(2026, 10, 16)},
+		{"今週月曜", date(2026, 10, 12)},
+		{"来週金曜", date(2026, 10, 23)},
+		{"来週の金曜日", date(2026, 10, 23)},
+		{"再来週水曜", date(2026, 10, 28)},
+		{"来週", date(2026, 10, 19)},
+		{"月末", date(2026, 10, 31)},
+		{"来月末", date(2026, 11, 30)},
+		{"3日後", date(2026, 10, 17)},
+		{"2週間後", date(2026, 10, 28)},
+		{"1ヶ月後", date(2026, 11, 14)},
+		{"11月3日", date(2026, 11, 3)},
+		{"2027年1月5日", date(2027, 1, 5)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseDueDate(tt.input, now)
+			if err != nil {
+				t.Fatalf("ParseDueDate(%q) error = %v", tt.input, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseDueDate(%q) = %s, want %s", tt.input, got.Format("2006-01-02 Mon"), tt.want.Format("2006-01-02 Mon"))
+			}
+		})
+	}
+}
+
+func TestParseDueDate_Invalid(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)
+
+	inputs := []string{"", "   ", "someday", "2/30", "2026-13-01", "+3x", "next someday", "金",
+		"+99999999999999999999d", "in 99999999999999999999 days", "99999999999999999999日後"}
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			if got, err := ParseDueDate(input, now); err == nil {
+				t.Errorf("ParseDueDate(%q) = %s, want error", input, got.Format("2006-01-02"))
+			}
+		})
+	}
+}
+
+func TestParseDueDate_MonthClamp(t *testing.T) {
+	now := time.Date(2026, 1, 31, 9, 0, 0, 0, time.UTC)
+
+	got, err := ParseDueDate("+1m", now)
+	if err != nil {
+		t.Fatalf("ParseDueDate() error = %v", err)
+	}
+	want := time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("ParseDueDate(+1m) = %s, want %s", got.Format("2006-01-02"), want.Format("2006-01-02"))
+	}
+}
+
+func TestParseDueDate_LeapDay(t *testing.T) {
+	tests := []struct {
+		name  string
+		now   time.Time
+		input string
+		want  time.Time
+	}{
+		{"two years ahead", time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC), "2/29", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"japanese", time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC), "2月29日", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"just passed", time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC), "2/29", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"today", time.Date(2024, 2, 29, 9, 0, 0, 0, time.UTC), "2/29", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"over a century year", time.Date(2096, 3, 1, 9, 0, 0, 0, time.UTC), "2/29", time.Date(2104, 2, 29, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDueDate(tt.input, tt.now)
+			if err != nil {
+				t.Fatalf("ParseDueDate(%q) error = %v", tt.input, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseDueDate(%q) = %s, want %s", tt.input, got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+			}
+		})
+	}
+}
+
+func TestParseDueDate_KeepsLocation(t *testing.T) {
+	loc := time.FixedZone("JST", 9*60*60)
+	now := time.Date(2026, 10, 14, 23, 30, 0, 0, loc)
+
+	got, err := ParseDueDate("tomorrow", now)
+	if err != nil {
+		t.Fatalf("ParseDueDate() error = %v", err)
+	}
+	want := time.Date(2026, 10, 15, 0, 0, 0, 0, loc)
+	if !got.Equal(want) || got.Location() != loc {
+		t.Errorf("ParseDueDate(tomorrow) = %v, want %v", got, want)
+	}
+}
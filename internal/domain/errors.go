@@ -0,0 +1,27 @@
+package domain
+
+import "errors"
+
+// Errors returned by TaskRepository implementations. Wrapped errors add
+// detail, so compare with errors.Is.
+var (
+	// ErrNotFound means the task or category does not exist
+	ErrNotFound = errors.New("not found")
+
+	// ErrDuplicateCategory means a category with the same name exists
+	ErrDuplicateCategory = errors.New("category name already exists")
+
+	// ErrConflict means the task was changed by someone else since it was
+	// loaded, so the update was not applied
+	ErrConflict = errors.New("task was modified elsewhere")
+)
+
+// ValidationError reports an invalid field of a task or category
+type ValidationError struct {
+	Field   string // e.g. "title", "priority" or "color"
+	Message string // Complete message, e.g. "title is required"
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
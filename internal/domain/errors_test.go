@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidationError_Field(t *testing.T) {
+	valid := func() *Task {
+		return &Task{Title: "Task", Status: TaskStatusNew, Priority: PriorityLow}
+	}
+
+	tests := []struct {
+		name      string
+		validate  func() error
+		wantField string
+	}{
+		{"empty title", func() error { task := valid(); task.Title = " "; return task.Validate() }, "title"},
+		{"long title", func() error { task := valid(); task.Title = strings.Repeat("a", 201); return task.Validate() }, "title"},
+		{"long description", func() error { task := valid(); task.Description = strings.Repeat("a", 1001); return task.Validate() }, "description"},
+		{"bad status", func() error { task := valid(); task.Status = "done"; return task.Validate() }, "status"},
+		{"bad priority", func() error { task := valid(); task.Priority = "urgent"; return task.Validate() }, "priority"},
+		{"SetStatus", func() error { return valid().SetStatus("done", time.Now()) }, "status"},
+		{"category name", func() error { return (&Category{Color: "red"}).Validate() }, "name"},
+		{"category color", func() error { return (&Category{Name: "Work", Color: "orange"}).Validate() }, "color"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var verr *ValidationError
+			if err := tt.validate(); !errors.As(err, &verr) {
+				t.Fatalf("error = %v, want a *ValidationError", err)
+			}
+			if verr.Field != tt.wantField {
+				t.Errorf("Field = %q, want %q", verr.Field, tt.wantField)
+			}
+			if !strings.Contains(verr.Error(), tt.wantField) {
+				t.Errorf("Error() = %q, want it to name %q", verr.Error(), tt.wantField)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// EventType identifies a change to a task
+type EventType string
+
+const (
+	EventTaskCreated       EventType = "task.created"
+	EventTaskUpdated       EventType = "task.updated"
+	EventTaskStatusChanged EventType = "task.status_changed" // Sent alongside task.updated
+	EventTaskDeleted       EventType = "task.deleted"
+
+	// EventsDropped tells a subscriber that it fell behind and missed
+	// events, so its copy of the tasks must be reloaded
+	EventsDropped EventType = "events.dropped"
+)
+
+// Event describes a change to a task
+type Event struct {
+	Type           EventType
+	Task           *Task      // State after the change; the deleted task for EventTaskDeleted, nil for EventsDropped
+	PreviousStatus TaskStatus // Set for EventTaskStatusChanged
+	OccurredAt     time.Time
+}
@@ -11,9 +11,53 @@ type DateRange int
 const (
 	DateRangeAll DateRange = iota
 	DateRangeToday
-	DateRangeThisWeek
-	DateRangeOverdue
+	DateRangeThisWeek // Calendar week containing today, starting on Filter.WeekStart
+	DateRangeOverdue  // Before today
 	DateRangeNoDueDate
+	DateRangeThisMonth // Calendar month containing today
+	DateRangeNextDays  // Today through Filter.NextDays days ahead
+	DateRangeCustom    // Filter.RangeFrom through Filter.RangeTo, either may be open
+)
+
+// DefaultNextDays is used by DateRangeNextDays when Filter.NextDays is not set
+const DefaultNextDays = 7
+
+// DateField selects the timestamp a date range applies to
+type DateField int
+
+const (
+	DateFieldDue DateField = iota
+	DateFieldCreated
+	DateFieldCompleted
+)
+
+// String returns the display name of the date field
+func (d DateField) String() string {
+	switch d {
+	case DateFieldDue:
+		return "Due"
+	case DateFieldCreated:
+		return "Created"
+	case DateFieldCompleted:
+		return "Completed"
+	default:
+		return "Unknown"
+	}
+}
+
+// CanBeUnset reports whether tasks may lack the date, so that
+// DateRangeNoDueDate can match. Every task has a creation time.
+func (d DateField) CanBeUnset() bool {
+	return d != DateFieldCreated
+}
+
+// ArchivedMatch selects tasks by whether they are archived
+type ArchivedMatch int
+
+const (
+	ArchivedAny     ArchivedMatch = iota
+	ArchivedExclude               // Only tasks that are not archived
+	ArchivedOnly                  // Only archived tasks
 )
 
 // Filter represents task filtering criteria
@@ -22,7 +66,67 @@ type Filter struct {
 	Priorities []Priority
 	Categories []int64
 	DateRange  DateRange
+	DateField  DateField  // Timestamp DateRange applies to; DateRangeNoDueDate matches it being unset
+	RangeFrom  *time.Time // First day of DateRangeCustom, nil for no lower bound
+	RangeTo    *time.Time // Last day (inclusive) of DateRangeCustom, nil for no upper bound
+	NextDays   int        // Length of DateRangeNextDays, DefaultNextDays if zero
+	WeekStart  time.Weekday
 	SearchText string
+
+	// Timestamp criteria, combined with each other and with DateRange
+	Created   TimeCriterion
+	Started   TimeCriterion
+	Completed TimeCriterion
+
+	Archived ArchivedMatch
+}
+
+// TimeCriterion restricts one task timestamp. Every condition that is set
+// must hold; the zero value matches everything.
+type TimeCriterion struct {
+	After     *time.Time    // At or after this instant
+	Before    *time.Time    // Strictly before this instant
+	OlderThan time.Duration // At least this long before now
+	Unset     bool          // The timestamp must not be set, e.g. not yet started
+}
+
+// IsZero returns true if no condition is set
+func (c TimeCriterion) IsZero() bool {
+	return c.After == nil && c.Before == nil && c.OlderThan == 0 && !c.Unset
+}
+
+// Within returns a criterion matching [start, end)
+func Within(start, end time.Time) TimeCriterion {
+	return TimeCriterion{After: &start, Before: &end}
+}
+
+// match checks value, which is nil when the timestamp is not set
+func (c TimeCriterion) match(value *time.Time, now time.Time) bool {
+	if c.Unset {
+		return value == nil
+	}
+	if c.IsZero() {
+		return true
+	}
+	if value == nil {
+		return false
+	}
+	if c.After != nil && value.Before(*c.After) {
+		return false
+	}
+	if c.Before != nil && !value.Before(*c.Before) {
+		return false
+	}
+	if c.OlderThan > 0 && now.Sub(*value) < c.OlderThan {
+		return false
+	}
+	return true
+}
+
+// LastWeek returns the previous calendar week as [start, end)
+func LastWeek(now time.Time, weekStart time.Weekday) (start, end time.Time) {
+	end = startOfWeek(now, weekStart)
+	return end.AddDate(0, 0, -7), end
 }
 
 // IsEmpty returns true if no filter criteria are set
@@ -31,11 +135,20 @@ func (f *Filter) IsEmpty() bool {
 		len(f.Priorities) == 0 &&
 		len(f.Categories) == 0 &&
 		f.DateRange == DateRangeAll &&
-		f.SearchText == ""
+		f.SearchText == "" &&
+		f.Created.IsZero() &&
+		f.Started.IsZero() &&
+		f.Completed.IsZero() &&
+		f.Archived == ArchivedAny
 }
 
 // Match returns true if the task matches all filter criteria
 func (f *Filter) Match(task *Task) bool {
+	return f.MatchAt(task, time.Now())
+}
+
+// MatchAt is Match with date ranges evaluated relative to now
+func (f *Filter) MatchAt(task *Task, now time.Time) bool {
 	// Empty filter matches everything
 	if f.IsEmpty() {
 		return true
@@ -87,38 +200,26 @@ func (f *Filter) Match(task *Task) bool {
 	}
 
 	// Check date range
-	if f.DateRange != DateRangeAll {
-		now := time.Now()
-		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-
-		switch f.DateRange {
-		case DateRangeToday:
-			if task.DueDate == nil {
-				return false
-			}
-			dueDay := time.Date(task.DueDate.Year(), task.DueDate.Month(), task.DueDate.Day(), 0, 0, 0, 0, task.DueDate.Location())
-			if !dueDay.Equal(today) {
-				return false
-			}
-		case DateRangeThisWeek:
-			if task.DueDate == nil {
-				return false
-			}
-			weekEnd := today.AddDate(0, 0, 7)
-			if task.DueDate.Before(today) || task.DueDate.After(weekEnd) {
-				return false
-			}
-		case DateRangeOverdue:
-			if task.DueDate == nil {
-				return false
-			}
-			if !task.DueDate.Before(today) {
-				return false
-			}
-		case DateRangeNoDueDate:
-			if task.DueDate != nil {
-				return false
-			}
+	if f.DateRange != DateRangeAll && !f.matchDateRange(task, now) {
+		return false
+	}
+
+	// Check timestamp criteria
+	if !f.Created.match(&task.CreatedAt, now) ||
+		!f.Started.match(task.StartedAt, now) ||
+		!f.Completed.match(task.CompletedAt, now) {
+		return false
+	}
+
+	// Check archived
+	switch f.Archived {
+	case ArchivedExclude:
+		if task.ArchivedAt != nil {
+			return false
+		}
+	case ArchivedOnly:
+		if task.ArchivedAt == nil {
+			return false
 		}
 	}
 
@@ -127,7 +228,7 @@ func (f *Filter) Match(task *Task) bool {
 		searchLower := strings.ToLower(f.SearchText)
 		titleLower := strings.ToLower(task.Title)
 		descLower := strings.ToLower(task.Description)
-		if !strings.Contains(titleLower, searchLower) && !strings.Contains(descLower, searchLower) {
+		if !strings.Contains(titleLower, searchLower) && !strings.Contains(descLower, searchLower) && !notesContain(task.Notes, searchLower) {
 			return false
 		}
 	}
@@ -135,6 +236,93 @@ func (f *Filter) Match(task *Task) bool {
 	return true
 }
 
+// notesContain reports whether any note contains the lower-case text
+func notesContain(notes []Note, text string) bool {
+	for _, note := range notes {
+		if strings.Contains(strings.ToLower(note.Text), text) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchDateRange checks the date range against the selected date field
+func (f *Filter) matchDateRange(task *Task, now time.Time) bool {
+	value := f.dateValue(task, now.Location())
+
+	if f.DateRange == DateRangeNoDueDate {
+		return value == nil
+	}
+	if value == nil {
+		return false
+	}
+
+	start, end := f.bounds(now)
+	if start != nil && value.Before(*start) {
+		return false
+	}
+	if end != nil && !value.Before(*end) {
+		return false
+	}
+	return true
+}
+
+// dateValue returns the task timestamp selected by DateField. Due dates are
+// calendar days, so they are compared as midnight in loc.
+func (f *Filter) dateValue(task *Task, loc *time.Location) *time.Time {
+	switch f.DateField {
+	case DateFieldCreated:
+		return &task.CreatedAt
+	case DateFieldCompleted:
+		return task.CompletedAt
+	default:
+		if task.DueDate == nil {
+			return nil
+		}
+		day := time.Date(task.DueDate.Year(), task.DueDate.Month(), task.DueDate.Day(), 0, 0, 0, 0, loc)
+		return &day
+	}
+}
+
+// bounds returns the half-open interval [start, end) of the date range.
+// A nil bound is open-ended.
+func (f *Filter) bounds(now time.Time) (start, end *time.Time) {
+	today := startOfDay(now)
+	span := func(from, to time.Time) (*time.Time, *time.Time) {
+		return &from, &to
+	}
+
+	switch f.DateRange {
+	case DateRangeToday:
+		return span(today, today.AddDate(0, 0, 1))
+	case DateRangeThisWeek:
+		weekStart := startOfWeek(today, f.WeekStart)
+		return span(weekStart, weekStart.AddDate(0, 0, 7))
+	case DateRangeThisMonth:
+		monthStart := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, today.Location())
+		return span(monthStart, monthStart.AddDate(0, 1, 0))
+	case DateRangeNextDays:
+		days := f.NextDays
+		if days <= 0 {
+			days = DefaultNextDays
+		}
+		return span(today, today.AddDate(0, 0, days+1))
+	case DateRangeOverdue:
+		return nil, &today
+	case DateRangeCustom:
+		if f.RangeFrom != nil {
+			from := time.Date(f.RangeFrom.Year(), f.RangeFrom.Month(), f.RangeFrom.Day(), 0, 0, 0, 0, now.Location())
+			start = &from
+		}
+		if f.RangeTo != nil {
+			to := time.Date(f.RangeTo.Year(), f.RangeTo.Month(), f.RangeTo.Day()+1, 0, 0, 0, 0, now.Location())
+			end = &to
+		}
+		return start, end
+	}
+	return nil, nil
+}
+
 // Apply filters a slice of tasks
 func (f *Filter) Apply(tasks []*Task) []*Task {
 	if f.IsEmpty() {
@@ -100,6 +100,19 @@ func TestFilter_Match(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "search text matches a note",
+			filter: Filter{
+				SearchText: "VENDOR",
+			},
+			task: Task{
+				Title:    "Task",
+				Status:   TaskStatusNew,
+				Priority: PriorityMedium,
+				Notes:    []Note{{Text: "Sent the order"}, {Text: "Waiting on vendor reply"}},
+			},
+			want: true,
+		},
 		{
 			name: "search text no match",
 			filter: Filter{
@@ -177,9 +190,9 @@ func TestFilter_Match(t *testing.T) {
 			want: true,
 		},
 		{
-			name: "this week filter matches",
+			name: "next days filter matches",
 			filter: Filter{
-				DateRange: DateRangeThisWeek,
+				DateRange: DateRangeNextDays,
 			},
 			task: Task{
 				Title:    "Test",
@@ -253,6 +266,275 @@ func TestFilter_Match(t *testing.T) {
 	}
 }
 
+func TestFilter_MatchAt_DateRanges(t *testing.T) {
+	// Wednesday afternoon
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)
+	day := func(m time.Month, d int) *time.Time {
+		t := time.Date(2026, m, d, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+	at := func(m time.Month, d, hour int) time.Time {
+		return time.Date(2026, m, d, hour, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		task   Task
+		want   bool
+	}{
+		{
+			name:   "calendar week starting monday includes sunday",
+			filter: Filter{DateRange: DateRangeThisWeek, WeekStart: time.Monday},
+			task:   Task{DueDate: day(10, 18)},
+			want:   true,
+		},
+		{
+			name:   "calendar week starting monday excludes next monday",
+			filter: Filter{DateRange: DateRangeThisWeek, WeekStart: time.Monday},
+			task:   Task{DueDate: day(10, 19)},
+			want:   false,
+		},
+		{
+			name:   "calendar week starting monday includes past monday",
+			filter: Filter{DateRange: DateRangeThisWeek, WeekStart: time.Monday},
+			task:   Task{DueDate: day(10, 12)},
+			want:   true,
+		},
+		{
+			name:   "calendar week starting sunday excludes following sunday",
+			filter: Filter{DateRange: DateRangeThisWeek, WeekStart: time.Sunday},
+			task:   Task{DueDate: day(10, 18)},
+			want:   false,
+		},
+		{
+			name:   "calendar week starting sunday includes past sunday",
+			filter: Filter{DateRange: DateRangeThisWeek, WeekStart: time.Sunday},
+			task:   Task{DueDate: day(10, 11)},
+			want:   true,
+		},
+		{
+			name:   "this month includes last day",
+			filter: Filter{DateRange: DateRangeThisMonth},
+			task:   Task{DueDate: day(10, 31)},
+			want:   true,
+		},
+		{
+			name:   "this month excludes next month",
+			filter: Filter{DateRange: DateRangeThisMonth},
+			task:   Task{DueDate: day(11, 1)},
+			want:   false,
+		},
+		{
+			name:   "next 3 days includes third day",
+			filter: Filter{DateRange: DateRangeNextDays, NextDays: 3},
+			task:   Task{DueDate: day(10, 17)},
+			want:   true,
+		},
+		{
+			name:   "next 3 days excludes fourth day",
+			filter: Filter{DateRange: DateRangeNextDays, NextDays: 3},
+			task:   Task{DueDate: day(10, 18)},
+			want:   false,
+		},
+		{
+			name:   "next days excludes yesterday",
+			filter: Filter{DateRange: DateRangeNextDays},
+			task:   Task{DueDate: day(10, 13)},
+			want:   false,
+		},
+		{
+			name:   "custom range is inclusive",
+			filter: Filter{DateRange: DateRangeCustom, RangeFrom: day(10, 1), RangeTo: day(10, 5)},
+			task:   Task{DueDate: day(10, 5)},
+			want:   true,
+		},
+		{
+			name:   "custom range excludes day after",
+			filter: Filter{DateRange: DateRangeCustom, RangeFrom: day(10, 1), RangeTo: day(10, 5)},
+			task:   Task{DueDate: day(10, 6)},
+			want:   false,
+		},
+		{
+			name:   "custom range with open start",
+			filter: Filter{DateRange: DateRangeCustom, RangeTo: day(10, 5)},
+			task:   Task{DueDate: day(1, 1)},
+			want:   true,
+		},
+		{
+			name:   "custom range with open end",
+			filter: Filter{DateRange: DateRangeCustom, RangeFrom: day(10, 1)},
+			task:   Task{DueDate: day(9, 30)},
+			want:   false,
+		},
+		{
+			name:   "created today",
+			filter: Filter{DateRange: DateRangeToday, DateField: DateFieldCreated},
+			task:   Task{CreatedAt: at(10, 14, 9)},
+			want:   true,
+		},
+		{
+			name:   "created yesterday is not today",
+			filter: Filter{DateRange: DateRangeToday, DateField: DateFieldCreated},
+			task:   Task{CreatedAt: at(10, 13, 23), DueDate: day(10, 14)},
+			want:   false,
+		},
+		{
+			name:   "completed within custom range",
+			filter: Filter{DateRange: DateRangeCustom, DateField: DateFieldCompleted, RangeFrom: day(10, 5), RangeTo: day(10, 11)},
+			task:   Task{CompletedAt: func() *time.Time { t := at(10, 11, 18); return &t }()},
+			want:   true,
+		},
+		{
+			name:   "not completed has no completed date",
+			filter: Filter{DateRange: DateRangeNoDueDate, DateField: DateFieldCompleted},
+			task:   Task{DueDate: day(10, 14)},
+			want:   true,
+		},
+		{
+			name:   "due date in another zone compares by calendar day",
+			filter: Filter{DateRange: DateRangeToday},
+			task: Task{DueDate: func() *time.Time {
+				t := time.Date(2026, 10, 14, 0, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+				return &t
+			}()},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.MatchAt(&tt.task, now); got != tt.want {
+				t.Errorf("Filter.MatchAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilter_MatchAt_TimeCriteria(t *testing.T) {
+	// Wednesday afternoon
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)
+	at := func(m time.Month, d int) *time.Time {
+		t := time.Date(2026, m, d, 10, 0, 0, 0, time.UTC)
+		return &t
+	}
+	lastWeekStart, lastWeekEnd := LastWeek(now, time.Monday)
+
+	tests := []struct {
+		name   string
+		filter Filter
+		task   Task
+		want   bool
+	}{
+		{
+			name:   "completed last week matches",
+			filter: Filter{Completed: Within(lastWeekStart, lastWeekEnd)},
+			task:   Task{Status: TaskStatusCompleted, CompletedAt: at(10, 9)},
+			want:   true,
+		},
+		{
+			name:   "completed this week excluded",
+			filter: Filter{Completed: Within(lastWeekStart, lastWeekEnd)},
+			task:   Task{Status: TaskStatusCompleted, CompletedAt: at(10, 12)},
+			want:   false,
+		},
+		{
+			name:   "not completed excluded from completed criterion",
+			filter: Filter{Completed: Within(lastWeekStart, lastWeekEnd)},
+			task:   Task{Status: TaskStatusWorking},
+			want:   false,
+		},
+		{
+			name: "created long ago and not started matches",
+			filter: Filter{
+				Created: TimeCriterion{OlderThan: 14 * 24 * time.Hour},
+				Started: TimeCriterion{Unset: true},
+			},
+			task: Task{Status: TaskStatusNew, CreatedAt: *at(9, 20)},
+			want: true,
+		},
+		{
+			name: "created recently excluded",
+			filter: Filter{
+				Created: TimeCriterion{OlderThan: 14 * 24 * time.Hour},
+				Started: TimeCriterion{Unset: true},
+			},
+			task: Task{Status: TaskStatusNew, CreatedAt: *at(10, 5)},
+			want: false,
+		},
+		{
+			name: "created long ago but started excluded",
+			filter: Filter{
+				Created: TimeCriterion{OlderThan: 14 * 24 * time.Hour},
+				Started: TimeCriterion{Unset: true},
+			},
+			task: Task{Status: TaskStatusWorking, CreatedAt: *at(9, 20), StartedAt: at(10, 1)},
+			want: false,
+		},
+		{
+			name:   "started after bound matches",
+			filter: Filter{Started: TimeCriterion{After: at(10, 1)}},
+			task:   Task{Status: TaskStatusWorking, StartedAt: at(10, 2)},
+			want:   true,
+		},
+		{
+			name:   "started before bound excluded",
+			filter: Filter{Started: TimeCriterion{After: at(10, 1)}},
+			task:   Task{Status: TaskStatusWorking, StartedAt: at(9, 30)},
+			want:   false,
+		},
+		{
+			name:   "archived excluded",
+			filter: Filter{Archived: ArchivedExclude},
+			task:   Task{Status: TaskStatusCompleted, ArchivedAt: at(10, 1)},
+			want:   false,
+		},
+		{
+			name:   "not archived matches exclusion",
+			filter: Filter{Archived: ArchivedExclude},
+			task:   Task{Status: TaskStatusCompleted},
+			want:   true,
+		},
+		{
+			name:   "only archived excludes the rest",
+			filter: Filter{Archived: ArchivedOnly},
+			task:   Task{Status: TaskStatusCompleted},
+			want:   false,
+		},
+		{
+			name:   "archived matches by default",
+			filter: Filter{},
+			task:   Task{Status: TaskStatusCompleted, ArchivedAt: at(10, 1)},
+			want:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.MatchAt(&tt.task, now); got != tt.want {
+				t.Errorf("Filter.MatchAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLastWeek(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)
+
+	start, end := LastWeek(now, time.Monday)
+	if want := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC); !start.Equal(want) {
+		t.Errorf("start = %v, want %v", start, want)
+	}
+	if want := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC); !end.Equal(want) {
+		t.Errorf("end = %v, want %v", end, want)
+	}
+
+	start, _ = LastWeek(now, time.Sunday)
+	if want := time.Date(2026, 10, 4, 0, 0, 0, 0, time.UTC); !start.Equal(want) {
+		t.Errorf("start (sunday) = %v, want %v", start, want)
+	}
+}
+
 func TestFilter_IsEmpty(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -278,6 +560,27 @@ func TestFilter_IsEmpty(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "filter with timestamp criterion",
+			filter: Filter{
+				Started: TimeCriterion{Unset: true},
+			},
+			want: false,
+		},
+		{
+			name: "filter excluding archived",
+			filter: Filter{
+				Archived: ArchivedExclude,
+			},
+			want: false,
+		},
+		{
+			name: "week start alone is empty",
+			filter: Filter{
+				WeekStart: time.Monday,
+			},
+			want: true,
+		},
 	}
 
 	for _, tt := range tests {
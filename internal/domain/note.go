@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// MaxNoteLen bounds the length of a note in bytes
+const MaxNoteLen = 1000
+
+// Note is a timestamped remark appended to a task, such as "waiting on
+// vendor reply". Notes are only ever added, through TaskRepository.AddNote,
+// so they keep the history the description would overwrite.
+type Note struct {
+	ID        int64
+	TaskID    int64
+	Text      string
+	CreatedAt time.Time
+}
+
+// Validate checks if the note has valid data
+func (n *Note) Validate() error {
+	if strings.TrimSpace(n.Text) == "" {
+		return &ValidationError{Field: "text", Message: "note is empty"}
+	}
+	if len(n.Text) > MaxNoteLen {
+		return &ValidationError{Field: "text", Message: "note must be 1000 characters or less"}
+	}
+	return nil
+}
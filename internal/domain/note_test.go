@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNote_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{name: "valid note", text: "waiting on vendor reply"},
+		{name: "empty", text: "", wantErr: true},
+		{name: "only spaces", text: "  \n ", wantErr: true},
+		{name: "exactly the limit", text: strings.Repeat("a", MaxNoteLen)},
+		{name: "too long", text: strings.Repeat("a", MaxNoteLen+1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			note := &Note{TaskID: 1, Text: tt.text}
+			err := note.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
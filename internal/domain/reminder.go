@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultReminderHour is used when a reminder is given as a date without a time
+const DefaultReminderHour = 9
+
+var reminderTimeRe = regexp.MustCompile(`^(.*?)\s*(\d{1,2}):(\d{2})$`)
+
+// ParseReminder resolves a reminder time expression relative to now.
+//
+// It accepts a Go duration offset ("+30m", "+2h", "+1h30m"), a time of day
+// ("14:30", today or tomorrow if already past), any ParseDueDate expression
+// optionally followed by a time ("fri 14:30", "明日 9:00"), or a date alone,
+// which reminds at DefaultReminderHour.
+func ParseReminder(input string, now time.Time) (time.Time, error) {
+	s := normalizeDateInput(input)
+	if s == "" {
+		return time.Time{}, fmt.Errorf("empty reminder")
+	}
+
+	if strings.HasPrefix(s, "+") {
+		if d, err := time.ParseDuration(strings.TrimSpace(s[1:])); err == nil {
+			return now.Add(d), nil
+		}
+	}
+
+	if m := reminderTimeRe.FindStringSubmatch(s); m != nil {
+		hour, _ := strconv.Atoi(m[2])
+		minute, _ := strconv.Atoi(m[3])
+		if hour > 23 || minute > 59 {
+			return time.Time{}, fmt.Errorf("invalid time %s:%s", m[2], m[3])
+		}
+
+		if m[1] == "" {
+			t := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location())
+			if !t.After(now) {
+				t = t.AddDate(0, 0, 1)
+			}
+			return t, nil
+		}
+
+		day, err := ParseDueDate(m[1], now)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute), nil
+	}
+
+	day, err := ParseDueDate(s, now)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return day.Add(DefaultReminderHour * time.Hour), nil
+}
+
+// ReminderDue reports whether the task's reminder should fire at now.
+// Completed and archived tasks never fire.
+func (t *Task) ReminderDue(now time.Time) bool {
+	if t.RemindAt == nil || t.Status == TaskStatusCompleted || t.ArchivedAt != nil {
+		return false
+	}
+	return !t.RemindAt.After(now)
+}
+
+// Snooze pushes the reminder to d after now
+func (t *Task) Snooze(d time.Duration, now time.Time) {
+	at := now.Add(d)
+	t.RemindAt = &at
+}
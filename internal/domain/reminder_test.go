@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseReminder(t *testing.T) {
+	// Wednesday afternoon
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)
+	at := func(m time.Month, d, hour, min int) time.Time {
+		return time.Date(2026, m, d, hour, min, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"+30m", at(10, 14, 16, 0)},
+		{"+2h", at(10, 14, 17, 30)},
+		{"+1h30m", at(10, 14, 17, 0)},
+		{"16:00", at(10, 14, 16, 0)},
+		{"9:00", at(10, 15, 9, 0)},
+		{"tomorrow", at(10, 15, 9, 0)},
+		{"fri 14:30", at(10, 16, 14, 30)},
+		{"2026-11-03 08:15", at(11, 3, 8, 15)},
+		{"明日 10:00", at(10, 15, 10, 0)},
+		{"+3d", at(10, 17, 9, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseReminder(tt.input, now)
+			if err != nil {
+				t.Fatalf("ParseReminder(%q) error = %v", tt.input, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseReminder(%q) = %s, want %s", tt.input, got.Format(time.RFC3339), tt.want.Format(time.RFC3339))
+			}
+		})
+	}
+}
+
+func TestParseReminder_Invalid(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)
+
+	for _, input := range []string{"", "25:00", "someday 10:00", "later", "+99999999999999999999d 10:00"} {
+		t.Run(input, func(t *testing.T) {
+			if _, err := ParseReminder(input, now); err == nil {
+				t.Errorf("ParseReminder(%q) expected error", input)
+			}
+		})
+	}
+}
+
+func TestTask_ReminderDue(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Minute)
+
+	tests := []struct {
+		name string
+		task Task
+		want bool
+	}{
+		{"no reminder", Task{Status: TaskStatusNew}, false},
+		{"past reminder", Task{Status: TaskStatusNew, RemindAt: &past}, true},
+		{"reminder exactly now", Task{Status: TaskStatusWorking, RemindAt: &now}, true},
+		{"future reminder", Task{Status: TaskStatusNew, RemindAt: &future}, false},
+		{"completed task", Task{Status: TaskStatusCompleted, RemindAt: &past}, false},
+		{"archived task", Task{Status: TaskStatusNew, RemindAt: &past, ArchivedAt: &past}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.task.ReminderDue(now); got != tt.want {
+				t.Errorf("ReminderDue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTask_Snooze(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	task := Task{Status: TaskStatusNew, RemindAt: &past}
+
+	task.Snooze(10*time.Minute, now)
+
+	if task.RemindAt == nil || !task.RemindAt.Equal(now.Add(10*time.Minute)) {
+		t.Errorf("RemindAt = %v, want %v", task.RemindAt, now.Add(10*time.Minute))
+	}
+	if task.ReminderDue(now) {
+		t.Errorf("ReminderDue() = true right after snoozing")
+	}
+}
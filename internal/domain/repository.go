@@ -7,9 +7,26 @@ type TaskRepository interface {
 	// Create creates a new task
 	Create(ctx context.Context, task *Task) error
 
-	// Update updates an existing task
+	// Update updates an existing task. It fails with ErrConflict if the
+	// stored task's Version differs from task.Version, and on success
+	// increments task.Version to match the stored task.
 	Update(ctx context.Context, task *Task) error
 
+	// Restore creates a task again, as undo and import do. It assigns a
+	// new ID and Version 1 like Create but keeps CreatedAt and the other
+	// timestamps, stamping CreatedAt only if it is zero. task.Notes are
+	// added back with new IDs and their own CreatedAt.
+	Restore(ctx context.Context, task *Task) error
+
+	// ClearReminder clears the reminder of a task once it has fired. It
+	// fails with ErrConflict if the stored task's Version differs from
+	// task.Version or its reminder is already cleared, for instance by
+	// another process delivering it first. Like Update it increments
+	// task.Version, so that a copy read before the reminder fired cannot
+	// store it again; unlike Update it runs no hooks or webhooks, as firing
+	// is not an edit.
+	ClearReminder(ctx context.Context, task *Task) error
+
 	// Delete deletes a task by ID
 	Delete(ctx context.Context, id int64) error
 
@@ -19,12 +36,31 @@ type TaskRepository interface {
 	// List retrieves all tasks
 	List(ctx context.Context) ([]*Task, error)
 
+	// AddNote appends a note to the task note.TaskID, setting the note's ID
+	// and CreatedAt. It fails with ErrNotFound if the task does not exist.
+	// Notes are not task edits: the task's Version stays the same and no
+	// hooks or webhooks run.
+	AddNote(ctx context.Context, note *Note) error
+
+	// ListNotes retrieves the notes of a task, oldest first. GetByID and
+	// List fill in Task.Notes the same way.
+	ListNotes(ctx context.Context, taskID int64) ([]*Note, error)
+
 	// CreateCategory creates a new category
 	CreateCategory(ctx context.Context, category *Category) error
 
 	// GetCategories retrieves all categories
 	GetCategories(ctx context.Context) ([]*Category, error)
 
+	// DeleteCategory deletes a category by ID. Its tasks are kept without
+	// a category; their Version stays the same.
+	DeleteCategory(ctx context.Context, id int64) error
+
+	// WithTx runs fn with a repository whose changes are applied all
+	// together if fn returns nil, or not at all if it returns an error. fn
+	// must make its changes through the repository it is given.
+	WithTx(ctx context.Context, fn func(TaskRepository) error) error
+
 	// Close closes the repository connection
 	Close() error
 }
@@ -1,7 +1,6 @@
 package domain
 
 import (
-	"errors"
 	"strings"
 	"time"
 )
@@ -66,29 +65,95 @@ type Task struct {
 	CreatedAt   time.Time
 	StartedAt   *time.Time
 	CompletedAt *time.Time
+	RemindAt    *time.Time
+	ArchivedAt  *time.Time // Archived tasks are kept but hidden from the TUI
+	Checklist   []ChecklistItem
+	Attachments []Attachment
+	Notes       []Note // Oldest first; read only, see TaskRepository.AddNote
+	Version     int64  // Incremented by every update; see TaskRepository.Update
 }
 
+// Length limits checked by Validate, in bytes
+const (
+	MaxTitleLen       = 200
+	MaxDescriptionLen = 1000
+)
+
 // Validate checks if the task has valid data
 func (t *Task) Validate() error {
 	if strings.TrimSpace(t.Title) == "" {
-		return errors.New("title is required")
+		return &ValidationError{Field: "title", Message: "title is required"}
 	}
 
-	if len(t.Title) > 200 {
-		return errors.New("title must be 200 characters or less")
+	if len(t.Title) > MaxTitleLen {
+		return &ValidationError{Field: "title", Message: "title must be 200 characters or less"}
 	}
 
-	if len(t.Description) > 1000 {
-		return errors.New("description must be 1000 characters or less")
+	if len(t.Description) > MaxDescriptionLen {
+		return &ValidationError{Field: "description", Message: "description must be 1000 characters or less"}
+	}
+
+	if err := validateChecklist(t.Checklist); err != nil {
+		return err
+	}
+
+	if err := validateAttachments(t.Attachments); err != nil {
+		return err
 	}
 
 	if !t.Status.IsValid() {
-		return errors.New("invalid status")
+		return &ValidationError{Field: "status", Message: "invalid status"}
 	}
 
 	if !t.Priority.IsValid() {
-		return errors.New("invalid priority")
+		return &ValidationError{Field: "priority", Message: "invalid priority"}
 	}
 
 	return nil
 }
+
+// IsStale reports whether the task has been in working longer than threshold.
+// A zero threshold disables detection.
+func (t *Task) IsStale(now time.Time, threshold time.Duration) bool {
+	if threshold <= 0 || t.Status != TaskStatusWorking || t.StartedAt == nil {
+		return false
+	}
+	return now.Sub(*t.StartedAt) > threshold
+}
+
+// SetStatus moves the task to status and keeps the timestamps consistent:
+// StartedAt is set on entering working, CompletedAt on entering completed,
+// and both are cleared when the task returns to new.
+func (t *Task) SetStatus(status TaskStatus, now time.Time) error {
+	if !status.IsValid() {
+		return &ValidationError{Field: "status", Message: "invalid status"}
+	}
+
+	switch status {
+	case TaskStatusNew:
+		t.StartedAt = nil
+		t.CompletedAt = nil
+	case TaskStatusWorking:
+		if t.StartedAt == nil {
+			t.StartedAt = &now
+		}
+		t.CompletedAt = nil
+	case TaskStatusCompleted:
+		if t.CompletedAt == nil {
+			t.CompletedAt = &now
+		}
+	}
+	t.Status = status
+	return nil
+}
+
+// NextStatus returns the status after the current one in the
+// new -> working -> completed flow. Completed tasks stay completed.
+func (t *Task) NextStatus() TaskStatus {
+	switch t.Status {
+	case TaskStatusNew:
+		return TaskStatusWorking
+	default:
+		return TaskStatusCompleted
+	}
+}
@@ -3,6 +3,7 @@ package domain
 import (
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestTaskStatus_String(t *testing.T) {
@@ -239,6 +240,49 @@ func TestTask_Validate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "empty checklist item",
+			task: &Task{
+				Title:     "Test Task",
+				Status:    TaskStatusNew,
+				Priority:  PriorityMedium,
+				Checklist: []ChecklistItem{{Text: "Draft"}, {Text: " "}},
+			},
+			wantErr: true,
+			errMsg:  "checklist item 2 is empty",
+		},
+		{
+			name: "too many checklist items",
+			task: &Task{
+				Title:     "Test Task",
+				Status:    TaskStatusNew,
+				Priority:  PriorityMedium,
+				Checklist: make([]ChecklistItem, MaxChecklistItems+1),
+			},
+			wantErr: true,
+			errMsg:  "checklist must have 100 items or fewer",
+		},
+		{
+			name: "file and URL attachments",
+			task: &Task{
+				Title:       "Test Task",
+				Status:      TaskStatusNew,
+				Priority:    PriorityMedium,
+				Attachments: []Attachment{{Location: "/home/me/spec.pdf"}, {Location: "https://example.com/issue/1"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "relative attachment path",
+			task: &Task{
+				Title:       "Test Task",
+				Status:      TaskStatusNew,
+				Priority:    PriorityMedium,
+				Attachments: []Attachment{{Location: "spec.pdf"}},
+			},
+			wantErr: true,
+			errMsg:  "attachment 1 must be a URL or an absolute path",
+		},
 		{
 			name: "description exactly 1000 characters",
 			task: &Task{
@@ -270,3 +314,106 @@ func TestTask_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestTask_IsStale(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)
+	longAgo := now.Add(-10 * 24 * time.Hour)
+	recently := now.Add(-time.Hour)
+	threshold := 7 * 24 * time.Hour
+
+	tests := []struct {
+		name      string
+		task      Task
+		threshold time.Duration
+		want      bool
+	}{
+		{"working for too long", Task{Status: TaskStatusWorking, StartedAt: &longAgo}, threshold, true},
+		{"working recently", Task{Status: TaskStatusWorking, StartedAt: &recently}, threshold, false},
+		{"completed task", Task{Status: TaskStatusCompleted, StartedAt: &longAgo}, threshold, false},
+		{"new task", Task{Status: TaskStatusNew}, threshold, false},
+		{"detection disabled", Task{Status: TaskStatusWorking, StartedAt: &longAgo}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.task.IsStale(now, tt.threshold); got != tt.want {
+				t.Errorf("IsStale() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTask_SetStatus(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)
+	earlier := now.Add(-time.Hour)
+
+	tests := []struct {
+		name          string
+		task          Task
+		status        TaskStatus
+		wantStarted   *time.Time
+		wantCompleted *time.Time
+		wantErr       bool
+	}{
+		{"new to working", Task{Status: TaskStatusNew}, TaskStatusWorking, &now, nil, false},
+		{"working to completed keeps start", Task{Status: TaskStatusWorking, StartedAt: &earlier}, TaskStatusCompleted, &earlier, &now, false},
+		{"completed to new clears timestamps", Task{Status: TaskStatusCompleted, StartedAt: &earlier, CompletedAt: &earlier}, TaskStatusNew, nil, nil, false},
+		{"completed to working reopens", Task{Status: TaskStatusCompleted, StartedAt: &earlier, CompletedAt: &earlier}, TaskStatusWorking, &earlier, nil, false},
+		{"invalid status", Task{Status: TaskStatusNew}, TaskStatus("done"), nil, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := tt.task
+			err := task.SetStatus(tt.status, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("SetStatus() expected error")
+				}
+				if task.Status != tt.task.Status {
+					t.Errorf("Status = %v, want unchanged %v", task.Status, tt.task.Status)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SetStatus() error = %v", err)
+			}
+			if task.Status != tt.status {
+				t.Errorf("Status = %v, want %v", task.Status, tt.status)
+			}
+			if !equalTimePtr(task.StartedAt, tt.wantStarted) {
+				t.Errorf("StartedAt = %v, want %v", task.StartedAt, tt.wantStarted)
+			}
+			if !equalTimePtr(task.CompletedAt, tt.wantCompleted) {
+				t.Errorf("CompletedAt = %v, want %v", task.CompletedAt, tt.wantCompleted)
+			}
+		})
+	}
+}
+
+func TestTask_NextStatus(t *testing.T) {
+	tests := []struct {
+		status TaskStatus
+		want   TaskStatus
+	}{
+		{TaskStatusNew, TaskStatusWorking},
+		{TaskStatusWorking, TaskStatusCompleted},
+		{TaskStatusCompleted, TaskStatusCompleted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status.String(), func(t *testing.T) {
+			task := Task{Status: tt.status}
+			if got := task.NextStatus(); got != tt.want {
+				t.Errorf("NextStatus() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func equalTimePtr(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
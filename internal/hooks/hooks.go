@@ -0,0 +1,197 @@
+// Package hooks runs user scripts on task lifecycle events, in the manner of
+// Taskwarrior hooks.
+//
+// Executables in the hooks directory whose names start with an event name
+// (on-add, on-modify, on-complete, on-delete) run in name order, e.g.
+// on-add or on-add-10-tag.sh. Each receives the task as one line of JSON on
+// stdin; on-modify and on-complete receive the original task on the first
+// line and the modified task on the second. A hook may print a modified task
+// as JSON on stdout, which replaces the task for the next hook and for
+// storage (on-delete output is ignored). A non-zero exit vetoes the change,
+// with the hook's stderr as the reason.
+//
+// Hooks for changes made inside a transaction, such as bulk actions, run
+// while the transaction holds the SQLite write lock, and other writers give
+// up after its 5 second busy timeout. Each hook is therefore limited to
+// DefaultTimeout; a hook with slow work should start it in the background
+// and exit.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hitsumabushi845/task-management/internal/api"
+	"github.com/hitsumabushi845/task-management/internal/domain"
+)
+
+// Event names a point in the task lifecycle
+type Event string
+
+const (
+	OnAdd      Event = "on-add"
+	OnModify   Event = "on-modify"
+	OnComplete Event = "on-complete" // Runs after on-modify when an update completes a task
+	OnDelete   Event = "on-delete"
+)
+
+// DefaultTimeout bounds each hook when Runner.Timeout is not set. It is
+// kept well below the SQLite busy timeout so a hook run inside a write
+// transaction cannot make other writers fail.
+const DefaultTimeout = 2 * time.Second
+
+// VetoError is returned when a hook rejects a change
+type VetoError struct {
+	Hook   string // File name of the hook
+	Reason string
+}
+
+func (e *VetoError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("rejected by hook %s", e.Hook)
+	}
+	return fmt.Sprintf("rejected by hook %s: %s", e.Hook, e.Reason)
+}
+
+// Runner finds and runs hook scripts in a directory
+type Runner struct {
+	Dir     string
+	Timeout time.Duration // DefaultTimeout if zero
+}
+
+// Scripts returns the executable hooks for event in the order they run
+func (r *Runner) Scripts(event Event) ([]string, error) {
+	entries, err := os.ReadDir(r.Dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var scripts []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), string(event)) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		if info.Mode()&0111 == 0 {
+			continue // Not executable, e.g. a disabled hook or a README
+		}
+		scripts = append(scripts, filepath.Join(r.Dir, entry.Name()))
+	}
+	sort.Strings(scripts)
+	return scripts, nil
+}
+
+// Run runs the hooks for event on task, applying any modifications the
+// hooks print. original is the stored task for on-modify and on-complete
+// and nil otherwise.
+func (r *Runner) Run(ctx context.Context, event Event, original, task *domain.Task) error {
+	scripts, err := r.Scripts(event)
+	if err != nil {
+		return err
+	}
+
+	for _, script := range scripts {
+		var stdin bytes.Buffer
+		enc := json.NewEncoder(&stdin)
+		if original != nil {
+			if err := enc.Encode(api.FromTask(original)); err != nil {
+				return err
+			}
+		}
+		if err := enc.Encode(api.FromTask(task)); err != nil {
+			return err
+		}
+
+		out, err := r.exec(ctx, script, event, &stdin)
+		if err != nil {
+			return err
+		}
+		if event == OnDelete || len(bytes.TrimSpace(out)) == 0 {
+			continue
+		}
+		if err := applyOutput(task, out); err != nil {
+			return fmt.Errorf("hook %s: %w", filepath.Base(script), err)
+		}
+	}
+	return nil
+}
+
+// exec runs one hook and returns its stdout
+func (r *Runner) exec(ctx context.Context, script string, event Event, stdin *bytes.Buffer) ([]byte, error) {
+	timeout := r.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, script)
+	cmd.Stdin = stdin
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.Env = append(os.Environ(), "TASK_HOOK_EVENT="+string(event))
+
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return stdout.Bytes(), nil
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return nil, fmt.Errorf("hook %s timed out after %v", filepath.Base(script), timeout)
+	case ctx.Err() != nil:
+		return nil, fmt.Errorf("hook %s: %w", filepath.Base(script), ctx.Err())
+	case errors.As(err, &exitErr):
+		return nil, &VetoError{Hook: filepath.Base(script), Reason: strings.TrimSpace(stderr.String())}
+	default:
+		return nil, fmt.Errorf("hook %s: %w", filepath.Base(script), err)
+	}
+}
+
+// applyOutput copies the editable fields of the task a hook printed onto
+// task. IDs and timestamps other than the due date and reminder are kept.
+func applyOutput(task *domain.Task, out []byte) error {
+	var modified api.Task
+	if err := json.Unmarshal(out, &modified); err != nil {
+		return fmt.Errorf("invalid task JSON on stdout: %w", err)
+	}
+
+	task.Title = modified.Title
+	task.Description = modified.Description
+	task.Priority = modified.Priority
+	task.CategoryID = modified.CategoryID
+	task.RemindAt = modified.RemindAt
+
+	switch {
+	case modified.DueDate == nil:
+		task.DueDate = nil
+	case task.DueDate != nil && task.DueDate.Format(api.DateLayout) == *modified.DueDate:
+		// Unchanged; keep the stored time of day and location
+	default:
+		due, err := time.ParseInLocation(api.DateLayout, *modified.DueDate, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid due_date %q", *modified.DueDate)
+		}
+		task.DueDate = &due
+	}
+
+	if modified.Status != task.Status {
+		return task.SetStatus(modified.Status, time.Now())
+	}
+	return nil
+}
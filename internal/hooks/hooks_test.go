@@ -0,0 +1,264 @@
+package hooks
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hitsumabushi845/task-management/internal/domain"
+	"github.com/hitsumabushi845/task-management/internal/repository"
+)
+
+// writeHook creates an executable shell script in dir
+func writeHook(t *testing.T, dir, name, body string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+body), 0755); err != nil {
+		t.Fatalf("failed to write hook: %v", err)
+	}
+}
+
+func newRepository(t *testing.T) (*Repository, string) {
+	t.Helper()
+	store, err := repository.NewSQLiteRepository(":memory:")
+	if err != nil {
+		t.Fatalf("NewSQLiteRepository() error = %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	dir := t.TempDir()
+	return NewRepository(store, &Runner{Dir: dir, Timeout: 5 * time.Second}), dir
+}
+
+func newTask(title string) *domain.Task {
+	return &domain.Task{Title: title, Status: domain.TaskStatusNew, Priority: domain.PriorityMedium}
+}
+
+func TestRepository_OnAddModifiesTask(t *testing.T) {
+	repo, dir := newRepository(t)
+	// Raise the priority of anything mentioning "urgent"
+	writeHook(t, dir, "on-add", `sed 's/"priority":"medium"/"priority":"high"/'`+"\n")
+
+	ctx := context.Background()
+	task := newTask("urgent: renew passport")
+	if err := repo.Create(ctx, task); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	stored, err := repo.GetByID(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if stored.Priority != domain.PriorityHigh {
+		t.Errorf("Priority = %v, want high from the hook", stored.Priority)
+	}
+}
+
+func TestRepository_OnAddVeto(t *testing.T) {
+	repo, dir := newRepository(t)
+	writeHook(t, dir, "on-add", "echo 'vague titles are not allowed' >&2\nexit 1\n")
+
+	ctx := context.Background()
+	err := repo.Create(ctx, newTask("stuff"))
+
+	var veto *VetoError
+	if !errors.As(err, &veto) {
+		t.Fatalf("Create() error = %v, want VetoError", err)
+	}
+	if veto.Hook != "on-add" || veto.Reason != "vague titles are not allowed" {
+		t.Errorf("VetoError = %+v", veto)
+	}
+
+	tasks, _ := repo.List(ctx)
+	if len(tasks) != 0 {
+		t.Errorf("vetoed task was stored")
+	}
+}
+
+func TestRepository_OnModifyAndOnComplete(t *testing.T) {
+	repo, dir := newRepository(t)
+	log := filepath.Join(dir, "log")
+	// Each hook logs its name and the titles it received, then passes the
+	// modified task through unchanged
+	for _, name := range []string{"on-modify", "on-complete"} {
+		writeHook(t, dir, name, `input=$(cat)
+echo "$TASK_HOOK_EVENT $(echo "$input" | sed -n 's/.*"title":"\([^"]*\)".*/\1/p' | tr '\n' ' ')" >> `+log+`
+echo "$input" | tail -n 1
+`)
+	}
+
+	ctx := context.Background()
+	task := newTask("Draft")
+	if err := repo.Create(ctx, task); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	task.Title = "Final"
+	if err := repo.Update(ctx, task); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	task.SetStatus(domain.TaskStatusCompleted, time.Now())
+	if err := repo.Update(ctx, task); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	data, err := os.ReadFile(log)
+	if err != nil {
+		t.Fatalf("hooks did not run: %v", err)
+	}
+	want := "on-modify Draft Final \non-modify Final Final \non-complete Final Final \n"
+	if string(data) != want {
+		t.Errorf("hook log = %q, want %q", string(data), want)
+	}
+
+	stored, _ := repo.GetByID(ctx, task.ID)
+	if stored.Title != "Final" || stored.Status != domain.TaskStatusCompleted {
+		t.Errorf("stored = %q %v, want Final completed", stored.Title, stored.Status)
+	}
+}
+
+func TestRepository_OnCompleteVetoKeepsStoredTask(t *testing.T) {
+	repo, dir := newRepository(t)
+	writeHook(t, dir, "on-complete", "echo 'add a description first' >&2\nexit 1\n")
+
+	ctx := context.Background()
+	task := newTask("Review")
+	if err := repo.Create(ctx, task); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	task.SetStatus(domain.TaskStatusCompleted, time.Now())
+	if err := repo.Update(ctx, task); err == nil || !strings.Contains(err.Error(), "add a description first") {
+		t.Fatalf("Update() error = %v, want veto", err)
+	}
+
+	stored, _ := repo.GetByID(ctx, task.ID)
+	if stored.Status != domain.TaskStatusNew {
+		t.Errorf("Status = %v, want new after veto", stored.Status)
+	}
+}
+
+func TestRepository_OnDeleteVeto(t *testing.T) {
+	repo, dir := newRepository(t)
+	writeHook(t, dir, "on-delete", `grep -q '"priority":"high"' && exit 1
+exit 0
+`)
+
+	ctx := context.Background()
+	keep := newTask("Important")
+	keep.Priority = domain.PriorityHigh
+	drop := newTask("Trivial")
+	for _, task := range []*domain.Task{keep, drop} {
+		if err := repo.Create(ctx, task); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	if err := repo.Delete(ctx, keep.ID); err == nil {
+		t.Errorf("Delete() of high priority task expected veto")
+	}
+	if err := repo.Delete(ctx, drop.ID); err != nil {
+		t.Errorf("Delete() error = %v", err)
+	}
+
+	tasks, _ := repo.List(ctx)
+	if len(tasks) != 1 || tasks[0].ID != keep.ID {
+		t.Errorf("remaining tasks = %v, want only %d", tasks, keep.ID)
+	}
+}
+
+func TestRepository_VetoRollsBackTransaction(t *testing.T) {
+	repo, dir := newRepository(t)
+	writeHook(t, dir, "on-delete", `grep -q '"priority":"high"' && exit 1
+exit 0
+`)
+
+	ctx := context.Background()
+	keep := newTask("Important")
+	keep.Priority = domain.PriorityHigh
+	drop := newTask("Trivial")
+	for _, task := range []*domain.Task{keep, drop} {
+		if err := repo.Create(ctx, task); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	err := repo.WithTx(ctx, func(tx domain.TaskRepository) error {
+		for _, id := range []int64{drop.ID, keep.ID} {
+			if err := tx.Delete(ctx, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	var veto *VetoError
+	if !errors.As(err, &veto) {
+		t.Fatalf("WithTx() error = %v, want VetoError", err)
+	}
+
+	tasks, _ := repo.List(ctx)
+	if len(tasks) != 2 {
+		t.Errorf("%d tasks remain, want both: the veto should undo the first delete", len(tasks))
+	}
+}
+
+func TestRunner_Scripts(t *testing.T) {
+	dir := t.TempDir()
+	writeHook(t, dir, "on-add-20-second", "")
+	writeHook(t, dir, "on-add-10-first", "")
+	writeHook(t, dir, "on-modify", "")
+	if err := os.WriteFile(filepath.Join(dir, "on-add-disabled"), []byte("#!/bin/sh\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	r := &Runner{Dir: dir}
+	scripts, err := r.Scripts(OnAdd)
+	if err != nil {
+		t.Fatalf("Scripts() error = %v", err)
+	}
+	var names []string
+	for _, s := range scripts {
+		names = append(names, filepath.Base(s))
+	}
+	if strings.Join(names, ",") != "on-add-10-first,on-add-20-second" {
+		t.Errorf("Scripts(on-add) = %v, want the executable on-add hooks in order", names)
+	}
+
+	missing := &Runner{Dir: filepath.Join(dir, "missing")}
+	if scripts, err := missing.Scripts(OnAdd); err != nil || len(scripts) != 0 {
+		t.Errorf("Scripts() on missing dir = %v, %v; want none", scripts, err)
+	}
+}
+
+func TestRunner_InvalidOutput(t *testing.T) {
+	dir := t.TempDir()
+	writeHook(t, dir, "on-add", "echo not json\n")
+
+	r := &Runner{Dir: dir}
+	err := r.Run(context.Background(), OnAdd, nil, newTask("x"))
+	if err == nil || !strings.Contains(err.Error(), "invalid task JSON") {
+		t.Errorf("Run() error = %v, want invalid JSON error", err)
+	}
+}
+
+func TestRunner_TimeoutAndCancel(t *testing.T) {
+	dir := t.TempDir()
+	writeHook(t, dir, "on-add", "exec sleep 5\n")
+	runner := &Runner{Dir: dir, Timeout: 50 * time.Millisecond}
+
+	err := runner.Run(context.Background(), OnAdd, nil, newTask("Slow"))
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("Run() past the timeout error = %v, want a timeout", err)
+	}
+
+	runner.Timeout = 5 * time.Second
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	err = runner.Run(ctx, OnAdd, nil, newTask("Slow"))
+	if !errors.Is(err, context.Canceled) || strings.Contains(err.Error(), "timed out") {
+		t.Errorf("Run() after cancel error = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,83 @@
+package hooks
+
+import (
+	"context"
+
+	"github.com/hitsumabushi845/task-management/internal/domain"
+	"github.com/hitsumabushi845/task-management/internal/repository"
+)
+
+// Repository wraps a domain.TaskRepository so every change made through it
+// runs the matching hooks first. A veto leaves the stored task untouched.
+type Repository struct {
+	domain.TaskRepository
+	runner *Runner
+}
+
+// NewRepository wraps inner with the hooks in runner's directory
+func NewRepository(inner domain.TaskRepository, runner *Runner) *Repository {
+	return &Repository{TaskRepository: inner, runner: runner}
+}
+
+// Middleware returns NewRepository as a repository.Middleware
+func Middleware(runner *Runner) repository.Middleware {
+	return func(next domain.TaskRepository) domain.TaskRepository {
+		return NewRepository(next, runner)
+	}
+}
+
+// Create runs on-add, then creates the possibly modified task
+func (r *Repository) Create(ctx context.Context, task *domain.Task) error {
+	if err := r.runner.Run(ctx, OnAdd, nil, task); err != nil {
+		return err
+	}
+	return r.TaskRepository.Create(ctx, task)
+}
+
+// Restore runs on-add like Create, then stores the possibly modified task
+// with its timestamps
+func (r *Repository) Restore(ctx context.Context, task *domain.Task) error {
+	if err := r.runner.Run(ctx, OnAdd, nil, task); err != nil {
+		return err
+	}
+	return r.TaskRepository.Restore(ctx, task)
+}
+
+// Update runs on-modify, and on-complete if the update completes the task,
+// then stores the possibly modified task
+func (r *Repository) Update(ctx context.Context, task *domain.Task) error {
+	original, err := r.TaskRepository.GetByID(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := r.runner.Run(ctx, OnModify, original, task); err != nil {
+		return err
+	}
+	if task.Status == domain.TaskStatusCompleted && original.Status != domain.TaskStatusCompleted {
+		if err := r.runner.Run(ctx, OnComplete, original, task); err != nil {
+			return err
+		}
+	}
+	return r.TaskRepository.Update(ctx, task)
+}
+
+// Delete runs on-delete, then deletes the task
+func (r *Repository) Delete(ctx context.Context, id int64) error {
+	task, err := r.TaskRepository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := r.runner.Run(ctx, OnDelete, nil, task); err != nil {
+		return err
+	}
+	return r.TaskRepository.Delete(ctx, id)
+}
+
+// WithTx runs the hooks of every change made in fn. A veto rolls back the
+// whole transaction.
+func (r *Repository) WithTx(ctx context.Context, fn func(domain.TaskRepository) error) error {
+	return r.TaskRepository.WithTx(ctx, func(tx domain.TaskRepository) error {
+		return fn(NewRepository(tx, r.runner))
+	})
+}
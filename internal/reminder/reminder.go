@@ -0,0 +1,124 @@
+package reminder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/hitsumabushi845/task-management/internal/domain"
+)
+
+// Notifier delivers a reminder for a task
+type Notifier interface {
+	Notify(ctx context.Context, task *domain.Task) error
+}
+
+// NewNotifier returns a CommandNotifier for a non-empty command line, or a
+// WriterNotifier printing to w otherwise
+func NewNotifier(command string, w io.Writer) Notifier {
+	if args := strings.Fields(command); len(args) > 0 {
+		return &CommandNotifier{Args: args}
+	}
+	return &WriterNotifier{W: w}
+}
+
+// CommandNotifier runs an external command such as notify-send. The summary
+// and body are appended as the last two arguments, and TASK_ID and
+// TASK_TITLE are set in the environment for custom scripts.
+type CommandNotifier struct {
+	Args []string
+}
+
+// Notify runs the command for the task
+func (n *CommandNotifier) Notify(ctx context.Context, task *domain.Task) error {
+	args := append(append([]string{}, n.Args[1:]...), Summary(task), Body(task))
+	cmd := exec.CommandContext(ctx, n.Args[0], args...)
+	cmd.Env = append(os.Environ(),
+		fmt.Sprintf("TASK_ID=%d", task.ID),
+		"TASK_TITLE="+task.Title,
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s: %w: %s", n.Args[0], err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// WriterNotifier prints reminders as lines of text
+type WriterNotifier struct {
+	W io.Writer
+}
+
+// Notify writes the reminder to the writer
+func (n *WriterNotifier) Notify(ctx context.Context, task *domain.Task) error {
+	_, err := fmt.Fprintf(n.W, "[%s] %s: %s\n", time.Now().Format("15:04"), Summary(task), Body(task))
+	return err
+}
+
+// Summary is the notification headline for a task
+func Summary(task *domain.Task) string {
+	return fmt.Sprintf("Task reminder #%d", task.ID)
+}
+
+// Body describes the task in a notification
+func Body(task *domain.Task) string {
+	body := task.Title
+	if task.DueDate != nil {
+		body += " (due " + task.DueDate.Format("Mon 2006-01-02") + ")"
+	}
+	return body
+}
+
+// Check delivers every reminder due at now and clears it so it fires only
+// once. A reminder is cleared with ClearReminder before it is delivered,
+// and skipped if the task changed since it was listed, usually because
+// another checker such as a TUI has already delivered it. It returns the
+// tasks that were notified.
+func Check(ctx context.Context, repo domain.TaskRepository, n Notifier, now time.Time) ([]*domain.Task, error) {
+	tasks, err := repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var fired []*domain.Task
+	for _, task := range tasks {
+		if !task.ReminderDue(now) {
+			continue
+		}
+		err := repo.ClearReminder(ctx, task)
+		if errors.Is(err, domain.ErrConflict) {
+			continue
+		}
+		if err != nil {
+			return fired, err
+		}
+		if err := n.Notify(ctx, task); err != nil {
+			return fired, err
+		}
+		fired = append(fired, task)
+	}
+	return fired, nil
+}
+
+// Watch runs Check every interval until ctx is cancelled. Errors are
+// reported to errs and do not stop the loop.
+func Watch(ctx context.Context, repo domain.TaskRepository, n Notifier, interval time.Duration, errs io.Writer) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := Check(ctx, repo, n, time.Now()); err != nil && ctx.Err() == nil {
+			fmt.Fprintf(errs, "reminder check failed: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
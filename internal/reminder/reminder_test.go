@@ -0,0 +1,154 @@
+package reminder
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hitsumabushi845/task-management/internal/domain"
+	"github.com/hitsumabushi845/task-management/internal/repository"
+)
+
+func newTestRepo(t *testing.T) *repository.SQLiteRepository {
+	t.Helper()
+	repo, err := repository.NewSQLiteRepository(":memory:")
+	if err != nil {
+		t.Fatalf("NewSQLiteRepository() error = %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	return repo
+}
+
+func TestCheck(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Hour)
+
+	due := &domain.Task{Title: "Due reminder", Status: domain.TaskStatusNew, Priority: domain.PriorityMedium, RemindAt: &past}
+	later := &domain.Task{Title: "Later reminder", Status: domain.TaskStatusNew, Priority: domain.PriorityMedium, RemindAt: &future}
+	done := &domain.Task{Title: "Done task", Status: domain.TaskStatusCompleted, Priority: domain.PriorityMedium, RemindAt: &past}
+	archived := &domain.Task{Title: "Archived task", Status: domain.TaskStatusNew, Priority: domain.PriorityMedium, RemindAt: &past, ArchivedAt: &past}
+	for _, task := range []*domain.Task{due, later, done, archived} {
+		if err := repo.Create(ctx, task); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	var out bytes.Buffer
+	fired, err := Check(ctx, repo, NewNotifier("", &out), now)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	if len(fired) != 1 || fired[0].ID != due.ID {
+		t.Fatalf("Check() fired %v, want only task %d", fired, due.ID)
+	}
+	if !strings.Contains(out.String(), "Due reminder") {
+		t.Errorf("output %q does not mention the task", out.String())
+	}
+
+	// The fired reminder is cleared, the others are untouched
+	got, err := repo.GetByID(ctx, due.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.RemindAt != nil {
+		t.Errorf("RemindAt = %v after firing, want nil", got.RemindAt)
+	}
+	if got.Version != due.Version+1 {
+		t.Errorf("Version = %d after firing, want %d", got.Version, due.Version+1)
+	}
+	got, err = repo.GetByID(ctx, later.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.RemindAt == nil {
+		t.Errorf("future reminder was cleared")
+	}
+
+	// A second check fires nothing
+	fired, err = Check(ctx, repo, NewNotifier("", &out), now)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if len(fired) != 0 {
+		t.Errorf("second Check() fired %d reminders, want 0", len(fired))
+	}
+}
+
+// racingRepository clears every reminder through another checker just
+// before it is cleared, as a TUI checking at the same moment would
+type racingRepository struct {
+	domain.TaskRepository
+}
+
+func (r racingRepository) ClearReminder(ctx context.Context, task *domain.Task) error {
+	other, err := r.TaskRepository.GetByID(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+	if err := r.TaskRepository.ClearReminder(ctx, other); err != nil {
+		return err
+	}
+	return r.TaskRepository.ClearReminder(ctx, task)
+}
+
+func TestCheck_AlreadyFiredElsewhere(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	past := now.Add(-time.Minute)
+
+	task := &domain.Task{Title: "Due reminder", Status: domain.TaskStatusNew, Priority: domain.PriorityMedium, RemindAt: &past}
+	if err := repo.Create(ctx, task); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// The other checker wins, so this one neither notifies nor fails
+	var out bytes.Buffer
+	fired, err := Check(ctx, racingRepository{repo}, NewNotifier("", &out), now)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if len(fired) != 0 || out.Len() != 0 {
+		t.Errorf("Check() fired %v and wrote %q, want nothing", fired, out.String())
+	}
+}
+
+func TestCommandNotifier(t *testing.T) {
+	dir := t.TempDir()
+	outPath := filepath.Join(dir, "out.txt")
+	script := filepath.Join(dir, "notify.sh")
+	content := "#!/bin/sh\necho \"$TASK_ID|$1|$2|$3\" > " + outPath + "\n"
+	if err := os.WriteFile(script, []byte(content), 0755); err != nil {
+		t.Fatalf("failed to write script: %v", err)
+	}
+
+	n := NewNotifier(script+" --urgent", nil)
+	task := &domain.Task{ID: 7, Title: "Pay invoice"}
+	if err := n.Notify(context.Background(), task); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("script did not run: %v", err)
+	}
+	want := "7|--urgent|Task reminder #7|Pay invoice\n"
+	if string(data) != want {
+		t.Errorf("script received %q, want %q", string(data), want)
+	}
+}
+
+func TestCommandNotifier_Failure(t *testing.T) {
+	n := NewNotifier("false", nil)
+	if err := n.Notify(context.Background(), &domain.Task{ID: 1, Title: "x"}); err == nil {
+		t.Errorf("Notify() expected error from failing command")
+	}
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/hitsumabushi845/task-management/internal/domain"
+)
+
+func TestSQLiteRepository_JournalMode(t *testing.T) {
+	repo, err := NewSQLiteRepository(filepath.Join(t.TempDir(), "tasks.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteRepository() error = %v", err)
+	}
+	defer repo.Close()
+
+	var mode string
+	if err := repo.db.QueryRow("PRAGMA journal_mode").Scan(&mode); err != nil {
+		t.Fatalf("PRAGMA journal_mode error = %v", err)
+	}
+	if mode != "wal" {
+		t.Errorf("journal_mode = %q, want wal", mode)
+	}
+}
+
+// TestSQLiteRepository_ConcurrentWriters opens the same file several times,
+// as the TUI, CLI and server do, and writes through every handle at once
+func TestSQLiteRepository_ConcurrentWriters(t *testing.T) {
+	const (
+		handles    = 4
+		writers    = 3 // Per handle
+		increments = 10
+	)
+	path := filepath.Join(t.TempDir(), "tasks.db")
+	ctx := context.Background()
+
+	// Open one at a time so that only the first runs the migrations
+	repos := make([]*SQLiteRepository, handles)
+	for i := range repos {
+		repo, err := NewSQLiteRepository(path)
+		if err != nil {
+			t.Fatalf("NewSQLiteRepository() error = %v", err)
+		}
+		defer repo.Close()
+		repos[i] = repo
+	}
+
+	counter := &domain.Task{Title: "Counter", Description: "0", Status: domain.TaskStatusNew, Priority: domain.PriorityLow}
+	if err := repos[0].Create(ctx, counter); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, handles*writers)
+	for h, repo := range repos {
+		for w := 0; w < writers; w++ {
+			wg.Add(1)
+			go func(repo *SQLiteRepository, name string) {
+				defer wg.Done()
+				for i := 0; i < increments; i++ {
+					task := &domain.Task{Title: fmt.Sprintf("%s #%d", name, i), Status: domain.TaskStatusNew, Priority: domain.PriorityLow}
+					if err := repo.Create(ctx, task); err != nil {
+						errs <- fmt.Errorf("%s: Create() error = %w", name, err)
+						return
+					}
+					if err := increment(ctx, repo, counter.ID); err != nil {
+						errs <- fmt.Errorf("%s: %w", name, err)
+						return
+					}
+				}
+			}(repo, fmt.Sprintf("handle %d writer %d", h, w))
+		}
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	tasks, err := repos[handles-1].List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if want := handles*writers*increments + 1; len(tasks) != want {
+		t.Errorf("List() returned %d tasks, want %d", len(tasks), want)
+	}
+	got, err := repos[handles-1].GetByID(ctx, counter.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if want := strconv.Itoa(handles * writers * increments); got.Description != want {
+		t.Errorf("counter = %s, want %s: an increment was lost", got.Description, want)
+	}
+}
+
+// increment adds one to the number in a task's description, reloading and
+// retrying when another writer got there first
+func increment(ctx context.Context, repo domain.TaskRepository, id int64) error {
+	for {
+		task, err := repo.GetByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("GetByID() error = %w", err)
+		}
+		n, err := strconv.Atoi(task.Description)
+		if err != nil {
+			return err
+		}
+		task.Description = strconv.Itoa(n + 1)
+		err = repo.Update(ctx, task)
+		if errors.Is(err, domain.ErrConflict) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("Update() error = %w", err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,763 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/hitsumabushi845/task-management/internal/domain"
+)
+
+// The conformance suite checks that every TaskRepository implementation
+// behaves the same way
+
+func TestSQLiteRepository_Conformance(t *testing.T) {
+	testConformance(t, func(t *testing.T) domain.TaskRepository {
+		return newInMemorySQLite(t)
+	})
+}
+
+func TestMemoryRepository_Conformance(t *testing.T) {
+	testConformance(t, func(t *testing.T) domain.TaskRepository {
+		return NewMemoryRepository()
+	})
+}
+
+func testConformance(t *testing.T, newRepo func(t *testing.T) domain.TaskRepository) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repo domain.TaskRepository)
+	}{
+		{"CreateAssignsIDAndCreatedAt", conformCreate},
+		{"CreateRejectsInvalidTask", conformCreateInvalid},
+		{"GetByIDRoundTrip", conformRoundTrip},
+		{"MissingTask", conformMissing},
+		{"ReturnsCopies", conformCopies},
+		{"Update", conformUpdate},
+		{"UpdateConflict", conformUpdateConflict},
+		{"RestoreKeepsTimestamps", conformRestore},
+		{"ClearReminder", conformClearReminder},
+		{"Delete", conformDelete},
+		{"ListNewestFirst", conformListOrder},
+		{"Categories", conformCategories},
+		{"CategoryReferences", conformCategoryReferences},
+		{"Transactions", conformTransactions},
+		{"Checklist", conformChecklist},
+		{"Notes", conformNotes},
+		{"Attachments", conformAttachments},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newRepo(t)
+			t.Cleanup(func() { repo.Close() })
+			tt.run(t, repo)
+		})
+	}
+}
+
+func newConformTask(title string) *domain.Task {
+	return &domain.Task{Title: title, Status: domain.TaskStatusNew, Priority: domain.PriorityMedium}
+}
+
+func mustCreate(t *testing.T, repo domain.TaskRepository, task *domain.Task) {
+	t.Helper()
+	if err := repo.Create(context.Background(), task); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+}
+
+func conformCreate(t *testing.T, repo domain.TaskRepository) {
+	before := time.Now().Add(-time.Second)
+	first, second := newConformTask("First"), newConformTask("Second")
+	mustCreate(t, repo, first)
+	mustCreate(t, repo, second)
+
+	if first.ID <= 0 || second.ID <= first.ID {
+		t.Errorf("IDs = %d, %d; want positive and increasing", first.ID, second.ID)
+	}
+	if first.CreatedAt.Before(before) || first.CreatedAt.After(time.Now()) {
+		t.Errorf("CreatedAt = %v, want about now", first.CreatedAt)
+	}
+
+	// IDs of deleted tasks are not reused
+	if err := repo.Delete(context.Background(), second.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	third := newConformTask("Third")
+	mustCreate(t, repo, third)
+	if third.ID <= second.ID {
+		t.Errorf("ID after delete = %d, want greater than %d", third.ID, second.ID)
+	}
+}
+
+func conformCreateInvalid(t *testing.T, repo domain.TaskRepository) {
+	ctx := context.Background()
+	invalid := []struct {
+		task  *domain.Task
+		field string
+	}{
+		{&domain.Task{Title: "", Status: domain.TaskStatusNew, Priority: domain.PriorityLow}, "title"},
+		{&domain.Task{Title: "Bad status", Status: "done", Priority: domain.PriorityLow}, "status"},
+		{&domain.Task{Title: "Bad priority", Status: domain.TaskStatusNew, Priority: "urgent"}, "priority"},
+	}
+	for _, tt := range invalid {
+		task := tt.task
+		var verr *domain.ValidationError
+		if err := repo.Create(ctx, task); !errors.As(err, &verr) || verr.Field != tt.field {
+			t.Errorf("Create(%q) error = %v, want a validation error for %s", task.Title, err, tt.field)
+		}
+		if task.ID != 0 {
+			t.Errorf("Create(%q) assigned ID %d to an invalid task", task.Title, task.ID)
+		}
+	}
+
+	tasks, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(tasks) != 0 {
+		t.Errorf("List() returned %d tasks, want 0", len(tasks))
+	}
+}
+
+func conformRoundTrip(t *testing.T, repo domain.TaskRepository) {
+	ctx := context.Background()
+	categories, err := repo.GetCategories(ctx)
+	if err != nil || len(categories) == 0 {
+		t.Fatalf("GetCategories() = %d categories, %v", len(categories), err)
+	}
+
+	due := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)
+	started := time.Date(2026, 2, 20, 9, 30, 15, 0, time.Local)
+	completed := started.Add(26 * time.Hour)
+	remind := time.Date(2026, 2, 28, 18, 0, 0, 0, time.Local)
+	archived := completed.Add(72 * time.Hour)
+	task := &domain.Task{
+		Title:       "Round trip",
+		Description: "Every field set",
+		Status:      domain.TaskStatusCompleted,
+		Priority:    domain.PriorityHigh,
+		CategoryID:  &categories[0].ID,
+		DueDate:     &due,
+		StartedAt:   &started,
+		CompletedAt: &completed,
+		RemindAt:    &remind,
+		ArchivedAt:  &archived,
+	}
+	mustCreate(t, repo, task)
+
+	got, err := repo.GetByID(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.ID != task.ID || got.Title != task.Title || got.Description != task.Description ||
+		got.Status != task.Status || got.Priority != task.Priority {
+		t.Errorf("GetByID() = %+v, want %+v", got, task)
+	}
+	if got.CategoryID == nil || *got.CategoryID != categories[0].ID {
+		t.Errorf("CategoryID = %v, want %d", got.CategoryID, categories[0].ID)
+	}
+	if !got.CreatedAt.Equal(task.CreatedAt.Truncate(time.Second)) {
+		t.Errorf("CreatedAt = %v, want %v to the second", got.CreatedAt, task.CreatedAt)
+	}
+	for name, pair := range map[string][2]*time.Time{
+		"DueDate":     {got.DueDate, &due},
+		"StartedAt":   {got.StartedAt, &started},
+		"CompletedAt": {got.CompletedAt, &completed},
+		"RemindAt":    {got.RemindAt, &remind},
+		"ArchivedAt":  {got.ArchivedAt, &archived},
+	} {
+		if pair[0] == nil || !pair[0].Equal(*pair[1]) {
+			t.Errorf("%s = %v, want %v", name, pair[0], *pair[1])
+		}
+	}
+}
+
+func conformMissing(t *testing.T, repo domain.TaskRepository) {
+	ctx := context.Background()
+	if _, err := repo.GetByID(ctx, 999); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("GetByID(999) error = %v, want ErrNotFound", err)
+	}
+	task := newConformTask("Never stored")
+	task.ID = 999
+	if err := repo.Update(ctx, task); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Update(999) error = %v, want ErrNotFound", err)
+	}
+	if err := repo.Delete(ctx, 999); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Delete(999) error = %v, want ErrNotFound", err)
+	}
+}
+
+func conformCopies(t *testing.T, repo domain.TaskRepository) {
+	ctx := context.Background()
+	task := newConformTask("Original")
+	mustCreate(t, repo, task)
+
+	task.Title = "Changed after Create"
+	got, err := repo.GetByID(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	got.Title = "Changed after GetByID"
+
+	tasks, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if tasks[0].Title != "Original" {
+		t.Errorf("stored title = %q, want %q", tasks[0].Title, "Original")
+	}
+}
+
+func conformUpdate(t *testing.T, repo domain.TaskRepository) {
+	ctx := context.Background()
+	task := newConformTask("Before")
+	mustCreate(t, repo, task)
+	created := task.CreatedAt
+
+	now := time.Now()
+	task.Title = "After"
+	task.CreatedAt = now.Add(-48 * time.Hour) // Ignored: CreatedAt is set once
+	if err := task.SetStatus(domain.TaskStatusWorking, now); err != nil {
+		t.Fatalf("SetStatus() error = %v", err)
+	}
+	if err := repo.Update(ctx, task); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Title != "After" || got.Status != domain.TaskStatusWorking || got.StartedAt == nil {
+		t.Errorf("GetByID() = %+v, want the updated task", got)
+	}
+	if !got.CreatedAt.Equal(created.Truncate(time.Second)) {
+		t.Errorf("CreatedAt = %v, want %v", got.CreatedAt, created)
+	}
+
+	task.Title = ""
+	if err := repo.Update(ctx, task); err == nil {
+		t.Errorf("Update() with an empty title succeeded")
+	}
+	if got, _ := repo.GetByID(ctx, task.ID); got.Title != "After" {
+		t.Errorf("title after rejected update = %q, want %q", got.Title, "After")
+	}
+}
+
+func conformUpdateConflict(t *testing.T, repo domain.TaskRepository) {
+	ctx := context.Background()
+	task := newConformTask("Shared")
+	mustCreate(t, repo, task)
+	if task.Version != 1 {
+		t.Errorf("Version after Create() = %d, want 1", task.Version)
+	}
+
+	// Two editors load the same version
+	mine, err := repo.GetByID(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	theirs, err := repo.GetByID(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+
+	theirs.Title = "Theirs"
+	if err := repo.Update(ctx, theirs); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if theirs.Version != 2 {
+		t.Errorf("Version after Update() = %d, want 2", theirs.Version)
+	}
+
+	mine.Title = "Mine"
+	if err := repo.Update(ctx, mine); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("Update() of a stale version error = %v, want ErrConflict", err)
+	}
+	if mine.Version != 1 {
+		t.Errorf("Version after a conflict = %d, want it unchanged", mine.Version)
+	}
+	got, err := repo.GetByID(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Title != "Theirs" || got.Version != 2 {
+		t.Errorf("GetByID() = %q version %d, want %q version 2", got.Title, got.Version, "Theirs")
+	}
+
+	// Reloading picks up the new version and the update goes through
+	mine = got
+	mine.Title = "Mine"
+	if err := repo.Update(ctx, mine); err != nil {
+		t.Errorf("Update() after reload error = %v", err)
+	}
+
+	if err := repo.Delete(ctx, task.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := repo.Update(ctx, mine); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Update() of a deleted task error = %v, want ErrNotFound", err)
+	}
+}
+
+func conformRestore(t *testing.T, repo domain.TaskRepository) {
+	ctx := context.Background()
+	created := time.Date(2026, 9, 1, 9, 0, 0, 0, time.UTC)
+	completed := created.Add(48 * time.Hour)
+	task := newConformTask("Restored")
+	task.Status = domain.TaskStatusCompleted
+	task.CreatedAt = created
+	task.CompletedAt = &completed
+	task.ArchivedAt = &completed
+	task.Checklist = []domain.ChecklistItem{{Text: "Step", Done: true}}
+	task.Notes = []domain.Note{{ID: 3, TaskID: 42, Text: "Waiting on vendor", CreatedAt: completed}}
+	task.ID = 42
+	task.Version = 7
+
+	if err := repo.Restore(ctx, task); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if task.ID <= 0 || task.Version != 1 {
+		t.Errorf("ID %d version %d after Restore(), want a new ID and version 1", task.ID, task.Version)
+	}
+	got, err := repo.GetByID(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if !got.CreatedAt.Equal(created) {
+		t.Errorf("CreatedAt = %v, want %v", got.CreatedAt, created)
+	}
+	if got.CompletedAt == nil || !got.CompletedAt.Equal(completed) || got.ArchivedAt == nil {
+		t.Errorf("CompletedAt %v ArchivedAt %v, want both kept", got.CompletedAt, got.ArchivedAt)
+	}
+	if len(got.Checklist) != 1 || !got.Checklist[0].Done {
+		t.Errorf("Checklist = %+v, want the item kept", got.Checklist)
+	}
+	if len(got.Notes) != 1 || got.Notes[0].TaskID != task.ID || !got.Notes[0].CreatedAt.Equal(completed) {
+		t.Errorf("Notes = %+v, want the note kept with its time", got.Notes)
+	}
+	if len(task.Notes) != 1 || task.Notes[0].ID != got.Notes[0].ID {
+		t.Errorf("restored notes = %+v, want the stored IDs", task.Notes)
+	}
+
+	// A task without a creation time is stamped like Create
+	bare := newConformTask("Bare")
+	if err := repo.Restore(ctx, bare); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if bare.CreatedAt.IsZero() {
+		t.Errorf("CreatedAt of a task restored without one is zero")
+	}
+
+	if err := repo.Restore(ctx, &domain.Task{Status: domain.TaskStatusNew, Priority: domain.PriorityLow}); err == nil {
+		t.Errorf("Restore() of an invalid task succeeded")
+	}
+}
+
+func conformClearReminder(t *testing.T, repo domain.TaskRepository) {
+	ctx := context.Background()
+	at := time.Now().Add(-time.Minute).Truncate(time.Second)
+	task := newConformTask("Reminded")
+	task.RemindAt = &at
+	mustCreate(t, repo, task)
+	stale, err := repo.GetByID(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+
+	if err := repo.ClearReminder(ctx, task); err != nil {
+		t.Fatalf("ClearReminder() error = %v", err)
+	}
+	if task.RemindAt != nil || task.Version != 2 {
+		t.Errorf("RemindAt %v version %d after ClearReminder(), want nil and 2", task.RemindAt, task.Version)
+	}
+	got, err := repo.GetByID(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.RemindAt != nil || got.Version != 2 {
+		t.Errorf("stored RemindAt %v version %d, want nil and 2", got.RemindAt, got.Version)
+	}
+
+	// A copy read before the reminder fired cannot store it again
+	stale.Title = "Edited"
+	if err := repo.Update(ctx, stale); !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("Update() of a copy read before ClearReminder() error = %v, want ErrConflict", err)
+	}
+	if got, err := repo.GetByID(ctx, task.ID); err != nil || got.RemindAt != nil {
+		t.Errorf("GetByID() = %v, %v; want the reminder still cleared", got, err)
+	}
+
+	// A second checker finds it already cleared
+	task.RemindAt = &at
+	if err := repo.ClearReminder(ctx, task); !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("ClearReminder() of a cleared reminder error = %v, want ErrConflict", err)
+	}
+
+	// A reminder changed since it was read is left alone
+	got.RemindAt = &at
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if err := repo.ClearReminder(ctx, task); !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("ClearReminder() of a stale version error = %v, want ErrConflict", err)
+	}
+	if got, err := repo.GetByID(ctx, task.ID); err != nil || got.RemindAt == nil {
+		t.Errorf("GetByID() = %v, %v; want the reminder kept", got, err)
+	}
+
+	missing := newConformTask("Missing")
+	missing.ID = 999
+	missing.Version = 1
+	if err := repo.ClearReminder(ctx, missing); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("ClearReminder() of a missing task error = %v, want ErrNotFound", err)
+	}
+}
+
+func conformDelete(t *testing.T, repo domain.TaskRepository) {
+	ctx := context.Background()
+	keep, remove := newConformTask("Keep"), newConformTask("Remove")
+	mustCreate(t, repo, keep)
+	mustCreate(t, repo, remove)
+
+	if err := repo.Delete(ctx, remove.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repo.GetByID(ctx, remove.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("GetByID() of deleted task error = %v, want ErrNotFound", err)
+	}
+	tasks, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].ID != keep.ID {
+		t.Errorf("List() after delete = %d tasks, want only %d", len(tasks), keep.ID)
+	}
+}
+
+func conformListOrder(t *testing.T, repo domain.TaskRepository) {
+	var ids []int64
+	for _, title := range []string{"A", "B", "C"} {
+		task := newConformTask(title)
+		mustCreate(t, repo, task)
+		ids = append(ids, task.ID)
+	}
+
+	tasks, err := repo.List(context.Background())
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(tasks) != 3 {
+		t.Fatalf("List() returned %d tasks, want 3", len(tasks))
+	}
+	// Created within the same second, so ID breaks the tie
+	for i, task := range tasks {
+		if want := ids[len(ids)-1-i]; task.ID != want {
+			t.Errorf("List()[%d].ID = %d, want %d", i, task.ID, want)
+		}
+	}
+}
+
+func conformCategories(t *testing.T, repo domain.TaskRepository) {
+	ctx := context.Background()
+	initial, err := repo.GetCategories(ctx)
+	if err != nil {
+		t.Fatalf("GetCategories() error = %v", err)
+	}
+	if len(initial) != len(defaultCategories) {
+		t.Errorf("new repository has %d categories, want %d defaults", len(initial), len(defaultCategories))
+	}
+
+	for _, name := range []string{"Zeta", "Alpha"} {
+		category := &domain.Category{Name: name, Color: "red"}
+		if err := repo.CreateCategory(ctx, category); err != nil {
+			t.Fatalf("CreateCategory(%q) error = %v", name, err)
+		}
+		if category.ID <= 0 || category.CreatedAt.IsZero() {
+			t.Errorf("CreateCategory(%q) = ID %d, CreatedAt %v", name, category.ID, category.CreatedAt)
+		}
+	}
+	if err := repo.CreateCategory(ctx, &domain.Category{Name: "Alpha", Color: "blue"}); !errors.Is(err, domain.ErrDuplicateCategory) {
+		t.Errorf("CreateCategory() with a duplicate name error = %v, want ErrDuplicateCategory", err)
+	}
+	if err := repo.CreateCategory(ctx, &domain.Category{Name: "Orange", Color: "orange"}); err == nil {
+		t.Errorf("CreateCategory() with an invalid color succeeded")
+	}
+
+	categories, err := repo.GetCategories(ctx)
+	if err != nil {
+		t.Fatalf("GetCategories() error = %v", err)
+	}
+	if len(categories) != len(initial)+2 {
+		t.Fatalf("GetCategories() returned %d, want %d", len(categories), len(initial)+2)
+	}
+	if !sort.SliceIsSorted(categories, func(i, j int) bool { return categories[i].Name < categories[j].Name }) {
+		t.Errorf("GetCategories() is not ordered by name")
+	}
+}
+
+func conformCategoryReferences(t *testing.T, repo domain.TaskRepository) {
+	ctx := context.Background()
+	category := &domain.Category{Name: "Garden", Color: "green"}
+	if err := repo.CreateCategory(ctx, category); err != nil {
+		t.Fatalf("CreateCategory() error = %v", err)
+	}
+
+	missing := int64(999)
+	var verr *domain.ValidationError
+	orphan := newConformTask("Orphan")
+	orphan.CategoryID = &missing
+	if err := repo.Create(ctx, orphan); !errors.As(err, &verr) || verr.Field != "category_id" {
+		t.Errorf("Create() with a missing category error = %v, want a category_id validation error", err)
+	}
+
+	task := newConformTask("Weed the beds")
+	task.CategoryID = &category.ID
+	mustCreate(t, repo, task)
+	task.CategoryID = &missing
+	if err := repo.Update(ctx, task); !errors.As(err, &verr) || verr.Field != "category_id" {
+		t.Errorf("Update() with a missing category error = %v, want a category_id validation error", err)
+	}
+
+	if err := repo.DeleteCategory(ctx, category.ID); err != nil {
+		t.Fatalf("DeleteCategory() error = %v", err)
+	}
+	got, err := repo.GetByID(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.CategoryID != nil {
+		t.Errorf("CategoryID after deleting the category = %d, want nil", *got.CategoryID)
+	}
+	if err := repo.DeleteCategory(ctx, category.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("DeleteCategory() of a deleted category error = %v, want ErrNotFound", err)
+	}
+}
+
+func conformTransactions(t *testing.T, repo domain.TaskRepository) {
+	ctx := context.Background()
+	kept := newConformTask("Kept")
+	mustCreate(t, repo, kept)
+
+	errAbort := errors.New("abort")
+	err := repo.WithTx(ctx, func(tx domain.TaskRepository) error {
+		if err := tx.Create(ctx, newConformTask("Discarded")); err != nil {
+			return err
+		}
+		if err := tx.Delete(ctx, kept.ID); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("WithTx() error = %v, want %v", err, errAbort)
+	}
+	if tasks, _ := repo.List(ctx); len(tasks) != 1 || tasks[0].ID != kept.ID {
+		t.Errorf("List() after rollback = %d tasks, want only %q", len(tasks), kept.Title)
+	}
+
+	var added *domain.Task
+	err = repo.WithTx(ctx, func(tx domain.TaskRepository) error {
+		added = newConformTask("Added")
+		if err := tx.Create(ctx, added); err != nil {
+			return err
+		}
+		// Reads inside the transaction see its changes
+		if _, err := tx.GetByID(ctx, added.ID); err != nil {
+			return err
+		}
+		return tx.Delete(ctx, kept.ID)
+	})
+	if err != nil {
+		t.Fatalf("WithTx() error = %v", err)
+	}
+	if _, err := repo.GetByID(ctx, kept.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("GetByID() of a task deleted in a committed transaction error = %v, want ErrNotFound", err)
+	}
+	if _, err := repo.GetByID(ctx, added.ID); err != nil {
+		t.Errorf("GetByID() of a task created in a committed transaction error = %v", err)
+	}
+}
+
+func conformChecklist(t *testing.T, repo domain.TaskRepository) {
+	ctx := context.Background()
+	task := newConformTask("With checklist")
+	task.Checklist = []domain.ChecklistItem{{Text: "Outline"}, {Text: "Draft", Done: true}, {Text: "Review"}}
+	mustCreate(t, repo, task)
+	mustCreate(t, repo, newConformTask("Without checklist"))
+
+	got, err := repo.GetByID(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if !reflect.DeepEqual(got.Checklist, task.Checklist) {
+		t.Errorf("Checklist = %v, want %v", got.Checklist, task.Checklist)
+	}
+
+	// Items are replaced as a whole, in their new order
+	got.Checklist = []domain.ChecklistItem{{Text: "Review", Done: true}, {Text: "Outline", Done: true}}
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	stale := *task
+	stale.Checklist = nil
+	if err := repo.Update(ctx, &stale); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("Update() with a stale version error = %v, want ErrConflict", err)
+	}
+
+	tasks, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	for _, listed := range tasks {
+		want := []domain.ChecklistItem(nil)
+		if listed.ID == task.ID {
+			want = got.Checklist
+		}
+		if !reflect.DeepEqual(listed.Checklist, want) {
+			t.Errorf("List() task %d checklist = %v, want %v", listed.ID, listed.Checklist, want)
+		}
+	}
+
+	// Returned items are copies
+	tasks[1].Checklist[0].Text = "Changed after List"
+	if stored, _ := repo.GetByID(ctx, task.ID); stored.Checklist[0].Text != "Review" {
+		t.Errorf("stored item = %q after changing a copy, want Review", stored.Checklist[0].Text)
+	}
+}
+
+func conformNotes(t *testing.T, repo domain.TaskRepository) {
+	ctx := context.Background()
+	task := newConformTask("With notes")
+	mustCreate(t, repo, task)
+	other := newConformTask("Without notes")
+	mustCreate(t, repo, other)
+
+	before := time.Now().Add(-time.Second)
+	first := &domain.Note{TaskID: task.ID, Text: "Asked the vendor"}
+	second := &domain.Note{TaskID: task.ID, Text: "Waiting on vendor reply"}
+	for _, note := range []*domain.Note{first, second} {
+		if err := repo.AddNote(ctx, note); err != nil {
+			t.Fatalf("AddNote() error = %v", err)
+		}
+	}
+	if first.ID <= 0 || second.ID <= first.ID {
+		t.Errorf("note IDs = %d, %d; want positive and increasing", first.ID, second.ID)
+	}
+	if first.CreatedAt.Before(before) || first.CreatedAt.After(time.Now()) {
+		t.Errorf("note CreatedAt = %v, want about now", first.CreatedAt)
+	}
+
+	// Notes are not task changes
+	got, err := repo.GetByID(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Version != task.Version {
+		t.Errorf("Version = %d after AddNote, want %d", got.Version, task.Version)
+	}
+
+	notes, err := repo.ListNotes(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("ListNotes() error = %v", err)
+	}
+	if len(notes) != 2 || notes[0].Text != first.Text || notes[1].Text != second.Text || notes[1].TaskID != task.ID {
+		t.Fatalf("ListNotes() = %v, want the two notes oldest first", notes)
+	}
+	if len(got.Notes) != 2 || got.Notes[0] != *notes[0] || got.Notes[1] != *notes[1] {
+		t.Errorf("GetByID() notes = %v, want %v and %v", got.Notes, *notes[0], *notes[1])
+	}
+	if notes, err := repo.ListNotes(ctx, other.ID); err != nil || len(notes) != 0 {
+		t.Errorf("ListNotes() of a task without notes = %v, %v; want none", notes, err)
+	}
+
+	// Updates keep the notes, whatever the task says
+	got.Notes = nil
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	tasks, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	for _, listed := range tasks {
+		want := 0
+		if listed.ID == task.ID {
+			want = 2
+		}
+		if len(listed.Notes) != want {
+			t.Errorf("List() task %d has %d notes, want %d", listed.ID, len(listed.Notes), want)
+		}
+	}
+
+	var verr *domain.ValidationError
+	if err := repo.AddNote(ctx, &domain.Note{TaskID: task.ID, Text: " "}); !errors.As(err, &verr) {
+		t.Errorf("AddNote() of an empty note error = %v, want a validation error", err)
+	}
+
+	// Notes go with their task
+	if err := repo.Delete(ctx, task.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := repo.ListNotes(ctx, task.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("ListNotes() of a deleted task error = %v, want ErrNotFound", err)
+	}
+	if err := repo.AddNote(ctx, &domain.Note{TaskID: task.ID, Text: "Too late"}); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("AddNote() to a deleted task error = %v, want ErrNotFound", err)
+	}
+}
+
+func conformAttachments(t *testing.T, repo domain.TaskRepository) {
+	ctx := context.Background()
+	added := time.Now()
+	task := newConformTask("With attachments")
+	task.Attachments = []domain.Attachment{
+		{Location: "/home/me/spec.pdf", AddedAt: added},
+		{Location: "https://example.com/issue/1", AddedAt: added},
+	}
+	mustCreate(t, repo, task)
+
+	got, err := repo.GetByID(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if len(got.Attachments) != 2 {
+		t.Fatalf("Attachments = %v, want 2", got.Attachments)
+	}
+	for i, a := range got.Attachments {
+		if a.Location != task.Attachments[i].Location || !a.AddedAt.Equal(storedTime(added)) {
+			t.Errorf("attachment %d = %+v, want %s added at %v", i, a, task.Attachments[i].Location, storedTime(added))
+		}
+	}
+
+	// Attachments are replaced as a whole
+	got.Attachments = got.Attachments[1:]
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	tasks, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(tasks) != 1 || len(tasks[0].Attachments) != 1 || tasks[0].Attachments[0].Location != "https://example.com/issue/1" {
+		t.Errorf("List() attachments = %v, want only the URL", tasks[0].Attachments)
+	}
+
+	// Returned attachments are copies
+	tasks[0].Attachments[0].Location = "/changed"
+	if stored, _ := repo.GetByID(ctx, task.ID); stored.Attachments[0].Location != "https://example.com/issue/1" {
+		t.Errorf("stored attachment = %q after changing a copy", stored.Attachments[0].Location)
+	}
+
+	var verr *domain.ValidationError
+	bad := newConformTask("Relative attachment")
+	bad.Attachments = []domain.Attachment{{Location: "spec.pdf", AddedAt: added}}
+	if err := repo.Create(ctx, bad); !errors.As(err, &verr) || verr.Field != "attachments" {
+		t.Errorf("Create() with a relative path error = %v, want a validation error for attachments", err)
+	}
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ScanError reports a stored value that cannot be decoded, such as a
+// timestamp that is not RFC 3339. "task doctor" finds and repairs them.
+type ScanError struct {
+	Table  string
+	ID     int64
+	Column string
+	Value  string
+	Err    error
+}
+
+func (e *ScanError) Error() string {
+	return fmt.Sprintf("%s row %d: column %s: invalid value %q: %v", e.Table, e.ID, e.Column, e.Value, e.Err)
+}
+
+func (e *ScanError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorHint suggests how to recover from err, or returns empty if there is
+// no known way. Errors caused by corrupt rows point to "task doctor".
+func ErrorHint(err error) string {
+	var scanErr *ScanError
+	if errors.As(err, &scanErr) {
+		return "Run \"task doctor\" to find and repair corrupt rows.\n"
+	}
+	return ""
+}
+
+// rowDecoder decodes the text columns of one row, keeping the first error
+// so that callers can decode every column and check once
+type rowDecoder struct {
+	table string
+	id    int64
+	err   error
+}
+
+// time parses a NOT NULL timestamp column
+func (d *rowDecoder) time(column, value string) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil && d.err == nil {
+		d.err = &ScanError{Table: d.table, ID: d.id, Column: column, Value: value, Err: err}
+	}
+	return t
+}
+
+// timePtr parses a nullable timestamp column
+func (d *rowDecoder) timePtr(column string, value sql.NullString) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+	t := d.time(column, value.String)
+	return &t
+}
@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hitsumabushi845/task-management/internal/domain"
+)
+
+// Problem is an inconsistency in the database found by Diagnose
+type Problem struct {
+	Table  string
+	ID     int64
+	Column string
+	Value  string // The stored value, "" for NULL
+	Issue  string // What is wrong, e.g. "unparsable timestamp"
+	Repair string // What Repair does about it, e.g. "clear it"
+
+	query string
+	args  []interface{}
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s #%d %s: %s %q -> %s", p.Table, p.ID, p.Column, p.Issue, p.Value, p.Repair)
+}
+
+// timestampColumn is a timestamp checked by Diagnose
+type timestampColumn struct {
+	name  string
+	clear bool // Invalid values are cleared rather than set to now
+}
+
+// timestampColumns lists every timestamp column by table
+var timestampColumns = []struct {
+	table   string
+	columns []timestampColumn
+}{
+	{"tasks", []timestampColumn{
+		{"created_at", false},
+		{"due_date", true},
+		{"started_at", true},
+		{"completed_at", true},
+		{"remind_at", true},
+		{"archived_at", true},
+	}},
+	{"categories", []timestampColumn{{"created_at", false}}},
+	{"task_notes", []timestampColumn{{"created_at", false}}},
+	{"task_attachments", []timestampColumn{{"added_at", false}}},
+	{"webhook_deliveries", []timestampColumn{
+		{"created_at", false},
+		{"next_attempt_at", false},
+		// Retired deliveries stay retired
+		{"delivered_at", false},
+		{"failed_at", false},
+	}},
+}
+
+// Diagnose scans the database for values the repository cannot use:
+// unparsable timestamps, tasks whose category no longer exists, and
+// invalid statuses or priorities. It changes nothing; pass the result to
+// Repair to fix them.
+func (r *SQLiteRepository) Diagnose(ctx context.Context) ([]Problem, error) {
+	var problems []Problem
+	now := time.Now().Format(time.RFC3339)
+
+	for _, tc := range timestampColumns {
+		for _, col := range tc.columns {
+			found, err := r.badTimestamps(ctx, tc.table, col, now)
+			if err != nil {
+				return nil, err
+			}
+			problems = append(problems, found...)
+		}
+	}
+
+	orphans, err := r.orphanedCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	problems = append(problems, orphans...)
+
+	invalid, err := r.invalidEnums(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return append(problems, invalid...), nil
+}
+
+// Repair applies the repairs of problems in a single transaction
+func (r *SQLiteRepository) Repair(ctx context.Context, problems []Problem) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, p := range problems {
+		if _, err := tx.ExecContext(ctx, p.query, p.args...); err != nil {
+			return fmt.Errorf("repair %s #%d %s: %w", p.Table, p.ID, p.Column, err)
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *SQLiteRepository) badTimestamps(ctx context.Context, table string, col timestampColumn, now string) ([]Problem, error) {
+	rows, err := r.db.QueryContext(ctx,
+		fmt.Sprintf("SELECT id, %s FROM %s WHERE %s IS NOT NULL ORDER BY id", col.name, table, col.name))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var problems []Problem
+	for rows.Next() {
+		var id int64
+		var value string
+		if err := rows.Scan(&id, &value); err != nil {
+			return nil, err
+		}
+		if _, err := time.Parse(time.RFC3339, value); err == nil {
+			continue
+		}
+
+		p := Problem{Table: table, ID: id, Column: col.name, Value: value, Issue: "unparsable timestamp"}
+		if col.clear {
+			p.Repair = "clear it"
+			p.query = fmt.Sprintf("UPDATE %s SET %s = NULL WHERE id = ?", table, col.name)
+			p.args = []interface{}{id}
+		} else {
+			p.Repair = "set it to the current time"
+			p.query = fmt.Sprintf("UPDATE %s SET %s = ? WHERE id = ?", table, col.name)
+			p.args = []interface{}{now, id}
+		}
+		problems = append(problems, p)
+	}
+	return problems, rows.Err()
+}
+
+func (r *SQLiteRepository) orphanedCategories(ctx context.Context) ([]Problem, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT t.id, t.category_id
+		 FROM tasks t LEFT JOIN categories c ON c.id = t.category_id
+		 WHERE t.category_id IS NOT NULL AND c.id IS NULL
+		 ORDER BY t.id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var problems []Problem
+	for rows.Next() {
+		var id, categoryID int64
+		if err := rows.Scan(&id, &categoryID); err != nil {
+			return nil, err
+		}
+		problems = append(problems, Problem{
+			Table:  "tasks",
+			ID:     id,
+			Column: "category_id",
+			Value:  fmt.Sprint(categoryID),
+			Issue:  "category does not exist",
+			Repair: "remove the category",
+			query:  "UPDATE tasks SET category_id = NULL WHERE id = ?",
+			args:   []interface{}{id},
+		})
+	}
+	return problems, rows.Err()
+}
+
+// invalidEnums finds statuses and priorities outside the allowed values.
+// A status is inferred from the task's timestamps; a priority becomes medium.
+func (r *SQLiteRepository) invalidEnums(ctx context.Context) ([]Problem, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, status, priority, started_at IS NOT NULL, completed_at IS NOT NULL
+		 FROM tasks
+		 WHERE status NOT IN ('new', 'working', 'completed')
+		    OR priority NOT IN ('low', 'medium', 'high')
+		 ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var problems []Problem
+	for rows.Next() {
+		var id int64
+		var status, priority string
+		var started, completed bool
+		if err := rows.Scan(&id, &status, &priority, &started, &completed); err != nil {
+			return nil, err
+		}
+
+		if !domain.TaskStatus(status).IsValid() {
+			inferred := "new"
+			switch {
+			case completed:
+				inferred = "completed"
+			case started:
+				inferred = "working"
+			}
+			problems = append(problems, Problem{
+				Table:  "tasks",
+				ID:     id,
+				Column: "status",
+				Value:  status,
+				Issue:  "invalid status",
+				Repair: "set it to " + inferred,
+				query:  "UPDATE tasks SET status = ? WHERE id = ?",
+				args:   []interface{}{inferred, id},
+			})
+		}
+		if !domain.Priority(priority).IsValid() {
+			problems = append(problems, Problem{
+				Table:  "tasks",
+				ID:     id,
+				Column: "priority",
+				Value:  priority,
+				Issue:  "invalid priority",
+				Repair: "set it to medium",
+				query:  "UPDATE tasks SET priority = 'medium' WHERE id = ?",
+				args:   []interface{}{id},
+			})
+		}
+	}
+	return problems, rows.Err()
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hitsumabushi845/task-management/internal/domain"
+)
+
+// corrupt runs statements on one connection with CHECK and foreign key
+// constraints off, as a damaged or hand-edited database might contain
+func corrupt(t *testing.T, repo *SQLiteRepository, statements ...string) {
+	t.Helper()
+	ctx := context.Background()
+	conn, err := repo.db.Conn(ctx)
+	if err != nil {
+		t.Fatalf("Conn() error = %v", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "PRAGMA ignore_check_constraints = ON; PRAGMA foreign_keys = OFF"); err != nil {
+		t.Fatalf("PRAGMA error = %v", err)
+	}
+	defer conn.ExecContext(ctx, "PRAGMA ignore_check_constraints = OFF; PRAGMA foreign_keys = ON")
+	for _, stmt := range statements {
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+}
+
+func TestSQLiteRepository_StrictTimestamps(t *testing.T) {
+	repo, err := NewSQLiteRepository(filepath.Join(t.TempDir(), "tasks.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteRepository() error = %v", err)
+	}
+	defer repo.Close()
+	ctx := context.Background()
+
+	task := &domain.Task{Title: "Task", Status: domain.TaskStatusNew, Priority: domain.PriorityLow}
+	if err := repo.Create(ctx, task); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	corrupt(t, repo, "UPDATE tasks SET due_date = '2026-13-45' WHERE id = 1")
+
+	_, err = repo.GetByID(ctx, task.ID)
+	var scanErr *ScanError
+	if !errors.As(err, &scanErr) {
+		t.Fatalf("GetByID() error = %v, want a *ScanError", err)
+	}
+	if scanErr.Table != "tasks" || scanErr.ID != task.ID || scanErr.Column != "due_date" || scanErr.Value != "2026-13-45" {
+		t.Errorf("ScanError = %+v, want tasks row %d column due_date", scanErr, task.ID)
+	}
+	if _, err := repo.List(ctx); !errors.As(err, &scanErr) {
+		t.Errorf("List() error = %v, want a *ScanError", err)
+	}
+	if !strings.Contains(ErrorHint(fmt.Errorf("load: %w", err)), "task doctor") {
+		t.Errorf("ErrorHint() does not suggest task doctor for a wrapped *ScanError")
+	}
+	if hint := ErrorHint(errors.New("disk full")); hint != "" {
+		t.Errorf("ErrorHint() = %q for an unrelated error, want empty", hint)
+	}
+}
+
+func TestSQLiteRepository_DiagnoseAndRepair(t *testing.T) {
+	repo, err := NewSQLiteRepository(filepath.Join(t.TempDir(), "tasks.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteRepository() error = %v", err)
+	}
+	defer repo.Close()
+	ctx := context.Background()
+
+	for _, title := range []string{"First", "Second", "Healthy"} {
+		task := &domain.Task{Title: title, Status: domain.TaskStatusNew, Priority: domain.PriorityLow}
+		if err := repo.Create(ctx, task); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	if problems, err := repo.Diagnose(ctx); err != nil || len(problems) != 0 {
+		t.Fatalf("Diagnose() of a healthy database = %v, %v; want none", problems, err)
+	}
+
+	started := time.Now().Add(-time.Hour).Format(time.RFC3339)
+	corrupt(t, repo,
+		"UPDATE tasks SET due_date = 'next week', category_id = 999 WHERE id = 1",
+		"UPDATE tasks SET created_at = 'yesterday', status = 'doing', priority = 'urgent', started_at = '"+started+"' WHERE id = 2",
+		"UPDATE categories SET created_at = '' WHERE id = 1",
+	)
+
+	problems, err := repo.Diagnose(ctx)
+	if err != nil {
+		t.Fatalf("Diagnose() error = %v", err)
+	}
+	type key struct {
+		table  string
+		id     int64
+		column string
+	}
+	want := map[key]bool{
+		{"tasks", 1, "due_date"}:        true,
+		{"tasks", 1, "category_id"}:     true,
+		{"tasks", 2, "created_at"}:      true,
+		{"tasks", 2, "status"}:          true,
+		{"tasks", 2, "priority"}:        true,
+		{"categories", 1, "created_at"}: true,
+	}
+	if len(problems) != len(want) {
+		t.Errorf("Diagnose() found %d problems, want %d: %v", len(problems), len(want), problems)
+	}
+	for _, p := range problems {
+		if !want[key{p.Table, p.ID, p.Column}] {
+			t.Errorf("unexpected problem %v", p)
+		}
+		if p.Issue == "" || p.Repair == "" {
+			t.Errorf("problem %v lacks an issue or repair", p)
+		}
+	}
+
+	if err := repo.Repair(ctx, problems); err != nil {
+		t.Fatalf("Repair() error = %v", err)
+	}
+	if problems, err := repo.Diagnose(ctx); err != nil || len(problems) != 0 {
+		t.Errorf("Diagnose() after Repair() = %v, %v; want none", problems, err)
+	}
+
+	first, err := repo.GetByID(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetByID(1) error = %v", err)
+	}
+	if first.DueDate != nil || first.CategoryID != nil {
+		t.Errorf("task 1 = due %v, category %v; want both cleared", first.DueDate, first.CategoryID)
+	}
+	second, err := repo.GetByID(ctx, 2)
+	if err != nil {
+		t.Fatalf("GetByID(2) error = %v", err)
+	}
+	if second.Status != domain.TaskStatusWorking || second.Priority != domain.PriorityMedium || second.CreatedAt.IsZero() {
+		t.Errorf("task 2 = %s, %s, created %v; want working, medium and a creation time", second.Status, second.Priority, second.CreatedAt)
+	}
+	if _, err := repo.GetCategories(ctx); err != nil {
+		t.Errorf("GetCategories() after Repair() error = %v", err)
+	}
+}
@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/hitsumabushi845/task-management/internal/domain"
+)
+
+// EventSink receives the events produced by WithEvents. An error fails the
+// call that caused the event, although the change itself is already stored.
+// Events of a WithTx transaction are published after it commits, so an
+// error there is logged to slog.Default instead and the call succeeds.
+type EventSink interface {
+	Publish(ctx context.Context, e domain.Event) error
+}
+
+// WithEvents publishes an event to every sink after each successful task
+// change. Updates that change the status also publish
+// domain.EventTaskStatusChanged. Clearing a fired reminder, adding a note
+// and deleting a category publish domain.EventTaskUpdated for each task
+// they affect. Each event carries its own copy of the task.
+func WithEvents(sinks ...EventSink) Middleware {
+	return func(next domain.TaskRepository) domain.TaskRepository {
+		return &eventRepository{TaskRepository: next, sinks: sinks}
+	}
+}
+
+type eventRepository struct {
+	domain.TaskRepository
+	sinks []EventSink
+}
+
+func (r *eventRepository) Create(ctx context.Context, task *domain.Task) error {
+	if err := r.TaskRepository.Create(ctx, task); err != nil {
+		return err
+	}
+	return r.publish(ctx, domain.EventTaskCreated, task, "")
+}
+
+func (r *eventRepository) Restore(ctx context.Context, task *domain.Task) error {
+	if err := r.TaskRepository.Restore(ctx, task); err != nil {
+		return err
+	}
+	return r.publish(ctx, domain.EventTaskCreated, task, "")
+}
+
+func (r *eventRepository) Update(ctx context.Context, task *domain.Task) error {
+	previous, err := r.TaskRepository.GetByID(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+	if err := r.TaskRepository.Update(ctx, task); err != nil {
+		return err
+	}
+
+	if err := r.publish(ctx, domain.EventTaskUpdated, task, ""); err != nil {
+		return err
+	}
+	if previous.Status != task.Status {
+		return r.publish(ctx, domain.EventTaskStatusChanged, task, previous.Status)
+	}
+	return nil
+}
+
+func (r *eventRepository) Delete(ctx context.Context, id int64) error {
+	task, err := r.TaskRepository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := r.TaskRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	return r.publish(ctx, domain.EventTaskDeleted, task, "")
+}
+
+// ClearReminder publishes the task as updated so subscribers stop showing
+// the reminder
+func (r *eventRepository) ClearReminder(ctx context.Context, task *domain.Task) error {
+	if err := r.TaskRepository.ClearReminder(ctx, task); err != nil {
+		return err
+	}
+	return r.publish(ctx, domain.EventTaskUpdated, task, "")
+}
+
+// AddNote publishes the task, with its notes, as updated
+func (r *eventRepository) AddNote(ctx context.Context, note *domain.Note) error {
+	if err := r.TaskRepository.AddNote(ctx, note); err != nil {
+		return err
+	}
+	task, err := r.TaskRepository.GetByID(ctx, note.TaskID)
+	if err != nil {
+		return err
+	}
+	return r.publish(ctx, domain.EventTaskUpdated, task, "")
+}
+
+// DeleteCategory publishes every task that lost the category as updated
+func (r *eventRepository) DeleteCategory(ctx context.Context, id int64) error {
+	tasks, err := r.TaskRepository.List(ctx)
+	if err != nil {
+		return err
+	}
+	if err := r.TaskRepository.DeleteCategory(ctx, id); err != nil {
+		return err
+	}
+
+	for _, task := range tasks {
+		if task.CategoryID == nil || *task.CategoryID != id {
+			continue
+		}
+		task.CategoryID = nil
+		if err := r.publish(ctx, domain.EventTaskUpdated, task, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WithTx publishes the events of the changes made in fn once they are
+// committed, and none if they are rolled back. The changes are stored by
+// then, so a sink error is logged rather than returned.
+func (r *eventRepository) WithTx(ctx context.Context, fn func(domain.TaskRepository) error) error {
+	var pending eventBuffer
+	err := r.TaskRepository.WithTx(ctx, func(tx domain.TaskRepository) error {
+		return fn(&eventRepository{TaskRepository: tx, sinks: []EventSink{&pending}})
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, e := range pending {
+		for _, sink := range r.sinks {
+			snapshot := *e.Task
+			e.Task = &snapshot
+			if err := sink.Publish(ctx, e); err != nil {
+				slog.Default().LogAttrs(ctx, slog.LevelError, "publishing a committed event failed",
+					slog.String("event", string(e.Type)),
+					slog.Int64("task_id", e.Task.ID),
+					slog.String("error", err.Error()),
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// eventBuffer holds the events of a transaction until it commits
+type eventBuffer []domain.Event
+
+func (b *eventBuffer) Publish(ctx context.Context, e domain.Event) error {
+	*b = append(*b, e)
+	return nil
+}
+
+func (r *eventRepository) publish(ctx context.Context, typ domain.EventType, task *domain.Task, previous domain.TaskStatus) error {
+	now := time.Now()
+	for _, sink := range r.sinks {
+		snapshot := *task
+		e := domain.Event{Type: typ, Task: &snapshot, PreviousStatus: previous, OccurredAt: now}
+		if err := sink.Publish(ctx, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EventBus fans events out to in-process subscribers such as the TUI
+type EventBus struct {
+	mu   sync.Mutex
+	subs map[int]chan domain.Event
+	next int
+}
+
+// NewEventBus creates a bus with no subscribers
+func NewEventBus() *EventBus {
+	return &EventBus{subs: map[int]chan domain.Event{}}
+}
+
+// Subscribe returns a channel receiving every event published from now on
+// and a function that ends the subscription and closes the channel. A
+// subscriber that falls more than buffer events behind misses events and
+// receives one domain.EventsDropped in their place.
+func (b *EventBus) Subscribe(buffer int) (<-chan domain.Event, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.next
+	b.next++
+	// The last slot is kept for EventsDropped
+	ch := make(chan domain.Event, buffer+1)
+	b.subs[id] = ch
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			delete(b.subs, id)
+			close(ch)
+		})
+	}
+}
+
+// Publish delivers e to every subscriber without blocking. It implements
+// EventSink and never fails.
+func (b *EventBus) Publish(ctx context.Context, e domain.Event) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, ch := range b.subs {
+		if len(ch) < cap(ch)-1 {
+			ch <- e
+			continue
+		}
+		// Subscriber is full; drop rather than stall the repository, and
+		// say so unless the last slot already does
+		select {
+		case ch <- domain.Event{Type: domain.EventsDropped, OccurredAt: e.OccurredAt}:
+		default:
+		}
+	}
+	return nil
+}
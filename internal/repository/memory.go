@@ -0,0 +1,424 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hitsumabushi845/task-management/internal/domain"
+)
+
+// MemoryRepository implements TaskRepository in memory with the same
+// behaviour as SQLiteRepository: it validates input, assigns increasing IDs
+// that are never reused, stamps CreatedAt, stores timestamps to the second,
+// starts with the default categories and returns copies, never its own data.
+// It is safe for concurrent use.
+type MemoryRepository struct {
+	mu             sync.Mutex
+	tasks          map[int64]*domain.Task
+	categories     map[int64]*domain.Category
+	notes          map[int64][]domain.Note // By task ID, oldest first
+	nextTaskID     int64
+	nextCategoryID int64
+	nextNoteID     int64
+}
+
+// NewMemoryRepository creates an empty in-memory repository
+func NewMemoryRepository() *MemoryRepository {
+	r := &MemoryRepository{
+		tasks:          map[int64]*domain.Task{},
+		categories:     map[int64]*domain.Category{},
+		notes:          map[int64][]domain.Note{},
+		nextTaskID:     1,
+		nextCategoryID: 1,
+		nextNoteID:     1,
+	}
+	now := storedTime(time.Now())
+	for _, cat := range defaultCategories {
+		id := r.nextCategoryID
+		r.nextCategoryID++
+		r.categories[id] = &domain.Category{ID: id, Name: cat.name, Color: cat.color, CreatedAt: now}
+	}
+	return r
+}
+
+// Close does nothing; the data lives until the repository is garbage collected
+func (r *MemoryRepository) Close() error {
+	return nil
+}
+
+// Create creates a new task
+func (r *MemoryRepository) Create(ctx context.Context, task *domain.Task) error {
+	if err := task.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkCategory(task.CategoryID); err != nil {
+		return err
+	}
+	task.CreatedAt = time.Now()
+	task.Notes = nil
+	r.insert(task)
+	return nil
+}
+
+// Restore creates a task again with a new ID, keeping its timestamps and
+// notes
+func (r *MemoryRepository) Restore(ctx context.Context, task *domain.Task) error {
+	if err := task.Validate(); err != nil {
+		return err
+	}
+	for i := range task.Notes {
+		if err := task.Notes[i].Validate(); err != nil {
+			return err
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkCategory(task.CategoryID); err != nil {
+		return err
+	}
+	if task.CreatedAt.IsZero() {
+		task.CreatedAt = time.Now()
+	}
+	r.insert(task)
+	return nil
+}
+
+// insert stores a new task with its notes, assigning its ID and first
+// version. The caller holds r.mu.
+func (r *MemoryRepository) insert(task *domain.Task) {
+	task.Version = 1
+	task.ID = r.nextTaskID
+	r.nextTaskID++
+	r.tasks[task.ID] = storedTask(task)
+
+	notes := append([]domain.Note(nil), task.Notes...)
+	for i, note := range notes {
+		note.ID = r.nextNoteID
+		r.nextNoteID++
+		note.TaskID = task.ID
+		if note.CreatedAt.IsZero() {
+			note.CreatedAt = time.Now()
+		}
+		notes[i] = note
+		note.CreatedAt = storedTime(note.CreatedAt)
+		r.notes[task.ID] = append(r.notes[task.ID], note)
+	}
+	task.Notes = notes
+}
+
+// Update updates an existing task, returning domain.ErrNotFound if it does
+// not exist and domain.ErrConflict if its version has moved on
+func (r *MemoryRepository) Update(ctx context.Context, task *domain.Task) error {
+	if err := task.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.tasks[task.ID]
+	if !ok {
+		return taskNotFound(task.ID)
+	}
+	if existing.Version != task.Version {
+		return taskConflict(task.ID)
+	}
+	if err := r.checkCategory(task.CategoryID); err != nil {
+		return err
+	}
+	task.Version++
+	updated := storedTask(task)
+	updated.CreatedAt = existing.CreatedAt
+	r.tasks[task.ID] = updated
+	return nil
+}
+
+// ClearReminder clears the reminder of a task that has fired, returning
+// domain.ErrNotFound if the task does not exist and domain.ErrConflict if
+// its version has moved on or the reminder is already cleared
+func (r *MemoryRepository) ClearReminder(ctx context.Context, task *domain.Task) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.tasks[task.ID]
+	if !ok {
+		return taskNotFound(task.ID)
+	}
+	if existing.Version != task.Version || existing.RemindAt == nil {
+		return taskConflict(task.ID)
+	}
+	existing.RemindAt = nil
+	existing.Version++
+	task.RemindAt = nil
+	task.Version = existing.Version
+	return nil
+}
+
+// Delete deletes a task by ID, returning domain.ErrNotFound if it does not
+// exist
+func (r *MemoryRepository) Delete(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.tasks[id]; !ok {
+		return taskNotFound(id)
+	}
+	delete(r.tasks, id)
+	delete(r.notes, id)
+	return nil
+}
+
+// GetByID retrieves a task by ID, returning domain.ErrNotFound if it does
+// not exist
+func (r *MemoryRepository) GetByID(ctx context.Context, id int64) (*domain.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	task, ok := r.tasks[id]
+	if !ok {
+		return nil, taskNotFound(id)
+	}
+	return r.readTask(task), nil
+}
+
+// List retrieves all tasks, newest first
+func (r *MemoryRepository) List(ctx context.Context) ([]*domain.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var tasks []*domain.Task
+	for _, task := range r.tasks {
+		tasks = append(tasks, r.readTask(task))
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		if !tasks[i].CreatedAt.Equal(tasks[j].CreatedAt) {
+			return tasks[i].CreatedAt.After(tasks[j].CreatedAt)
+		}
+		return tasks[i].ID > tasks[j].ID
+	})
+	return tasks, nil
+}
+
+// AddNote appends a note to a task, returning domain.ErrNotFound if the
+// task does not exist
+func (r *MemoryRepository) AddNote(ctx context.Context, note *domain.Note) error {
+	if err := note.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.tasks[note.TaskID]; !ok {
+		return taskNotFound(note.TaskID)
+	}
+	note.CreatedAt = time.Now()
+	note.ID = r.nextNoteID
+	r.nextNoteID++
+	stored := *note
+	stored.CreatedAt = storedTime(stored.CreatedAt)
+	r.notes[note.TaskID] = append(r.notes[note.TaskID], stored)
+	return nil
+}
+
+// ListNotes retrieves the notes of a task, oldest first, returning
+// domain.ErrNotFound if the task does not exist
+func (r *MemoryRepository) ListNotes(ctx context.Context, taskID int64) ([]*domain.Note, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.tasks[taskID]; !ok {
+		return nil, taskNotFound(taskID)
+	}
+	notes := make([]*domain.Note, 0, len(r.notes[taskID]))
+	for _, note := range r.notes[taskID] {
+		n := note
+		notes = append(notes, &n)
+	}
+	return notes, nil
+}
+
+// readTask copies a stored task with its notes, as GetByID and List return it
+func (r *MemoryRepository) readTask(task *domain.Task) *domain.Task {
+	c := copyTask(task)
+	c.Notes = append([]domain.Note(nil), r.notes[task.ID]...)
+	return c
+}
+
+// CreateCategory creates a new category, returning
+// domain.ErrDuplicateCategory if the name is taken
+func (r *MemoryRepository) CreateCategory(ctx context.Context, category *domain.Category) error {
+	if err := category.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.categories {
+		if existing.Name == category.Name {
+			return duplicateCategory(category.Name)
+		}
+	}
+
+	category.CreatedAt = time.Now()
+	category.ID = r.nextCategoryID
+	r.nextCategoryID++
+	stored := *category
+	stored.CreatedAt = storedTime(stored.CreatedAt)
+	r.categories[stored.ID] = &stored
+	return nil
+}
+
+// GetCategories retrieves all categories ordered by name
+func (r *MemoryRepository) GetCategories(ctx context.Context) ([]*domain.Category, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var categories []*domain.Category
+	for _, category := range r.categories {
+		c := *category
+		categories = append(categories, &c)
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		return categories[i].Name < categories[j].Name
+	})
+	return categories, nil
+}
+
+// DeleteCategory deletes a category by ID, returning domain.ErrNotFound if
+// it does not exist. Its tasks are kept without a category.
+func (r *MemoryRepository) DeleteCategory(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.categories[id]; !ok {
+		return fmt.Errorf("category %d: %w", id, domain.ErrNotFound)
+	}
+	delete(r.categories, id)
+	for _, task := range r.tasks {
+		if task.CategoryID != nil && *task.CategoryID == id {
+			task.CategoryID = nil
+		}
+	}
+	return nil
+}
+
+// WithTx runs fn on a copy of the repository and keeps the copy if fn
+// returns nil. Other calls wait until fn returns, so fn must make its
+// changes through the repository it is given.
+func (r *MemoryRepository) WithTx(ctx context.Context, fn func(domain.TaskRepository) error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tx := &MemoryRepository{
+		tasks:          make(map[int64]*domain.Task, len(r.tasks)),
+		categories:     make(map[int64]*domain.Category, len(r.categories)),
+		notes:          make(map[int64][]domain.Note, len(r.notes)),
+		nextTaskID:     r.nextTaskID,
+		nextCategoryID: r.nextCategoryID,
+		nextNoteID:     r.nextNoteID,
+	}
+	for id, task := range r.tasks {
+		tx.tasks[id] = copyTask(task)
+	}
+	for id, category := range r.categories {
+		c := *category
+		tx.categories[id] = &c
+	}
+	for id, notes := range r.notes {
+		tx.notes[id] = append([]domain.Note(nil), notes...)
+	}
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	r.tasks = tx.tasks
+	r.categories = tx.categories
+	r.notes = tx.notes
+	r.nextTaskID = tx.nextTaskID
+	r.nextCategoryID = tx.nextCategoryID
+	r.nextNoteID = tx.nextNoteID
+	return nil
+}
+
+// checkCategory enforces the foreign key SQLiteRepository declares
+func (r *MemoryRepository) checkCategory(id *int64) error {
+	if id == nil {
+		return nil
+	}
+	if _, ok := r.categories[*id]; !ok {
+		return missingCategory(id)
+	}
+	return nil
+}
+
+// storedTime drops what SQLiteRepository's RFC 3339 columns cannot hold
+func storedTime(t time.Time) time.Time {
+	return t.Truncate(time.Second).Round(0)
+}
+
+func storedTimePtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	s := storedTime(*t)
+	return &s
+}
+
+// storedTask copies task as SQLiteRepository would store it
+func storedTask(task *domain.Task) *domain.Task {
+	stored := copyTask(task)
+	stored.CreatedAt = storedTime(stored.CreatedAt)
+	stored.DueDate = storedTimePtr(stored.DueDate)
+	stored.StartedAt = storedTimePtr(stored.StartedAt)
+	stored.CompletedAt = storedTimePtr(stored.CompletedAt)
+	stored.RemindAt = storedTimePtr(stored.RemindAt)
+	stored.ArchivedAt = storedTimePtr(stored.ArchivedAt)
+	for i := range stored.Attachments {
+		stored.Attachments[i].AddedAt = storedTime(stored.Attachments[i].AddedAt)
+	}
+	stored.Notes = nil // Kept apart and only changed by AddNote
+	return stored
+}
+
+// copyTask returns a deep copy of task
+func copyTask(task *domain.Task) *domain.Task {
+	c := *task
+	if task.CategoryID != nil {
+		id := *task.CategoryID
+		c.CategoryID = &id
+	}
+	c.DueDate = copyTimePtr(task.DueDate)
+	c.StartedAt = copyTimePtr(task.StartedAt)
+	c.CompletedAt = copyTimePtr(task.CompletedAt)
+	c.RemindAt = copyTimePtr(task.RemindAt)
+	c.ArchivedAt = copyTimePtr(task.ArchivedAt)
+	if task.Checklist != nil {
+		c.Checklist = append([]domain.ChecklistItem(nil), task.Checklist...)
+	}
+	if task.Attachments != nil {
+		c.Attachments = append([]domain.Attachment(nil), task.Attachments...)
+	}
+	if task.Notes != nil {
+		c.Notes = append([]domain.Note(nil), task.Notes...)
+	}
+	return &c
+}
+
+func copyTimePtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	c := *t
+	return &c
+}
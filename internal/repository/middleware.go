@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/hitsumabushi845/task-management/internal/domain"
+)
+
+// Middleware wraps a TaskRepository with cross-cutting behaviour such as
+// logging, hooks or events
+type Middleware func(next domain.TaskRepository) domain.TaskRepository
+
+// Chain wraps repo with mws. The first middleware is the outermost, so it
+// sees each call first and its result last.
+func Chain(repo domain.TaskRepository, mws ...Middleware) domain.TaskRepository {
+	for i := len(mws) - 1; i >= 0; i-- {
+		repo = mws[i](repo)
+	}
+	return repo
+}
+
+// WithLogging logs every call with its duration and error, if any
+func WithLogging(logger *slog.Logger) Middleware {
+	return func(next domain.TaskRepository) domain.TaskRepository {
+		return &aroundRepository{next: next, around: func(ctx context.Context, op string, call func() error) error {
+			start := time.Now()
+			err := call()
+			attrs := []slog.Attr{
+				slog.String("op", op),
+				slog.Duration("duration", time.Since(start)),
+			}
+			if err != nil {
+				attrs = append(attrs, slog.String("error", err.Error()))
+				logger.LogAttrs(ctx, slog.LevelError, "repository call failed", attrs...)
+			} else {
+				logger.LogAttrs(ctx, slog.LevelDebug, "repository call", attrs...)
+			}
+			return err
+		}}
+	}
+}
+
+// OpStats summarises the calls to one repository method
+type OpStats struct {
+	Calls  int           `json:"calls"`
+	Errors int           `json:"errors"`
+	Total  time.Duration `json:"total_ns"`
+	Max    time.Duration `json:"max_ns"`
+}
+
+// Timing collects call counts and latencies per repository method
+type Timing struct {
+	mu    sync.Mutex
+	stats map[string]*OpStats
+}
+
+// NewTiming creates an empty collector
+func NewTiming() *Timing {
+	return &Timing{stats: map[string]*OpStats{}}
+}
+
+// Middleware records every call made through the wrapped repository
+func (t *Timing) Middleware() Middleware {
+	return func(next domain.TaskRepository) domain.TaskRepository {
+		return &aroundRepository{next: next, around: func(ctx context.Context, op string, call func() error) error {
+			start := time.Now()
+			err := call()
+			t.record(op, time.Since(start), err)
+			return err
+		}}
+	}
+}
+
+func (t *Timing) record(op string, d time.Duration, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s, ok := t.stats[op]
+	if !ok {
+		s = &OpStats{}
+		t.stats[op] = s
+	}
+	s.Calls++
+	if err != nil {
+		s.Errors++
+	}
+	s.Total += d
+	if d > s.Max {
+		s.Max = d
+	}
+}
+
+// Snapshot returns a copy of the statistics keyed by method name
+func (t *Timing) Snapshot() map[string]OpStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	out := make(map[string]OpStats, len(t.stats))
+	for op, s := range t.stats {
+		out[op] = *s
+	}
+	return out
+}
+
+// aroundRepository runs around for every call to next
+type aroundRepository struct {
+	next   domain.TaskRepository
+	around func(ctx context.Context, op string, call func() error) error
+}
+
+func (r *aroundRepository) Create(ctx context.Context, task *domain.Task) error {
+	return r.around(ctx, "Create", func() error { return r.next.Create(ctx, task) })
+}
+
+func (r *aroundRepository) Restore(ctx context.Context, task *domain.Task) error {
+	return r.around(ctx, "Restore", func() error { return r.next.Restore(ctx, task) })
+}
+
+func (r *aroundRepository) Update(ctx context.Context, task *domain.Task) error {
+	return r.around(ctx, "Update", func() error { return r.next.Update(ctx, task) })
+}
+
+func (r *aroundRepository) ClearReminder(ctx context.Context, task *domain.Task) error {
+	return r.around(ctx, "ClearReminder", func() error { return r.next.ClearReminder(ctx, task) })
+}
+
+func (r *aroundRepository) Delete(ctx context.Context, id int64) error {
+	return r.around(ctx, "Delete", func() error { return r.next.Delete(ctx, id) })
+}
+
+func (r *aroundRepository) GetByID(ctx context.Context, id int64) (*domain.Task, error) {
+	var task *domain.Task
+	err := r.around(ctx, "GetByID", func() error {
+		var err error
+		task, err = r.next.GetByID(ctx, id)
+		return err
+	})
+	return task, err
+}
+
+func (r *aroundRepository) List(ctx context.Context) ([]*domain.Task, error) {
+	var tasks []*domain.Task
+	err := r.around(ctx, "List", func() error {
+		var err error
+		tasks, err = r.next.List(ctx)
+		return err
+	})
+	return tasks, err
+}
+
+func (r *aroundRepository) AddNote(ctx context.Context, note *domain.Note) error {
+	return r.around(ctx, "AddNote", func() error { return r.next.AddNote(ctx, note) })
+}
+
+func (r *aroundRepository) ListNotes(ctx context.Context, taskID int64) ([]*domain.Note, error) {
+	var notes []*domain.Note
+	err := r.around(ctx, "ListNotes", func() error {
+		var err error
+		notes, err = r.next.ListNotes(ctx, taskID)
+		return err
+	})
+	return notes, err
+}
+
+func (r *aroundRepository) CreateCategory(ctx context.Context, category *domain.Category) error {
+	return r.around(ctx, "CreateCategory", func() error { return r.next.CreateCategory(ctx, category) })
+}
+
+func (r *aroundRepository) GetCategories(ctx context.Context) ([]*domain.Category, error) {
+	var categories []*domain.Category
+	err := r.around(ctx, "GetCategories", func() error {
+		var err error
+		categories, err = r.next.GetCategories(ctx)
+		return err
+	})
+	return categories, err
+}
+
+func (r *aroundRepository) DeleteCategory(ctx context.Context, id int64) error {
+	return r.around(ctx, "DeleteCategory", func() error { return r.next.DeleteCategory(ctx, id) })
+}
+
+func (r *aroundRepository) Close() error {
+	return r.around(context.Background(), "Close", r.next.Close)
+}
+
+func (r *aroundRepository) WithTx(ctx context.Context, fn func(domain.TaskRepository) error) error {
+	return r.around(ctx, "WithTx", func() error {
+		return r.next.WithTx(ctx, func(tx domain.TaskRepository) error {
+			return fn(&aroundRepository{next: tx, around: r.around})
+		})
+	})
+}
@@ -0,0 +1,327 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hitsumabushi845/task-management/internal/domain"
+)
+
+func newInMemorySQLite(t *testing.T) *SQLiteRepository {
+	t.Helper()
+	repo, err := NewSQLiteRepository(":memory:")
+	if err != nil {
+		t.Fatalf("NewSQLiteRepository() error = %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	return repo
+}
+
+// recordSink collects published events
+type recordSink struct {
+	events []domain.Event
+	err    error
+}
+
+func (s *recordSink) Publish(ctx context.Context, e domain.Event) error {
+	s.events = append(s.events, e)
+	return s.err
+}
+
+func TestChain_Order(t *testing.T) {
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(next domain.TaskRepository) domain.TaskRepository {
+			return &aroundRepository{next: next, around: func(ctx context.Context, op string, call func() error) error {
+				calls = append(calls, name+" before")
+				err := call()
+				calls = append(calls, name+" after")
+				return err
+			}}
+		}
+	}
+
+	repo := Chain(newInMemorySQLite(t), trace("outer"), trace("inner"))
+	if _, err := repo.List(context.Background()); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	want := []string{"outer before", "inner before", "inner after", "outer after"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestWithEvents(t *testing.T) {
+	sink := &recordSink{}
+	repo := Chain(newInMemorySQLite(t), WithEvents(sink))
+	ctx := context.Background()
+
+	task := &domain.Task{Title: "Write docs", Status: domain.TaskStatusNew, Priority: domain.PriorityMedium}
+	if err := repo.Create(ctx, task); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	task.Status = domain.TaskStatusWorking
+	if err := repo.Update(ctx, task); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if err := repo.Delete(ctx, task.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	// A failed call publishes nothing
+	if err := repo.Delete(ctx, task.ID); err == nil {
+		t.Errorf("Delete() of a deleted task succeeded")
+	}
+
+	want := []domain.EventType{
+		domain.EventTaskCreated,
+		domain.EventTaskUpdated,
+		domain.EventTaskStatusChanged,
+		domain.EventTaskDeleted,
+	}
+	if len(sink.events) != len(want) {
+		t.Fatalf("got %d events, want %d", len(sink.events), len(want))
+	}
+	for i, e := range sink.events {
+		if e.Type != want[i] {
+			t.Errorf("event %d = %s, want %s", i, e.Type, want[i])
+		}
+		if e.Task.ID != task.ID {
+			t.Errorf("event %d task ID = %d, want %d", i, e.Task.ID, task.ID)
+		}
+	}
+	if got := sink.events[2].PreviousStatus; got != domain.TaskStatusNew {
+		t.Errorf("PreviousStatus = %s, want new", got)
+	}
+
+	// Events hold copies, unaffected by later changes to the task
+	task.Title = "changed"
+	if sink.events[0].Task.Title != "Write docs" {
+		t.Errorf("event task title = %q, want the title at the time", sink.events[0].Task.Title)
+	}
+}
+
+func TestWithEvents_IndirectChanges(t *testing.T) {
+	sink := &recordSink{}
+	repo := Chain(newInMemorySQLite(t), WithEvents(sink))
+	ctx := context.Background()
+
+	category := &domain.Category{Name: "Work", Color: "red"}
+	if err := repo.CreateCategory(ctx, category); err != nil {
+		t.Fatalf("CreateCategory() error = %v", err)
+	}
+	at := time.Now().Add(-time.Minute)
+	filed := &domain.Task{Title: "Filed", Status: domain.TaskStatusNew, Priority: domain.PriorityMedium, CategoryID: &category.ID, RemindAt: &at}
+	other := &domain.Task{Title: "Other", Status: domain.TaskStatusNew, Priority: domain.PriorityMedium}
+	for _, task := range []*domain.Task{filed, other} {
+		if err := repo.Create(ctx, task); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	sink.events = nil
+
+	if err := repo.ClearReminder(ctx, filed); err != nil {
+		t.Fatalf("ClearReminder() error = %v", err)
+	}
+	if err := repo.AddNote(ctx, &domain.Note{TaskID: filed.ID, Text: "Called back"}); err != nil {
+		t.Fatalf("AddNote() error = %v", err)
+	}
+	if err := repo.DeleteCategory(ctx, category.ID); err != nil {
+		t.Fatalf("DeleteCategory() error = %v", err)
+	}
+
+	if len(sink.events) != 3 {
+		t.Fatalf("got %d events, want one per change to Filed", len(sink.events))
+	}
+	for i, e := range sink.events {
+		if e.Type != domain.EventTaskUpdated || e.Task.ID != filed.ID {
+			t.Errorf("event %d = %s for task %d, want %s for %d", i, e.Type, e.Task.ID, domain.EventTaskUpdated, filed.ID)
+		}
+	}
+	if e := sink.events[0]; e.Task.RemindAt != nil {
+		t.Errorf("reminder event RemindAt = %v, want nil", e.Task.RemindAt)
+	}
+	if e := sink.events[1]; len(e.Task.Notes) != 1 {
+		t.Errorf("note event notes = %+v, want the new note", e.Task.Notes)
+	}
+	if e := sink.events[2]; e.Task.CategoryID != nil {
+		t.Errorf("category event CategoryID = %d, want nil", *e.Task.CategoryID)
+	}
+}
+
+func TestWithEvents_SinkError(t *testing.T) {
+	sinkErr := errors.New("sink down")
+	repo := Chain(newInMemorySQLite(t), WithEvents(&recordSink{err: sinkErr}))
+
+	task := &domain.Task{Title: "Task", Status: domain.TaskStatusNew, Priority: domain.PriorityLow}
+	if err := repo.Create(context.Background(), task); !errors.Is(err, sinkErr) {
+		t.Errorf("Create() error = %v, want %v", err, sinkErr)
+	}
+}
+
+func TestWithEvents_SinkErrorAfterCommit(t *testing.T) {
+	var buf bytes.Buffer
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, nil)))
+
+	store := newInMemorySQLite(t)
+	repo := Chain(store, WithEvents(&recordSink{err: errors.New("sink down")}))
+	ctx := context.Background()
+
+	task := &domain.Task{Title: "Committed", Status: domain.TaskStatusNew, Priority: domain.PriorityLow}
+	err := repo.WithTx(ctx, func(tx domain.TaskRepository) error {
+		return tx.Create(ctx, task)
+	})
+	if err != nil {
+		t.Fatalf("WithTx() error = %v, want the committed change to succeed", err)
+	}
+	if _, err := store.GetByID(ctx, task.ID); err != nil {
+		t.Errorf("GetByID() error = %v, want the task stored", err)
+	}
+	if !strings.Contains(buf.String(), "sink down") {
+		t.Errorf("log = %q, want the sink error", buf.String())
+	}
+}
+
+func TestWithEvents_Transaction(t *testing.T) {
+	sink := &recordSink{}
+	repo := Chain(newInMemorySQLite(t), WithEvents(sink))
+	ctx := context.Background()
+
+	errAbort := errors.New("abort")
+	err := repo.WithTx(ctx, func(tx domain.TaskRepository) error {
+		if err := tx.Create(ctx, &domain.Task{Title: "Discarded", Status: domain.TaskStatusNew, Priority: domain.PriorityLow}); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("WithTx() error = %v, want %v", err, errAbort)
+	}
+	if len(sink.events) != 0 {
+		t.Errorf("got %d events from a rolled back transaction, want none", len(sink.events))
+	}
+
+	err = repo.WithTx(ctx, func(tx domain.TaskRepository) error {
+		for _, title := range []string{"First", "Second"} {
+			if err := tx.Create(ctx, &domain.Task{Title: title, Status: domain.TaskStatusNew, Priority: domain.PriorityLow}); err != nil {
+				return err
+			}
+		}
+		if len(sink.events) != 0 {
+			t.Errorf("got %d events before commit, want none", len(sink.events))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WithTx() error = %v", err)
+	}
+	if len(sink.events) != 2 || sink.events[0].Task.Title != "First" || sink.events[1].Task.Title != "Second" {
+		t.Errorf("events after commit = %v, want First and Second created", sink.events)
+	}
+}
+
+func TestEventBus(t *testing.T) {
+	bus := NewEventBus()
+	ctx := context.Background()
+	a, unsubscribeA := bus.Subscribe(1)
+	b, unsubscribeB := bus.Subscribe(1)
+	defer unsubscribeB()
+
+	e := domain.Event{Type: domain.EventTaskCreated, Task: &domain.Task{ID: 1}}
+	bus.Publish(ctx, e)
+	if got := <-a; got.Task.ID != 1 {
+		t.Errorf("subscriber a got task %d, want 1", got.Task.ID)
+	}
+
+	// b is still full, so these events are dropped for b but reach a
+	for id := int64(2); id <= 3; id++ {
+		bus.Publish(ctx, domain.Event{Type: domain.EventTaskDeleted, Task: &domain.Task{ID: id}})
+		if got := <-a; got.Type != domain.EventTaskDeleted || got.Task.ID != id {
+			t.Errorf("subscriber a got %s of task %d, want %s of %d", got.Type, got.Task.ID, domain.EventTaskDeleted, id)
+		}
+	}
+	if got := <-b; got.Task.ID != 1 {
+		t.Errorf("subscriber b got task %d, want 1", got.Task.ID)
+	}
+	// b learns that it missed events, once
+	if got := <-b; got.Type != domain.EventsDropped {
+		t.Errorf("subscriber b got %s after its buffer was full, want %s", got.Type, domain.EventsDropped)
+	}
+	select {
+	case got := <-b:
+		t.Errorf("subscriber b got %v after the drop notice", got.Type)
+	default:
+	}
+
+	unsubscribeA()
+	unsubscribeA()
+	if _, ok := <-a; ok {
+		t.Errorf("channel still open after unsubscribe")
+	}
+	bus.Publish(ctx, e)
+}
+
+func TestWithLogging(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	repo := Chain(newInMemorySQLite(t), WithLogging(logger))
+	ctx := context.Background()
+
+	if _, err := repo.List(ctx); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if _, err := repo.GetByID(ctx, 999); err == nil {
+		t.Fatalf("GetByID() of a missing task succeeded")
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d log lines, want 2:\n%s", len(lines), buf.String())
+	}
+	var records []map[string]interface{}
+	for _, line := range lines {
+		var rec map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("log line %q is not JSON: %v", line, err)
+		}
+		records = append(records, rec)
+	}
+	if records[0]["op"] != "List" || records[0]["level"] != "DEBUG" {
+		t.Errorf("first record = %v, want a DEBUG List", records[0])
+	}
+	if records[1]["op"] != "GetByID" || records[1]["level"] != "ERROR" || records[1]["error"] == nil {
+		t.Errorf("second record = %v, want an ERROR GetByID with the error", records[1])
+	}
+}
+
+func TestTiming(t *testing.T) {
+	timing := NewTiming()
+	repo := Chain(newInMemorySQLite(t), timing.Middleware())
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if _, err := repo.List(ctx); err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+	}
+	repo.GetByID(ctx, 999)
+
+	stats := timing.Snapshot()
+	if got := stats["List"]; got.Calls != 3 || got.Errors != 0 || got.Max > got.Total {
+		t.Errorf("List stats = %+v, want 3 calls and no errors", got)
+	}
+	if got := stats["GetByID"]; got.Calls != 1 || got.Errors != 1 {
+		t.Errorf("GetByID stats = %+v, want 1 call and 1 error", got)
+	}
+	if _, ok := stats["Create"]; ok {
+		t.Errorf("stats include Create, which was never called")
+	}
+}
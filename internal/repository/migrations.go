@@ -2,9 +2,142 @@ package repository
 
 import (
 	"database/sql"
+	"fmt"
 	"time"
 )
 
+// migrations are applied in order on top of the base schema. The number of
+// applied migrations is recorded in PRAGMA user_version, so entries must only
+// ever be appended.
+var migrations = []string{
+	// 1: reminders
+	`ALTER TABLE tasks ADD COLUMN remind_at DATETIME`,
+
+	// 2: outbound webhook delivery queue
+	`CREATE TABLE webhook_deliveries (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		url TEXT NOT NULL,
+		event TEXT NOT NULL,
+		payload BLOB NOT NULL,
+		attempts INTEGER NOT NULL DEFAULT 0,
+		next_attempt_at DATETIME NOT NULL,
+		last_error TEXT NOT NULL DEFAULT '',
+		created_at DATETIME NOT NULL,
+		delivered_at DATETIME,
+		failed_at DATETIME
+	)`,
+
+	// 3: drop dangling category references, then rebuild tasks so that
+	// deleting a category uncategorizes its tasks. Dropping tasks deletes its
+	// AUTOINCREMENT counter, which is carried over so that the IDs of deleted
+	// tasks are never reused.
+	`UPDATE tasks SET category_id = NULL
+	 WHERE category_id IS NOT NULL AND category_id NOT IN (SELECT id FROM categories);
+	CREATE TABLE tasks_new (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		title TEXT NOT NULL,
+		description TEXT,
+		status TEXT NOT NULL CHECK(status IN ('new', 'working', 'completed')),
+		priority TEXT NOT NULL CHECK(priority IN ('low', 'medium', 'high')),
+		category_id INTEGER,
+		due_date DATETIME,
+		created_at DATETIME NOT NULL,
+		started_at DATETIME,
+		completed_at DATETIME,
+		remind_at DATETIME,
+		FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE SET NULL
+	);
+	INSERT INTO tasks_new (id, title, description, status, priority, category_id, due_date, created_at, started_at, completed_at, remind_at)
+	SELECT id, title, description, status, priority, category_id, due_date, created_at, started_at, completed_at, remind_at FROM tasks;
+	DELETE FROM sqlite_sequence WHERE name = 'tasks_new';
+	INSERT INTO sqlite_sequence (name, seq) SELECT 'tasks_new', seq FROM sqlite_sequence WHERE name = 'tasks';
+	DROP TABLE tasks;
+	ALTER TABLE tasks_new RENAME TO tasks;`,
+
+	// 4: optimistic concurrency
+	`ALTER TABLE tasks ADD COLUMN version INTEGER NOT NULL DEFAULT 1`,
+
+	// 5: archiving
+	`ALTER TABLE tasks ADD COLUMN archived_at DATETIME`,
+
+	// 6: checklists, saved and deleted with their task
+	`CREATE TABLE checklist_items (
+		task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+		position INTEGER NOT NULL,
+		text TEXT NOT NULL,
+		done BOOLEAN NOT NULL DEFAULT 0,
+		PRIMARY KEY (task_id, position)
+	)`,
+
+	// 7: notes timeline, deleted with its task
+	`CREATE TABLE task_notes (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+		text TEXT NOT NULL,
+		created_at DATETIME NOT NULL
+	);
+	CREATE INDEX task_notes_task_id ON task_notes (task_id);`,
+
+	// 8: attachments, saved and deleted with their task
+	`CREATE TABLE task_attachments (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+		position INTEGER NOT NULL,
+		location TEXT NOT NULL,
+		added_at DATETIME NOT NULL,
+		UNIQUE (task_id, position)
+	)`,
+
+	// 9: a counter of changes to task data, bumped by every writer, so that
+	// ChangeWatcher can tell them from webhook queue writes and from its
+	// own process's commits
+	`CREATE TABLE data_changes (
+		id INTEGER PRIMARY KEY CHECK (id = 1),
+		version INTEGER NOT NULL
+	);
+	INSERT INTO data_changes (id, version) VALUES (1, 0);
+	CREATE TRIGGER tasks_insert_changed AFTER INSERT ON tasks
+	BEGIN UPDATE data_changes SET version = version + 1; END;
+	CREATE TRIGGER tasks_update_changed AFTER UPDATE ON tasks
+	BEGIN UPDATE data_changes SET version = version + 1; END;
+	CREATE TRIGGER tasks_delete_changed AFTER DELETE ON tasks
+	BEGIN UPDATE data_changes SET version = version + 1; END;
+	CREATE TRIGGER categories_insert_changed AFTER INSERT ON categories
+	BEGIN UPDATE data_changes SET version = version + 1; END;
+	CREATE TRIGGER categories_update_changed AFTER UPDATE ON categories
+	BEGIN UPDATE data_changes SET version = version + 1; END;
+	CREATE TRIGGER categories_delete_changed AFTER DELETE ON categories
+	BEGIN UPDATE data_changes SET version = version + 1; END;
+	CREATE TRIGGER checklist_items_insert_changed AFTER INSERT ON checklist_items
+	BEGIN UPDATE data_changes SET version = version + 1; END;
+	CREATE TRIGGER checklist_items_update_changed AFTER UPDATE ON checklist_items
+	BEGIN UPDATE data_changes SET version = version + 1; END;
+	CREATE TRIGGER checklist_items_delete_changed AFTER DELETE ON checklist_items
+	BEGIN UPDATE data_changes SET version = version + 1; END;
+	CREATE TRIGGER task_notes_insert_changed AFTER INSERT ON task_notes
+	BEGIN UPDATE data_changes SET version = version + 1; END;
+	CREATE TRIGGER task_notes_update_changed AFTER UPDATE ON task_notes
+	BEGIN UPDATE data_changes SET version = version + 1; END;
+	CREATE TRIGGER task_notes_delete_changed AFTER DELETE ON task_notes
+	BEGIN UPDATE data_changes SET version = version + 1; END;
+	CREATE TRIGGER task_attachments_insert_changed AFTER INSERT ON task_attachments
+	BEGIN UPDATE data_changes SET version = version + 1; END;
+	CREATE TRIGGER task_attachments_update_changed AFTER UPDATE ON task_attachments
+	BEGIN UPDATE data_changes SET version = version + 1; END;
+	CREATE TRIGGER task_attachments_delete_changed AFTER DELETE ON task_attachments
+	BEGIN UPDATE data_changes SET version = version + 1; END;`,
+}
+
+// defaultCategories are created in a new, empty repository
+var defaultCategories = []struct {
+	name  string
+	color string
+}{
+	{"仕事", "blue"},
+	{"個人", "green"},
+	{"その他", "yellow"},
+}
+
 // runMigrations executes database migrations
 func runMigrations(db *sql.DB) error {
 	// Create categories table
@@ -49,15 +182,6 @@ func runMigrations(db *sql.DB) error {
 
 	if count == 0 {
 		now := time.Now().Format(time.RFC3339)
-		defaultCategories := []struct {
-			name  string
-			color string
-		}{
-			{"仕事", "blue"},
-			{"個人", "green"},
-			{"その他", "yellow"},
-		}
-
 		for _, cat := range defaultCategories {
 			_, err = db.Exec(
 				"INSERT INTO categories (name, color, created_at) VALUES (?, ?, ?)",
@@ -69,5 +193,34 @@ func runMigrations(db *sql.DB) error {
 		}
 	}
 
+	return applyMigrations(db)
+}
+
+// applyMigrations runs the migrations newer than the database's user_version
+func applyMigrations(db *sql.DB) error {
+	var version int
+	if err := db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		return err
+	}
+
+	for i := version; i < len(migrations); i++ {
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(migrations[i]); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %d: %w", i+1, err)
+		}
+		// PRAGMA does not accept bound parameters
+		if _, err := tx.Exec(fmt.Sprintf("PRAGMA user_version = %d", i+1)); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
@@ -1,9 +1,13 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"path/filepath"
 	"testing"
 
+	"github.com/hitsumabushi845/task-management/internal/domain"
+
 	_ "modernc.org/sqlite"
 )
 
@@ -62,3 +66,128 @@ func TestRunMigrations_CreatesDefaultCategories(t *testing.T) {
 		t.Errorf("expected 3 default categories, got %d", count)
 	}
 }
+
+func TestRunMigrations_AppliesVersionedMigrations(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	if err := runMigrations(db); err != nil {
+		t.Fatalf("runMigrations() error = %v", err)
+	}
+
+	// Running again must be a no-op
+	if err := runMigrations(db); err != nil {
+		t.Fatalf("second runMigrations() error = %v", err)
+	}
+
+	var version int
+	if err := db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		t.Fatalf("failed to read user_version: %v", err)
+	}
+	if version != len(migrations) {
+		t.Errorf("user_version = %d, want %d", version, len(migrations))
+	}
+
+	// Verify remind_at column exists
+	var count int
+	err = db.QueryRow("SELECT COUNT(*) FROM pragma_table_info('tasks') WHERE name = 'remind_at'").Scan(&count)
+	if err != nil {
+		t.Fatalf("failed to inspect tasks table: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("remind_at column not found")
+	}
+}
+
+func TestRunMigrations_ClearsDanglingCategories(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.db")
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+
+	// Build the schema as it was before foreign keys were enforced
+	all := migrations
+	migrations = migrations[:2]
+	err = runMigrations(db)
+	migrations = all
+	if err != nil {
+		t.Fatalf("runMigrations() error = %v", err)
+	}
+	_, err = db.Exec(`INSERT INTO tasks (title, description, status, priority, category_id, created_at) VALUES
+		('Dangling', '', 'new', 'low', 999, '2026-01-01T00:00:00Z'),
+		('Categorized', '', 'new', 'low', 1, '2026-01-01T00:00:00Z')`)
+	if err != nil {
+		t.Fatalf("insert tasks: %v", err)
+	}
+	db.Close()
+
+	repo, err := NewSQLiteRepository(path)
+	if err != nil {
+		t.Fatalf("NewSQLiteRepository() error = %v", err)
+	}
+	defer repo.Close()
+	ctx := context.Background()
+
+	dangling, err := repo.GetByID(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetByID(1) error = %v", err)
+	}
+	if dangling.CategoryID != nil {
+		t.Errorf("dangling category_id = %d, want nil", *dangling.CategoryID)
+	}
+	categorized, err := repo.GetByID(ctx, 2)
+	if err != nil {
+		t.Fatalf("GetByID(2) error = %v", err)
+	}
+	if categorized.CategoryID == nil || *categorized.CategoryID != 1 {
+		t.Errorf("category_id = %v, want 1", categorized.CategoryID)
+	}
+	if problems, err := repo.Diagnose(ctx); err != nil || len(problems) != 0 {
+		t.Errorf("Diagnose() after migrating = %v, %v; want none", problems, err)
+	}
+}
+
+func TestRunMigrations_KeepsAutoincrementAcrossRebuild(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.db")
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+
+	// Before the tasks table was rebuilt, the newest task was deleted
+	all := migrations
+	migrations = migrations[:2]
+	err = runMigrations(db)
+	migrations = all
+	if err != nil {
+		t.Fatalf("runMigrations() error = %v", err)
+	}
+	_, err = db.Exec(`INSERT INTO tasks (title, description, status, priority, created_at) VALUES
+		('Kept', '', 'new', 'low', '2026-01-01T00:00:00Z'),
+		('Deleted', '', 'new', 'low', '2026-01-01T00:00:00Z')`)
+	if err != nil {
+		t.Fatalf("insert tasks: %v", err)
+	}
+	if _, err := db.Exec(`DELETE FROM tasks WHERE id = 2`); err != nil {
+		t.Fatalf("delete task: %v", err)
+	}
+	db.Close()
+
+	repo, err := NewSQLiteRepository(path)
+	if err != nil {
+		t.Fatalf("NewSQLiteRepository() error = %v", err)
+	}
+	defer repo.Close()
+
+	task := &domain.Task{Title: "New", Status: domain.TaskStatusNew, Priority: domain.PriorityLow}
+	if err := repo.Create(context.Background(), task); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if task.ID != 3 {
+		t.Errorf("new task ID = %d, want 3 so that deleted task 2's ID is not reused", task.ID)
+	}
+}
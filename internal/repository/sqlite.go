@@ -3,17 +3,37 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"time"
 
 	"github.com/hitsumabushi845/task-management/internal/domain"
-	_ "modernc.org/sqlite"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// Connection pool limits for file databases. WAL lets readers share the
+// file with one writer; further writers wait up to the busy timeout.
+const (
+	maxOpenConns    = 4
+	connMaxIdleTime = 5 * time.Minute
 )
 
 // SQLiteRepository implements TaskRepository using SQLite
 type SQLiteRepository struct {
-	db *sql.DB
+	db   *sql.DB
+	q    querier // db, or the transaction of a repository passed to WithTx
+	tx   *sql.Tx
+	path string // Absolute path of the database file, empty for :memory:
+}
+
+// querier is the part of *sql.DB and *sql.Tx the repository uses
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
 }
 
 // NewSQLiteRepository creates a new SQLite repository
@@ -27,10 +47,18 @@ func NewSQLiteRepository(dbPath string) (*SQLiteRepository, error) {
 	}
 
 	// Open database
-	db, err := sql.Open("sqlite", dbPath)
+	db, err := sql.Open("sqlite", dataSourceName(dbPath))
 	if err != nil {
 		return nil, err
 	}
+	if dbPath == ":memory:" {
+		// Every connection to :memory: is a separate, empty database
+		db.SetMaxOpenConns(1)
+	} else {
+		db.SetMaxOpenConns(maxOpenConns)
+		db.SetMaxIdleConns(maxOpenConns)
+		db.SetConnMaxIdleTime(connMaxIdleTime)
+	}
 
 	// Run migrations
 	if err := runMigrations(db); err != nil {
@@ -38,26 +66,196 @@ func NewSQLiteRepository(dbPath string) (*SQLiteRepository, error) {
 		return nil, err
 	}
 
-	return &SQLiteRepository{db: db}, nil
+	repo := &SQLiteRepository{db: db, q: db}
+	if dbPath != ":memory:" {
+		if repo.path, err = filepath.Abs(dbPath); err != nil {
+			db.Close()
+			return nil, err
+		}
+	}
+	return repo, nil
 }
 
-// Close closes the database connection
+// dataSourceName adds the connection settings every connection needs to dbPath
+func dataSourceName(dbPath string) string {
+	// Every connection enforces tasks.category_id references
+	dsn := dbPath + "?_pragma=foreign_keys(1)"
+	if dbPath != ":memory:" {
+		// Wait for locks held by other connections, e.g. the webhook
+		// dispatcher or another process, instead of failing with
+		// SQLITE_BUSY, and let readers run alongside a writer
+		dsn += "&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_pragma=synchronous(NORMAL)"
+		// Take the write lock when a transaction begins, so that it waits
+		// for other writers rather than failing when it first writes
+		dsn += "&_txlock=immediate"
+	}
+	return dsn
+}
+
+// Close closes the database connection. It does nothing on the repository
+// passed to a WithTx function.
 func (r *SQLiteRepository) Close() error {
+	if r.tx != nil {
+		return nil
+	}
 	return r.db.Close()
 }
 
+// WithTx runs fn in a transaction, committing if it returns nil and rolling
+// back if it returns an error or panics. fn must make its changes through
+// the repository it is given. Calls nested in fn join the transaction.
+func (r *SQLiteRepository) WithTx(ctx context.Context, fn func(domain.TaskRepository) error) error {
+	if r.tx != nil {
+		return fn(r)
+	}
+
+	tx, before, err := r.begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(&SQLiteRepository{db: r.db, q: tx, tx: tx, path: r.path}); err != nil {
+		return err
+	}
+	return r.commit(ctx, tx, before)
+}
+
+// atomically runs fn in the repository's transaction, or in a transaction
+// of its own outside WithTx, for changes spanning several statements
+func (r *SQLiteRepository) atomically(ctx context.Context, fn func(q querier) error) error {
+	if r.tx != nil {
+		return fn(r.tx)
+	}
+
+	tx, before, err := r.begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return r.commit(ctx, tx, before)
+}
+
+// begin starts a transaction. While a ChangeWatcher watches the database,
+// it also returns the data_changes version the transaction starts from, or
+// -1 otherwise. Transactions take the write lock as they begin, so every
+// later version up to the commit is theirs.
+func (r *SQLiteRepository) begin(ctx context.Context) (*sql.Tx, int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !localChanges.watched(r.path) {
+		return tx, -1, nil
+	}
+	before, err := dataChangesVersion(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, 0, err
+	}
+	return tx, before, nil
+}
+
+// commit commits a transaction from begin, recording the changes it made
+// as this process's own for the ChangeWatcher
+func (r *SQLiteRepository) commit(ctx context.Context, tx *sql.Tx, before int64) error {
+	if before < 0 {
+		return tx.Commit()
+	}
+	after, err := dataChangesVersion(ctx, tx)
+	if err != nil {
+		return err
+	}
+	if after == before {
+		return tx.Commit()
+	}
+
+	// Recorded first, so that the watcher never sees the commit without it
+	localChanges.record(r.path, before, after)
+	if err := tx.Commit(); err != nil {
+		localChanges.forget(r.path, before)
+		return err
+	}
+	return nil
+}
+
 // Create creates a new task
 func (r *SQLiteRepository) Create(ctx context.Context, task *domain.Task) error {
 	if err := task.Validate(); err != nil {
 		return err
 	}
 
-	now := time.Now()
-	task.CreatedAt = now
+	task.CreatedAt = time.Now()
+	task.Notes = nil
+	return r.insert(ctx, task)
+}
+
+// Restore creates a task again with a new ID, keeping its timestamps and
+// notes
+func (r *SQLiteRepository) Restore(ctx context.Context, task *domain.Task) error {
+	if err := task.Validate(); err != nil {
+		return err
+	}
+	for i := range task.Notes {
+		if err := task.Notes[i].Validate(); err != nil {
+			return err
+		}
+	}
 
-	result, err := r.db.ExecContext(ctx,
-		`INSERT INTO tasks (title, description, status, priority, category_id, due_date, created_at, started_at, completed_at)
-		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+	if task.CreatedAt.IsZero() {
+		task.CreatedAt = time.Now()
+	}
+	return r.insert(ctx, task)
+}
+
+// insert stores a new task with its checklist, attachments and notes,
+// assigning its ID and first version
+func (r *SQLiteRepository) insert(ctx context.Context, task *domain.Task) error {
+	task.Version = 1
+	notes := append([]domain.Note(nil), task.Notes...)
+
+	var id int64
+	err := r.atomically(ctx, func(q querier) error {
+		var err error
+		id, err = insertTask(ctx, q, task)
+		if err != nil {
+			return err
+		}
+		if err := saveChecklist(ctx, q, id, task.Checklist); err != nil {
+			return err
+		}
+		if err := saveAttachments(ctx, q, id, task.Attachments); err != nil {
+			return err
+		}
+		for i := range notes {
+			notes[i].TaskID = id
+			if notes[i].CreatedAt.IsZero() {
+				notes[i].CreatedAt = time.Now()
+			}
+			if notes[i].ID, err = insertNote(ctx, q, &notes[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	task.ID = id
+	task.Notes = notes
+	return nil
+}
+
+// insertTask inserts the task row, returning its ID
+func insertTask(ctx context.Context, q querier, task *domain.Task) (int64, error) {
+	result, err := q.ExecContext(ctx,
+		`INSERT INTO tasks (title, description, status, priority, category_id, due_date, created_at, started_at, completed_at, remind_at, archived_at, version)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		task.Title,
 		task.Description,
 		task.Status,
@@ -67,31 +265,50 @@ func (r *SQLiteRepository) Create(ctx context.Context, task *domain.Task) error
 		task.CreatedAt.Format(time.RFC3339),
 		formatTimePtr(task.StartedAt),
 		formatTimePtr(task.CompletedAt),
+		formatTimePtr(task.RemindAt),
+		formatTimePtr(task.ArchivedAt),
+		task.Version,
 	)
-	if err != nil {
-		return err
+	if isForeignKeyViolation(err) {
+		return 0, missingCategory(task.CategoryID)
 	}
-
-	id, err := result.LastInsertId()
 	if err != nil {
-		return err
+		return 0, err
 	}
-
-	task.ID = id
-	return nil
+	return result.LastInsertId()
 }
 
-// Update updates an existing task
+// Update updates an existing task, returning domain.ErrNotFound if it does
+// not exist and domain.ErrConflict if its version has moved on
 func (r *SQLiteRepository) Update(ctx context.Context, task *domain.Task) error {
 	if err := task.Validate(); err != nil {
 		return err
 	}
 
-	_, err := r.db.ExecContext(ctx,
+	err := r.atomically(ctx, func(q querier) error {
+		if err := updateTask(ctx, q, task); err != nil {
+			return err
+		}
+		if err := saveChecklist(ctx, q, task.ID, task.Checklist); err != nil {
+			return err
+		}
+		return saveAttachments(ctx, q, task.ID, task.Attachments)
+	})
+	if err != nil {
+		return err
+	}
+	task.Version++
+	return nil
+}
+
+// updateTask updates the task row if its version matches
+func updateTask(ctx context.Context, q querier, task *domain.Task) error {
+	result, err := q.ExecContext(ctx,
 		`UPDATE tasks
 		 SET title = ?, description = ?, status = ?, priority = ?, category_id = ?,
-		     due_date = ?, started_at = ?, completed_at = ?
-		 WHERE id = ?`,
+		     due_date = ?, started_at = ?, completed_at = ?, remind_at = ?, archived_at = ?,
+		     version = version + 1
+		 WHERE id = ? AND version = ?`,
 		task.Title,
 		task.Description,
 		task.Status,
@@ -100,75 +317,320 @@ func (r *SQLiteRepository) Update(ctx context.Context, task *domain.Task) error
 		formatTimePtr(task.DueDate),
 		formatTimePtr(task.StartedAt),
 		formatTimePtr(task.CompletedAt),
+		formatTimePtr(task.RemindAt),
+		formatTimePtr(task.ArchivedAt),
 		task.ID,
+		task.Version,
 	)
-	return err
+	if isForeignKeyViolation(err) {
+		return missingCategory(task.CategoryID)
+	}
+	if err != nil {
+		return err
+	}
+
+	return checkVersionedWrite(ctx, q, result, task.ID)
 }
 
-// Delete deletes a task by ID
-func (r *SQLiteRepository) Delete(ctx context.Context, id int64) error {
-	_, err := r.db.ExecContext(ctx, "DELETE FROM tasks WHERE id = ?", id)
-	return err
+// checkVersionedWrite turns a write that matched no row because of its
+// version condition into domain.ErrNotFound or domain.ErrConflict
+func checkVersionedWrite(ctx context.Context, q querier, result sql.Result, id int64) error {
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		// Either the task is gone or its version no longer matches
+		var exists bool
+		if err := q.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM tasks WHERE id = ?)", id).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return taskNotFound(id)
+		}
+		return taskConflict(id)
+	}
+	return nil
 }
 
-// GetByID retrieves a task by ID
-func (r *SQLiteRepository) GetByID(ctx context.Context, id int64) (*domain.Task, error) {
-	task := &domain.Task{}
-	var createdAt, startedAt, completedAt, dueDate sql.NullString
-	var categoryID sql.NullInt64
+// ClearReminder clears the reminder of a task that has fired, returning
+// domain.ErrNotFound if the task does not exist and domain.ErrConflict if
+// its version has moved on or the reminder is already cleared
+func (r *SQLiteRepository) ClearReminder(ctx context.Context, task *domain.Task) error {
+	err := r.atomically(ctx, func(q querier) error {
+		result, err := q.ExecContext(ctx,
+			"UPDATE tasks SET remind_at = NULL, version = version + 1 WHERE id = ? AND version = ? AND remind_at IS NOT NULL",
+			task.ID, task.Version,
+		)
+		if err != nil {
+			return err
+		}
+		return checkVersionedWrite(ctx, q, result, task.ID)
+	})
+	if err != nil {
+		return err
+	}
+	task.RemindAt = nil
+	task.Version++
+	return nil
+}
 
-	err := r.db.QueryRowContext(ctx,
-		`SELECT id, title, description, status, priority, category_id, due_date, created_at, started_at, completed_at
-		 FROM tasks
-		 WHERE id = ?`,
-		id,
-	).Scan(
-		&task.ID,
-		&task.Title,
-		&task.Description,
-		&task.Status,
-		&task.Priority,
-		&categoryID,
-		&dueDate,
-		&createdAt,
-		&startedAt,
-		&completedAt,
+// saveChecklist replaces the checklist of a task
+func saveChecklist(ctx context.Context, q querier, taskID int64, items []domain.ChecklistItem) error {
+	if _, err := q.ExecContext(ctx, "DELETE FROM checklist_items WHERE task_id = ?", taskID); err != nil {
+		return err
+	}
+	for i, item := range items {
+		_, err := q.ExecContext(ctx,
+			"INSERT INTO checklist_items (task_id, position, text, done) VALUES (?, ?, ?, ?)",
+			taskID, i, item.Text, item.Done,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadChecklists fills in the checklists of tasks. With a single task only
+// its items are read.
+func (r *SQLiteRepository) loadChecklists(ctx context.Context, tasks []*domain.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	query := "SELECT task_id, text, done FROM checklist_items ORDER BY task_id, position"
+	var args []interface{}
+	if len(tasks) == 1 {
+		query = "SELECT task_id, text, done FROM checklist_items WHERE task_id = ? ORDER BY position"
+		args = append(args, tasks[0].ID)
+	}
+	rows, err := r.q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	byID := make(map[int64]*domain.Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+	for rows.Next() {
+		var taskID int64
+		var item domain.ChecklistItem
+		if err := rows.Scan(&taskID, &item.Text, &item.Done); err != nil {
+			return err
+		}
+		if task, ok := byID[taskID]; ok {
+			task.Checklist = append(task.Checklist, item)
+		}
+	}
+	return rows.Err()
+}
+
+// saveAttachments replaces the attachments of a task
+func saveAttachments(ctx context.Context, q querier, taskID int64, attachments []domain.Attachment) error {
+	if _, err := q.ExecContext(ctx, "DELETE FROM task_attachments WHERE task_id = ?", taskID); err != nil {
+		return err
+	}
+	for i, a := range attachments {
+		_, err := q.ExecContext(ctx,
+			"INSERT INTO task_attachments (task_id, position, location, added_at) VALUES (?, ?, ?, ?)",
+			taskID, i, a.Location, a.AddedAt.Format(time.RFC3339),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadAttachments fills in the attachments of tasks. With a single task
+// only its attachments are read.
+func (r *SQLiteRepository) loadAttachments(ctx context.Context, tasks []*domain.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	query := "SELECT id, task_id, location, added_at FROM task_attachments ORDER BY task_id, position"
+	var args []interface{}
+	if len(tasks) == 1 {
+		query = "SELECT id, task_id, location, added_at FROM task_attachments WHERE task_id = ? ORDER BY position"
+		args = append(args, tasks[0].ID)
+	}
+	rows, err := r.q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	byID := make(map[int64]*domain.Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+	for rows.Next() {
+		var id, taskID int64
+		var a domain.Attachment
+		var addedAt string
+		if err := rows.Scan(&id, &taskID, &a.Location, &addedAt); err != nil {
+			return err
+		}
+		d := rowDecoder{table: "task_attachments", id: id}
+		a.AddedAt = d.time("added_at", addedAt)
+		if d.err != nil {
+			return d.err
+		}
+		if task, ok := byID[taskID]; ok {
+			task.Attachments = append(task.Attachments, a)
+		}
+	}
+	return rows.Err()
+}
+
+// AddNote appends a note to a task, returning domain.ErrNotFound if the
+// task does not exist
+func (r *SQLiteRepository) AddNote(ctx context.Context, note *domain.Note) error {
+	if err := note.Validate(); err != nil {
+		return err
+	}
+
+	stored := *note
+	stored.CreatedAt = time.Now()
+	err := r.atomically(ctx, func(q querier) error {
+		var err error
+		stored.ID, err = insertNote(ctx, q, &stored)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	*note = stored
+	return nil
+}
+
+// insertNote inserts the note row with its CreatedAt, returning its ID
+func insertNote(ctx context.Context, q querier, note *domain.Note) (int64, error) {
+	result, err := q.ExecContext(ctx,
+		"INSERT INTO task_notes (task_id, text, created_at) VALUES (?, ?, ?)",
+		note.TaskID,
+		note.Text,
+		note.CreatedAt.Format(time.RFC3339),
 	)
+	if isForeignKeyViolation(err) {
+		return 0, taskNotFound(note.TaskID)
+	}
 	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// ListNotes retrieves the notes of a task, oldest first, returning
+// domain.ErrNotFound if the task does not exist
+func (r *SQLiteRepository) ListNotes(ctx context.Context, taskID int64) ([]*domain.Note, error) {
+	var exists bool
+	if err := r.q.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM tasks WHERE id = ?)", taskID).Scan(&exists); err != nil {
 		return nil, err
 	}
+	if !exists {
+		return nil, taskNotFound(taskID)
+	}
 
-	// Parse timestamps
-	if createdAt.Valid {
-		t, _ := time.Parse(time.RFC3339, createdAt.String)
-		task.CreatedAt = t
+	task := &domain.Task{ID: taskID}
+	if err := r.loadNotes(ctx, []*domain.Task{task}); err != nil {
+		return nil, err
 	}
-	if startedAt.Valid {
-		t, _ := time.Parse(time.RFC3339, startedAt.String)
-		task.StartedAt = &t
+	notes := make([]*domain.Note, 0, len(task.Notes))
+	for i := range task.Notes {
+		notes = append(notes, &task.Notes[i])
 	}
-	if completedAt.Valid {
-		t, _ := time.Parse(time.RFC3339, completedAt.String)
-		task.CompletedAt = &t
+	return notes, nil
+}
+
+// loadNotes fills in the notes of tasks. With a single task only its notes
+// are read.
+func (r *SQLiteRepository) loadNotes(ctx context.Context, tasks []*domain.Task) error {
+	if len(tasks) == 0 {
+		return nil
 	}
-	if dueDate.Valid {
-		t, _ := time.Parse(time.RFC3339, dueDate.String)
-		task.DueDate = &t
+	query := "SELECT id, task_id, text, created_at FROM task_notes ORDER BY id"
+	var args []interface{}
+	if len(tasks) == 1 {
+		query = "SELECT id, task_id, text, created_at FROM task_notes WHERE task_id = ? ORDER BY id"
+		args = append(args, tasks[0].ID)
 	}
-	if categoryID.Valid {
-		id := categoryID.Int64
-		task.CategoryID = &id
+	rows, err := r.q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
 	}
+	defer rows.Close()
 
+	byID := make(map[int64]*domain.Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+	for rows.Next() {
+		var note domain.Note
+		var createdAt string
+		if err := rows.Scan(&note.ID, &note.TaskID, &note.Text, &createdAt); err != nil {
+			return err
+		}
+		d := rowDecoder{table: "task_notes", id: note.ID}
+		note.CreatedAt = d.time("created_at", createdAt)
+		if d.err != nil {
+			return d.err
+		}
+		if task, ok := byID[note.TaskID]; ok {
+			task.Notes = append(task.Notes, note)
+		}
+	}
+	return rows.Err()
+}
+
+// Delete deletes a task by ID, returning domain.ErrNotFound if it does not
+// exist
+func (r *SQLiteRepository) Delete(ctx context.Context, id int64) error {
+	return r.atomically(ctx, func(q querier) error {
+		result, err := q.ExecContext(ctx, "DELETE FROM tasks WHERE id = ?", id)
+		if err != nil {
+			return err
+		}
+		return requireAffected(result, id)
+	})
+}
+
+// GetByID retrieves a task by ID, returning domain.ErrNotFound if it does
+// not exist
+func (r *SQLiteRepository) GetByID(ctx context.Context, id int64) (*domain.Task, error) {
+	row := r.q.QueryRowContext(ctx,
+		`SELECT `+taskColumns+`
+		 FROM tasks
+		 WHERE id = ?`,
+		id,
+	)
+	task, err := scanTask(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, taskNotFound(id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadChecklists(ctx, []*domain.Task{task}); err != nil {
+		return nil, err
+	}
+	if err := r.loadAttachments(ctx, []*domain.Task{task}); err != nil {
+		return nil, err
+	}
+	if err := r.loadNotes(ctx, []*domain.Task{task}); err != nil {
+		return nil, err
+	}
 	return task, nil
 }
 
 // List retrieves all tasks
 func (r *SQLiteRepository) List(ctx context.Context) ([]*domain.Task, error) {
-	rows, err := r.db.QueryContext(ctx,
-		`SELECT id, title, description, status, priority, category_id, due_date, created_at, started_at, completed_at
+	rows, err := r.q.QueryContext(ctx,
+		`SELECT `+taskColumns+`
 		 FROM tasks
-		 ORDER BY created_at DESC`,
+		 ORDER BY created_at DESC, id DESC`,
 	)
 	if err != nil {
 		return nil, err
@@ -177,59 +639,32 @@ func (r *SQLiteRepository) List(ctx context.Context) ([]*domain.Task, error) {
 
 	var tasks []*domain.Task
 	for rows.Next() {
-		task := &domain.Task{}
-		var createdAt, startedAt, completedAt, dueDate sql.NullString
-		var categoryID sql.NullInt64
-
-		err := rows.Scan(
-			&task.ID,
-			&task.Title,
-			&task.Description,
-			&task.Status,
-			&task.Priority,
-			&categoryID,
-			&dueDate,
-			&createdAt,
-			&startedAt,
-			&completedAt,
-		)
+		task, err := scanTask(rows)
 		if err != nil {
 			return nil, err
 		}
-
-		// Parse timestamps
-		if createdAt.Valid {
-			t, _ := time.Parse(time.RFC3339, createdAt.String)
-			task.CreatedAt = t
-		}
-		if startedAt.Valid {
-			t, _ := time.Parse(time.RFC3339, startedAt.String)
-			task.StartedAt = &t
-		}
-		if completedAt.Valid {
-			t, _ := time.Parse(time.RFC3339, completedAt.String)
-			task.CompletedAt = &t
-		}
-		if dueDate.Valid {
-			t, _ := time.Parse(time.RFC3339, dueDate.String)
-			task.DueDate = &t
-		}
-		if categoryID.Valid {
-			id := categoryID.Int64
-			task.CategoryID = &id
-		}
-
 		tasks = append(tasks, task)
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
+	rows.Close()
 
+	if err := r.loadChecklists(ctx, tasks); err != nil {
+		return nil, err
+	}
+	if err := r.loadAttachments(ctx, tasks); err != nil {
+		return nil, err
+	}
+	if err := r.loadNotes(ctx, tasks); err != nil {
+		return nil, err
+	}
 	return tasks, nil
 }
 
-// CreateCategory creates a new category
+// CreateCategory creates a new category, returning
+// domain.ErrDuplicateCategory if the name is taken
 func (r *SQLiteRepository) CreateCategory(ctx context.Context, category *domain.Category) error {
 	if err := category.Validate(); err != nil {
 		return err
@@ -238,17 +673,23 @@ func (r *SQLiteRepository) CreateCategory(ctx context.Context, category *domain.
 	now := time.Now()
 	category.CreatedAt = now
 
-	result, err := r.db.ExecContext(ctx,
-		"INSERT INTO categories (name, color, created_at) VALUES (?, ?, ?)",
-		category.Name,
-		category.Color,
-		category.CreatedAt.Format(time.RFC3339),
-	)
-	if err != nil {
+	var id int64
+	err := r.atomically(ctx, func(q querier) error {
+		result, err := q.ExecContext(ctx,
+			"INSERT INTO categories (name, color, created_at) VALUES (?, ?, ?)",
+			category.Name,
+			category.Color,
+			category.CreatedAt.Format(time.RFC3339),
+		)
+		if isUniqueViolation(err) {
+			return duplicateCategory(category.Name)
+		}
+		if err != nil {
+			return err
+		}
+		id, err = result.LastInsertId()
 		return err
-	}
-
-	id, err := result.LastInsertId()
+	})
 	if err != nil {
 		return err
 	}
@@ -259,7 +700,7 @@ func (r *SQLiteRepository) CreateCategory(ctx context.Context, category *domain.
 
 // GetCategories retrieves all categories
 func (r *SQLiteRepository) GetCategories(ctx context.Context) ([]*domain.Category, error) {
-	rows, err := r.db.QueryContext(ctx,
+	rows, err := r.q.QueryContext(ctx,
 		"SELECT id, name, color, created_at FROM categories ORDER BY name",
 	)
 	if err != nil {
@@ -283,8 +724,11 @@ func (r *SQLiteRepository) GetCategories(ctx context.Context) ([]*domain.Categor
 		}
 
 		// Parse timestamp
-		t, _ := time.Parse(time.RFC3339, createdAt)
-		category.CreatedAt = t
+		d := rowDecoder{table: "categories", id: category.ID}
+		category.CreatedAt = d.time("created_at", createdAt)
+		if d.err != nil {
+			return nil, d.err
+		}
 
 		categories = append(categories, category)
 	}
@@ -296,6 +740,128 @@ func (r *SQLiteRepository) GetCategories(ctx context.Context) ([]*domain.Categor
 	return categories, nil
 }
 
+// requireAffected returns domain.ErrNotFound if result changed no rows
+func requireAffected(result sql.Result, id int64) error {
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return taskNotFound(id)
+	}
+	return nil
+}
+
+func taskNotFound(id int64) error {
+	return fmt.Errorf("task %d: %w", id, domain.ErrNotFound)
+}
+
+func taskConflict(id int64) error {
+	return fmt.Errorf("task %d: %w", id, domain.ErrConflict)
+}
+
+func duplicateCategory(name string) error {
+	return fmt.Errorf("%w: %q", domain.ErrDuplicateCategory, name)
+}
+
+// missingCategory reports a task referring to a category that does not exist
+func missingCategory(id *int64) error {
+	msg := "category does not exist"
+	if id != nil {
+		msg = fmt.Sprintf("category %d does not exist", *id)
+	}
+	return &domain.ValidationError{Field: "category_id", Message: msg}
+}
+
+// isUniqueViolation reports whether err is a UNIQUE constraint failure
+func isUniqueViolation(err error) bool {
+	return hasCode(err, sqlite3.SQLITE_CONSTRAINT_UNIQUE)
+}
+
+// isForeignKeyViolation reports whether err is a FOREIGN KEY constraint
+// failure. tasks.category_id is the only foreign key written by tasks.
+func isForeignKeyViolation(err error) bool {
+	return hasCode(err, sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY)
+}
+
+func hasCode(err error, code int) bool {
+	var sqliteErr *sqlite.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.Code() == code
+}
+
+// DeleteCategory deletes a category by ID, returning domain.ErrNotFound if
+// it does not exist. The foreign key clears the category of its tasks.
+func (r *SQLiteRepository) DeleteCategory(ctx context.Context, id int64) error {
+	return r.atomically(ctx, func(q querier) error {
+		result, err := q.ExecContext(ctx, "DELETE FROM categories WHERE id = ?", id)
+		if err != nil {
+			return err
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return fmt.Errorf("category %d: %w", id, domain.ErrNotFound)
+		}
+		return nil
+	})
+}
+
+// taskColumns is the column list read by scanTask
+const taskColumns = `id, title, description, status, priority, category_id, due_date, created_at, started_at, completed_at, remind_at, archived_at, version`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanTask reads a task selected with taskColumns
+func scanTask(row rowScanner) (*domain.Task, error) {
+	task := &domain.Task{}
+	var createdAt, startedAt, completedAt, dueDate, remindAt, archivedAt sql.NullString
+	var categoryID sql.NullInt64
+
+	err := row.Scan(
+		&task.ID,
+		&task.Title,
+		&task.Description,
+		&task.Status,
+		&task.Priority,
+		&categoryID,
+		&dueDate,
+		&createdAt,
+		&startedAt,
+		&completedAt,
+		&remindAt,
+		&archivedAt,
+		&task.Version,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse timestamps
+	d := rowDecoder{table: "tasks", id: task.ID}
+	if createdAt.Valid {
+		task.CreatedAt = d.time("created_at", createdAt.String)
+	}
+	task.StartedAt = d.timePtr("started_at", startedAt)
+	task.CompletedAt = d.timePtr("completed_at", completedAt)
+	task.DueDate = d.timePtr("due_date", dueDate)
+	task.RemindAt = d.timePtr("remind_at", remindAt)
+	task.ArchivedAt = d.timePtr("archived_at", archivedAt)
+	if d.err != nil {
+		return nil, d.err
+	}
+	if categoryID.Valid {
+		id := categoryID.Int64
+		task.CategoryID = &id
+	}
+
+	return task, nil
+}
+
 // Helper function to format *time.Time for SQL
 func formatTimePtr(t *time.Time) interface{} {
 	if t == nil {
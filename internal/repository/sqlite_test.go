@@ -2,8 +2,10 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 	"time"
 
@@ -167,13 +169,18 @@ func TestSQLiteRepository_Delete(t *testing.T) {
 
 	// Create a task
 	task := &domain.Task{
-		Title:    "To be deleted",
-		Status:   domain.TaskStatusNew,
-		Priority: domain.PriorityLow,
+		Title:       "To be deleted",
+		Status:      domain.TaskStatusNew,
+		Priority:    domain.PriorityLow,
+		Checklist:   []domain.ChecklistItem{{Text: "Step"}},
+		Attachments: []domain.Attachment{{Location: "https://example.com", AddedAt: time.Now()}},
 	}
 	if err := repo.Create(ctx, task); err != nil {
 		t.Fatalf("Create() error = %v", err)
 	}
+	if err := repo.AddNote(ctx, &domain.Note{TaskID: task.ID, Text: "Note"}); err != nil {
+		t.Fatalf("AddNote() error = %v", err)
+	}
 
 	// Delete the task
 	if err := repo.Delete(ctx, task.ID); err != nil {
@@ -185,6 +192,27 @@ func TestSQLiteRepository_Delete(t *testing.T) {
 	if err == nil {
 		t.Errorf("GetByID() after Delete should return error, got nil")
 	}
+
+	// Its checklist, attachments and notes go with it
+	var items, attachments, notes int
+	if err := repo.db.QueryRow("SELECT COUNT(*) FROM checklist_items").Scan(&items); err != nil {
+		t.Fatalf("count checklist items: %v", err)
+	}
+	if items != 0 {
+		t.Errorf("%d checklist items left after Delete, want 0", items)
+	}
+	if err := repo.db.QueryRow("SELECT COUNT(*) FROM task_attachments").Scan(&attachments); err != nil {
+		t.Fatalf("count attachments: %v", err)
+	}
+	if attachments != 0 {
+		t.Errorf("%d attachments left after Delete, want 0", attachments)
+	}
+	if err := repo.db.QueryRow("SELECT COUNT(*) FROM task_notes").Scan(&notes); err != nil {
+		t.Fatalf("count notes: %v", err)
+	}
+	if notes != 0 {
+		t.Errorf("%d notes left after Delete, want 0", notes)
+	}
 }
 
 func TestSQLiteRepository_CreateCategory(t *testing.T) {
@@ -252,3 +280,158 @@ func TestSQLiteRepository_GetCategories(t *testing.T) {
 		t.Errorf("GetCategories() after create returned %d categories, want 4", len(categories))
 	}
 }
+
+func TestSQLiteRepository_RemindAt(t *testing.T) {
+	repo, err := NewSQLiteRepository(":memory:")
+	if err != nil {
+		t.Fatalf("NewSQLiteRepository() error = %v", err)
+	}
+	defer repo.Close()
+
+	ctx := context.Background()
+	remindAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	task := &domain.Task{
+		Title:    "Call back",
+		Status:   domain.TaskStatusNew,
+		Priority: domain.PriorityMedium,
+		RemindAt: &remindAt,
+	}
+	if err := repo.Create(ctx, task); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.RemindAt == nil || !got.RemindAt.Equal(remindAt) {
+		t.Errorf("RemindAt = %v, want %v", got.RemindAt, remindAt)
+	}
+
+	// Clearing the reminder persists as NULL
+	got.RemindAt = nil
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	got, err = repo.GetByID(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.RemindAt != nil {
+		t.Errorf("RemindAt = %v, want nil", got.RemindAt)
+	}
+}
+
+func TestSQLiteRepository_WithTx(t *testing.T) {
+	errAbort := errors.New("abort")
+	tests := []struct {
+		name       string
+		fn         func(tx domain.TaskRepository, existing *domain.Task) error
+		wantErr    error
+		wantPanic  bool
+		wantTitles []string // Stored titles afterwards, newest first
+	}{
+		{
+			name: "commit",
+			fn: func(tx domain.TaskRepository, existing *domain.Task) error {
+				if err := tx.Create(context.Background(), newConformTask("Added")); err != nil {
+					return err
+				}
+				existing.Title = "Renamed"
+				return tx.Update(context.Background(), existing)
+			},
+			wantTitles: []string{"Added", "Renamed"},
+		},
+		{
+			name: "error rolls back",
+			fn: func(tx domain.TaskRepository, existing *domain.Task) error {
+				if err := tx.Create(context.Background(), newConformTask("Added")); err != nil {
+					return err
+				}
+				if err := tx.Delete(context.Background(), existing.ID); err != nil {
+					return err
+				}
+				return errAbort
+			},
+			wantErr:    errAbort,
+			wantTitles: []string{"Existing"},
+		},
+		{
+			name: "failed change rolls back earlier ones",
+			fn: func(tx domain.TaskRepository, existing *domain.Task) error {
+				if err := tx.Create(context.Background(), newConformTask("Added")); err != nil {
+					return err
+				}
+				return tx.Delete(context.Background(), existing.ID+100)
+			},
+			wantErr:    domain.ErrNotFound,
+			wantTitles: []string{"Existing"},
+		},
+		{
+			name: "panic rolls back",
+			fn: func(tx domain.TaskRepository, existing *domain.Task) error {
+				if err := tx.Create(context.Background(), newConformTask("Added")); err != nil {
+					return err
+				}
+				panic("boom")
+			},
+			wantPanic:  true,
+			wantTitles: []string{"Existing"},
+		},
+		{
+			name: "nested calls join",
+			fn: func(tx domain.TaskRepository, existing *domain.Task) error {
+				if err := tx.WithTx(context.Background(), func(inner domain.TaskRepository) error {
+					return inner.Create(context.Background(), newConformTask("Inner"))
+				}); err != nil {
+					return err
+				}
+				return errAbort
+			},
+			wantErr:    errAbort,
+			wantTitles: []string{"Existing"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, err := NewSQLiteRepository(filepath.Join(t.TempDir(), "tasks.db"))
+			if err != nil {
+				t.Fatalf("NewSQLiteRepository() error = %v", err)
+			}
+			defer repo.Close()
+			ctx := context.Background()
+
+			existing := newConformTask("Existing")
+			if err := repo.Create(ctx, existing); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+
+			func() {
+				defer func() {
+					if p := recover(); (p != nil) != tt.wantPanic {
+						t.Errorf("WithTx() panic = %v, want panic %v", p, tt.wantPanic)
+					}
+				}()
+				err = repo.WithTx(ctx, func(tx domain.TaskRepository) error {
+					return tt.fn(tx, existing)
+				})
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("WithTx() error = %v, want %v", err, tt.wantErr)
+				}
+			}()
+
+			tasks, err := repo.List(ctx)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			var titles []string
+			for _, task := range tasks {
+				titles = append(titles, task.Title)
+			}
+			if !reflect.DeepEqual(titles, tt.wantTitles) {
+				t.Errorf("titles = %v, want %v", titles, tt.wantTitles)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"sync"
+)
+
+// ChangeWatcher detects changes to task data committed to a SQLite
+// database by other processes, such as the CLI or a second TUI. It polls
+// the data_changes counter, which triggers bump on every write to task
+// data but not on webhook queue writes. Commits made through any
+// SQLiteRepository of this process are its own and not reported.
+type ChangeWatcher struct {
+	db   *sql.DB
+	path string
+
+	mu   sync.Mutex
+	last int64
+}
+
+// NewChangeWatcher opens a connection of its own to the database at dbPath,
+// which must already exist
+func NewChangeWatcher(dbPath string) (*ChangeWatcher, error) {
+	path, err := filepath.Abs(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("sqlite", dataSourceName(dbPath))
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+
+	w := &ChangeWatcher{db: db, path: path}
+	if w.last, err = dataChangesVersion(context.Background(), db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	localChanges.watch(path)
+	return w, nil
+}
+
+// Changed reports whether another process changed the data since the
+// watcher was opened or Changed last returned true
+func (w *ChangeWatcher) Changed(ctx context.Context) (bool, error) {
+	version, err := dataChangesVersion(ctx, w.db)
+	if err != nil {
+		return false, err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if version == w.last {
+		return false, nil
+	}
+	own := localChanges.covers(w.path, w.last, version)
+	w.last = version
+	return !own, nil
+}
+
+// Close closes the watcher's connection
+func (w *ChangeWatcher) Close() error {
+	localChanges.unwatch(w.path)
+	return w.db.Close()
+}
+
+// dataChangesVersion reads the data_changes counter
+func dataChangesVersion(ctx context.Context, q querier) (int64, error) {
+	var version int64
+	err := q.QueryRowContext(ctx, "SELECT version FROM data_changes").Scan(&version)
+	return version, err
+}
+
+// localChanges holds the data_changes versions committed by this process
+// to each watched database
+var localChanges = changeLog{
+	watchers: map[string]int{},
+	commits:  map[string]map[int64]int64{},
+}
+
+// changeLog records, per database file, the versions each commit moved
+// data_changes through. Only files with a ChangeWatcher are recorded.
+type changeLog struct {
+	mu       sync.Mutex
+	watchers map[string]int
+	commits  map[string]map[int64]int64 // The version after each commit, by the version before
+}
+
+func (l *changeLog) watch(path string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.watchers[path]++
+}
+
+func (l *changeLog) unwatch(path string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.watchers[path]--; l.watchers[path] <= 0 {
+		delete(l.watchers, path)
+		delete(l.commits, path)
+	}
+}
+
+// watched reports whether a ChangeWatcher watches the file at path
+func (l *changeLog) watched(path string) bool {
+	if path == "" {
+		return false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.watchers[path] > 0
+}
+
+// record notes a commit moving the version from before to after
+func (l *changeLog) record(path string, before, after int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.commits[path] == nil {
+		l.commits[path] = map[int64]int64{}
+	}
+	l.commits[path][before] = after
+}
+
+// forget drops a commit recorded before it failed
+func (l *changeLog) forget(path string, before int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.commits[path], before)
+}
+
+// covers reports whether this process's commits account for every change
+// from version from to version to, and forgets the commits before to
+func (l *changeLog) covers(path string, from, to int64) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	commits := l.commits[path]
+	version := from
+	for version < to {
+		after, ok := commits[version]
+		if !ok {
+			break
+		}
+		version = after
+	}
+	for before := range commits {
+		if before < to {
+			delete(commits, before)
+		}
+	}
+	return version == to
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/hitsumabushi845/task-management/internal/domain"
+)
+
+func TestChangeWatcher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.db")
+	repo, err := NewSQLiteRepository(path)
+	if err != nil {
+		t.Fatalf("NewSQLiteRepository() error = %v", err)
+	}
+	defer repo.Close()
+	ctx := context.Background()
+
+	watcher, err := NewChangeWatcher(path)
+	if err != nil {
+		t.Fatalf("NewChangeWatcher() error = %v", err)
+	}
+	defer watcher.Close()
+
+	// other stands in for another process, writing without this
+	// process's repository
+	other, err := sql.Open("sqlite", dataSourceName(path))
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer other.Close()
+
+	if changed, err := watcher.Changed(ctx); err != nil || changed {
+		t.Errorf("Changed() before any write = %v, %v; want false", changed, err)
+	}
+
+	// This process's own changes are not reported
+	task := &domain.Task{Title: "Task", Status: domain.TaskStatusNew, Priority: domain.PriorityLow}
+	if err := repo.Create(ctx, task); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	err = repo.WithTx(ctx, func(tx domain.TaskRepository) error {
+		task.Title = "Renamed"
+		return tx.Update(ctx, task)
+	})
+	if err != nil {
+		t.Fatalf("WithTx() error = %v", err)
+	}
+	if changed, err := watcher.Changed(ctx); err != nil || changed {
+		t.Errorf("Changed() after own writes = %v, %v; want false", changed, err)
+	}
+
+	// Nor are reads or webhook queue writes
+	if _, err := repo.List(ctx); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if err := repo.EnqueueWebhook(ctx, &WebhookDelivery{URL: "http://example.invalid", Event: "task.created", Payload: []byte(`{}`)}); err != nil {
+		t.Fatalf("EnqueueWebhook() error = %v", err)
+	}
+	if changed, err := watcher.Changed(ctx); err != nil || changed {
+		t.Errorf("Changed() after List() and EnqueueWebhook() = %v, %v; want false", changed, err)
+	}
+
+	// Another process's change is, even alongside own changes
+	if _, err := other.Exec("UPDATE tasks SET title = 'Elsewhere' WHERE id = ?", task.ID); err != nil {
+		t.Fatalf("update from another connection: %v", err)
+	}
+	if err := repo.Delete(ctx, task.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if changed, err := watcher.Changed(ctx); err != nil || !changed {
+		t.Errorf("Changed() after another process's write = %v, %v; want true", changed, err)
+	}
+	if changed, err := watcher.Changed(ctx); err != nil || changed {
+		t.Errorf("Changed() again = %v, %v; want false until the next write", changed, err)
+	}
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// WebhookDelivery is a webhook request waiting in, or retired from, the
+// queue. Queue timestamps are stored in UTC so that next_attempt_at compares
+// correctly as text.
+type WebhookDelivery struct {
+	ID            int64
+	URL           string
+	Event         string
+	Payload       []byte
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	CreatedAt     time.Time
+	DeliveredAt   *time.Time
+	FailedAt      *time.Time // Set once retries are exhausted
+}
+
+// EnqueueWebhook adds a delivery to the queue, due immediately unless
+// NextAttemptAt is set. Within WithTx it is part of the transaction.
+func (r *SQLiteRepository) EnqueueWebhook(ctx context.Context, d *WebhookDelivery) error {
+	d.CreatedAt = time.Now()
+	if d.NextAttemptAt.IsZero() {
+		d.NextAttemptAt = d.CreatedAt
+	}
+
+	result, err := r.q.ExecContext(ctx,
+		`INSERT INTO webhook_deliveries (url, event, payload, next_attempt_at, created_at)
+		 VALUES (?, ?, ?, ?, ?)`,
+		d.URL,
+		d.Event,
+		d.Payload,
+		d.NextAttemptAt.UTC().Format(time.RFC3339),
+		d.CreatedAt.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+	d.ID = id
+	return nil
+}
+
+// PendingWebhooks returns up to limit undelivered deliveries due at now,
+// oldest first
+func (r *SQLiteRepository) PendingWebhooks(ctx context.Context, now time.Time, limit int) ([]*WebhookDelivery, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, url, event, payload, attempts, next_attempt_at, last_error, created_at
+		 FROM webhook_deliveries
+		 WHERE delivered_at IS NULL AND failed_at IS NULL AND next_attempt_at <= ?
+		 ORDER BY id
+		 LIMIT ?`,
+		now.UTC().Format(time.RFC3339),
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*WebhookDelivery
+	for rows.Next() {
+		d := &WebhookDelivery{}
+		var nextAttemptAt, createdAt string
+		if err := rows.Scan(&d.ID, &d.URL, &d.Event, &d.Payload, &d.Attempts, &nextAttemptAt, &d.LastError, &createdAt); err != nil {
+			return nil, err
+		}
+		dec := rowDecoder{table: "webhook_deliveries", id: d.ID}
+		d.NextAttemptAt = dec.time("next_attempt_at", nextAttemptAt)
+		d.CreatedAt = dec.time("created_at", createdAt)
+		if dec.err != nil {
+			return nil, dec.err
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// MarkWebhookDelivered retires a delivery after a successful attempt
+func (r *SQLiteRepository) MarkWebhookDelivered(ctx context.Context, id int64, attempts int, at time.Time) error {
+	_, err := r.db.ExecContext(ctx,
+		"UPDATE webhook_deliveries SET attempts = ?, delivered_at = ?, last_error = '' WHERE id = ?",
+		attempts, at.UTC().Format(time.RFC3339), id,
+	)
+	return err
+}
+
+// MarkWebhookFailed records a failed attempt. The delivery is retried at
+// next, or given up on when next is nil.
+func (r *SQLiteRepository) MarkWebhookFailed(ctx context.Context, id int64, attempts int, lastError string, next *time.Time) error {
+	var failedAt interface{}
+	nextAttempt := time.Now()
+	if next == nil {
+		failedAt = nextAttempt.UTC().Format(time.RFC3339)
+	} else {
+		nextAttempt = *next
+	}
+
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE webhook_deliveries
+		 SET attempts = ?, last_error = ?, next_attempt_at = ?, failed_at = ?
+		 WHERE id = ?`,
+		attempts, lastError, nextAttempt.UTC().Format(time.RFC3339), failedAt, id,
+	)
+	return err
+}
+
+// PruneWebhooks deletes the deliveries retired, delivered or given up on,
+// before the given time and returns how many were deleted
+func (r *SQLiteRepository) PruneWebhooks(ctx context.Context, before time.Time) (int64, error) {
+	cutoff := before.UTC().Format(time.RFC3339)
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM webhook_deliveries
+		 WHERE delivered_at < ? OR failed_at < ?`,
+		cutoff, cutoff,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// WebhookDeliveryByID reads one delivery, including retired ones
+func (r *SQLiteRepository) WebhookDeliveryByID(ctx context.Context, id int64) (*WebhookDelivery, error) {
+	d := &WebhookDelivery{}
+	var nextAttemptAt, createdAt string
+	var deliveredAt, failedAt sql.NullString
+	err := r.db.QueryRowContext(ctx,
+		`SELECT id, url, event, payload, attempts, next_attempt_at, last_error, created_at, delivered_at, failed_at
+		 FROM webhook_deliveries
+		 WHERE id = ?`,
+		id,
+	).Scan(&d.ID, &d.URL, &d.Event, &d.Payload, &d.Attempts, &nextAttemptAt, &d.LastError, &createdAt, &deliveredAt, &failedAt)
+	if err != nil {
+		return nil, err
+	}
+	dec := rowDecoder{table: "webhook_deliveries", id: d.ID}
+	d.NextAttemptAt = dec.time("next_attempt_at", nextAttemptAt)
+	d.CreatedAt = dec.time("created_at", createdAt)
+	d.DeliveredAt = dec.timePtr("delivered_at", deliveredAt)
+	d.FailedAt = dec.timePtr("failed_at", failedAt)
+	if dec.err != nil {
+		return nil, dec.err
+	}
+	return d, nil
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSQLiteRepository_WebhookQueue(t *testing.T) {
+	repo, err := NewSQLiteRepository(":memory:")
+	if err != nil {
+		t.Fatalf("NewSQLiteRepository() error = %v", err)
+	}
+	defer repo.Close()
+
+	ctx := context.Background()
+	now := time.Now()
+
+	first := &WebhookDelivery{URL: "http://example.test/a", Event: "task.created", Payload: []byte(`{"n":1}`)}
+	second := &WebhookDelivery{URL: "http://example.test/b", Event: "task.deleted", Payload: []byte(`{"n":2}`)}
+	later := &WebhookDelivery{URL: "http://example.test/c", Event: "task.updated", Payload: []byte(`{"n":3}`), NextAttemptAt: now.Add(time.Hour)}
+	for _, d := range []*WebhookDelivery{first, second, later} {
+		if err := repo.EnqueueWebhook(ctx, d); err != nil {
+			t.Fatalf("EnqueueWebhook() error = %v", err)
+		}
+		if d.ID == 0 {
+			t.Errorf("EnqueueWebhook() did not set ID")
+		}
+	}
+
+	pending, err := repo.PendingWebhooks(ctx, now, 10)
+	if err != nil {
+		t.Fatalf("PendingWebhooks() error = %v", err)
+	}
+	if len(pending) != 2 || pending[0].ID != first.ID || pending[1].ID != second.ID {
+		t.Fatalf("PendingWebhooks() = %+v, want the two due deliveries in order", pending)
+	}
+	if string(pending[0].Payload) != `{"n":1}` || pending[0].URL != first.URL || pending[0].Event != first.Event {
+		t.Errorf("pending[0] = %+v, want %+v", pending[0], first)
+	}
+
+	// Delivered and failed entries leave the queue; rescheduled ones wait
+	if err := repo.MarkWebhookDelivered(ctx, first.ID, 1, now); err != nil {
+		t.Fatalf("MarkWebhookDelivered() error = %v", err)
+	}
+	retry := now.Add(time.Minute)
+	if err := repo.MarkWebhookFailed(ctx, second.ID, 1, "connection refused", &retry); err != nil {
+		t.Fatalf("MarkWebhookFailed() error = %v", err)
+	}
+	if pending, _ := repo.PendingWebhooks(ctx, now, 10); len(pending) != 0 {
+		t.Errorf("PendingWebhooks() = %d entries, want 0", len(pending))
+	}
+	pending, _ = repo.PendingWebhooks(ctx, retry, 10)
+	if len(pending) != 1 || pending[0].ID != second.ID || pending[0].Attempts != 1 || pending[0].LastError != "connection refused" {
+		t.Errorf("PendingWebhooks() after retry time = %+v", pending)
+	}
+
+	if err := repo.MarkWebhookFailed(ctx, second.ID, 2, "connection refused", nil); err != nil {
+		t.Fatalf("MarkWebhookFailed() error = %v", err)
+	}
+	if pending, _ := repo.PendingWebhooks(ctx, now.Add(24*time.Hour), 10); len(pending) != 1 || pending[0].ID != later.ID {
+		t.Errorf("PendingWebhooks() = %+v, want only the later delivery", pending)
+	}
+
+	got, err := repo.WebhookDeliveryByID(ctx, second.ID)
+	if err != nil {
+		t.Fatalf("WebhookDeliveryByID() error = %v", err)
+	}
+	if got.FailedAt == nil || got.DeliveredAt != nil {
+		t.Errorf("given-up delivery = failed %v, delivered %v", got.FailedAt, got.DeliveredAt)
+	}
+}
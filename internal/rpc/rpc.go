@@ -0,0 +1,256 @@
+// Package rpc serves a domain.TaskRepository over JSON-RPC 2.0 on a stream,
+// one message per line. It speaks enough of the Model Context Protocol
+// (initialize, tools/list, tools/call) for assistants to use the tools, and
+// each tool can also be called directly as a method of the same name.
+package rpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/hitsumabushi845/task-management/internal/domain"
+)
+
+// ProtocolVersion is the Model Context Protocol revision implemented
+const ProtocolVersion = "2024-11-05"
+
+// maxMessageBytes bounds the length of one request line
+const maxMessageBytes = 1 << 20
+
+// JSON-RPC error codes
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+	CodeNotFound       = -32004 // The task or category does not exist
+	CodeRejected       = -32005 // A hook script vetoed the change
+	CodeConflict       = -32009 // The change conflicts with stored data
+)
+
+// Request is a JSON-RPC request or, without an ID, a notification
+type Request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// Response is a JSON-RPC response carrying either Result or Error
+type Response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// Error is a JSON-RPC error object
+type Error struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"` // {"field": name} for invalid task fields
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Server dispatches requests to the task tools
+type Server struct {
+	repo      domain.TaskRepository
+	weekStart time.Weekday
+	now       func() time.Time
+	tools     []tool
+}
+
+// Option configures a Server
+type Option func(*Server)
+
+// WithWeekStart sets the first day of calendar weeks for date parsing and filters
+func WithWeekStart(day time.Weekday) Option {
+	return func(s *Server) {
+		s.weekStart = day
+	}
+}
+
+// WithClock sets the time source used for relative dates and timestamps
+func WithClock(now func() time.Time) Option {
+	return func(s *Server) {
+		s.now = now
+	}
+}
+
+// New creates a server backed by repo
+func New(repo domain.TaskRepository, opts ...Option) *Server {
+	s := &Server{
+		repo:      repo,
+		weekStart: time.Monday,
+		now:       time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.tools = s.buildTools()
+	return s
+}
+
+// Serve handles requests read from r until it reaches EOF or ctx is done,
+// writing one response line to w per request. Notifications get no response.
+// Cancelling ctx is a clean shutdown and returns nil, even while waiting for
+// a request; a read already blocked on r then ends when r does.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	lines := make(chan []byte)
+	readErr := make(chan error, 1)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxMessageBytes)
+		for scanner.Scan() {
+			line := append([]byte(nil), scanner.Bytes()...)
+			select {
+			case lines <- line:
+			case <-ctx.Done():
+				return
+			}
+		}
+		readErr <- scanner.Err()
+	}()
+
+	enc := json.NewEncoder(w)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case line, ok := <-lines:
+			if !ok {
+				select {
+				case err := <-readErr:
+					return err
+				default:
+					return nil // Stopped reading because ctx is done
+				}
+			}
+			if ctx.Err() != nil {
+				return nil
+			}
+			if len(line) == 0 {
+				continue
+			}
+
+			resp := s.handle(ctx, line)
+			if resp == nil {
+				continue
+			}
+			if err := enc.Encode(resp); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// handle processes one message, returning nil for notifications
+func (s *Server) handle(ctx context.Context, line []byte) *Response {
+	var req Request
+	if err := json.Unmarshal(line, &req); err != nil {
+		return &Response{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &Error{Code: CodeParseError, Message: err.Error()}}
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return errorResponse(req.ID, &Error{Code: CodeInvalidRequest, Message: `expected "jsonrpc": "2.0" and a method`})
+	}
+
+	result, err := s.dispatch(ctx, req.Method, req.Params)
+	if req.ID == nil {
+		return nil
+	}
+	if err != nil {
+		return errorResponse(req.ID, toRPCError(err))
+	}
+	return &Response{JSONRPC: "2.0", ID: req.ID, Result: result}
+}
+
+// dispatch runs a protocol method or a tool called directly by name
+func (s *Server) dispatch(ctx context.Context, method string, params json.RawMessage) (interface{}, error) {
+	switch method {
+	case "initialize":
+		return map[string]interface{}{
+			"protocolVersion": ProtocolVersion,
+			"capabilities":    map[string]interface{}{"tools": map[string]interface{}{}},
+			"serverInfo":      map[string]string{"name": "task-management", "version": "1.0.0"},
+		}, nil
+	case "notifications/initialized", "ping":
+		return map[string]interface{}{}, nil
+	case "tools/list":
+		return map[string]interface{}{"tools": s.tools}, nil
+	case "tools/call":
+		return s.callTool(ctx, params)
+	}
+
+	t, ok := s.lookup(method)
+	if !ok {
+		return nil, &Error{Code: CodeMethodNotFound, Message: "method not found: " + method}
+	}
+	return t.call(ctx, params)
+}
+
+// callTool implements the MCP tools/call method. Tool failures are reported
+// in the result with isError so the assistant can see and correct them.
+func (s *Server) callTool(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var call struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if err := json.Unmarshal(params, &call); err != nil {
+		return nil, &Error{Code: CodeInvalidParams, Message: err.Error()}
+	}
+	t, ok := s.lookup(call.Name)
+	if !ok {
+		return nil, &Error{Code: CodeInvalidParams, Message: "unknown tool: " + call.Name}
+	}
+
+	result, err := t.call(ctx, call.Arguments)
+	if err != nil {
+		return toolResult(toRPCError(err).Message, true), nil
+	}
+	text, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return toolResult(string(text), false), nil
+}
+
+func (s *Server) lookup(name string) (tool, bool) {
+	for _, t := range s.tools {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return tool{}, false
+}
+
+func toolResult(text string, isError bool) map[string]interface{} {
+	return map[string]interface{}{
+		"content": []map[string]string{{"type": "text", "text": text}},
+		"isError": isError,
+	}
+}
+
+func errorResponse(id json.RawMessage, err *Error) *Response {
+	if id == nil {
+		id = json.RawMessage("null")
+	}
+	return &Response{JSONRPC: "2.0", ID: id, Error: err}
+}
+
+// toRPCError converts a tool error to a JSON-RPC error
+func toRPCError(err error) *Error {
+	var rpcErr *Error
+	if errors.As(err, &rpcErr) {
+		return rpcErr
+	}
+	return &Error{Code: CodeInternalError, Message: err.Error()}
+}
@@ -0,0 +1,331 @@
+package rpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hitsumabushi845/task-management/internal/api"
+	"github.com/hitsumabushi845/task-management/internal/repository"
+)
+
+// now is Wed 2026-10-14, matching the domain tests
+var now = time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)
+
+// testClient talks to a Server through in-memory pipes
+type testClient struct {
+	t      *testing.T
+	in     *io.PipeWriter
+	out    *bufio.Scanner
+	nextID int
+}
+
+func newTestClient(t *testing.T) *testClient {
+	t.Helper()
+	repo, err := repository.NewSQLiteRepository(":memory:")
+	if err != nil {
+		t.Fatalf("NewSQLiteRepository() error = %v", err)
+	}
+
+	reqR, reqW := io.Pipe()
+	respR, respW := io.Pipe()
+	srv := New(repo, WithClock(func() time.Time { return now }))
+
+	done := make(chan error, 1)
+	go func() {
+		err := srv.Serve(context.Background(), reqR, respW)
+		respW.Close()
+		done <- err
+	}()
+
+	t.Cleanup(func() {
+		reqW.Close()
+		if err := <-done; err != nil {
+			t.Errorf("Serve() error = %v", err)
+		}
+		repo.Close()
+	})
+	return &testClient{t: t, in: reqW, out: bufio.NewScanner(respR)}
+}
+
+// send writes a raw line and returns the decoded response
+func (c *testClient) send(line string) Response {
+	c.t.Helper()
+	if _, err := io.WriteString(c.in, line+"\n"); err != nil {
+		c.t.Fatalf("write request: %v", err)
+	}
+	if !c.out.Scan() {
+		c.t.Fatalf("no response to %s: %v", line, c.out.Err())
+	}
+	var resp Response
+	if err := json.Unmarshal(c.out.Bytes(), &resp); err != nil {
+		c.t.Fatalf("decode response %s: %v", c.out.Text(), err)
+	}
+	return resp
+}
+
+// call invokes method and decodes a successful result into out
+func (c *testClient) call(method string, params interface{}, out interface{}) *Error {
+	c.t.Helper()
+	c.nextID++
+	data, err := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": c.nextID, "method": method, "params": params})
+	if err != nil {
+		c.t.Fatalf("encode request: %v", err)
+	}
+	resp := c.send(string(data))
+	if string(resp.ID) != fmt.Sprint(c.nextID) {
+		c.t.Errorf("response id = %s, want %d", resp.ID, c.nextID)
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+	if out != nil {
+		raw, _ := json.Marshal(resp.Result)
+		if err := json.Unmarshal(raw, out); err != nil {
+			c.t.Fatalf("decode result %s: %v", raw, err)
+		}
+	}
+	return nil
+}
+
+func TestServer_Initialize(t *testing.T) {
+	c := newTestClient(t)
+
+	var result struct {
+		ProtocolVersion string                 `json:"protocolVersion"`
+		Capabilities    map[string]interface{} `json:"capabilities"`
+	}
+	if err := c.call("initialize", map[string]interface{}{}, &result); err != nil {
+		t.Fatalf("initialize error = %v", err)
+	}
+	if result.ProtocolVersion != ProtocolVersion {
+		t.Errorf("protocolVersion = %q, want %q", result.ProtocolVersion, ProtocolVersion)
+	}
+	if _, ok := result.Capabilities["tools"]; !ok {
+		t.Errorf("capabilities = %v, want tools", result.Capabilities)
+	}
+
+	// Notifications get no response, so the next line answers the ping
+	if _, err := io.WriteString(c.in, `{"jsonrpc":"2.0","method":"notifications/initialized"}`+"\n"); err != nil {
+		t.Fatalf("write notification: %v", err)
+	}
+	if err := c.call("ping", nil, nil); err != nil {
+		t.Errorf("ping error = %v", err)
+	}
+}
+
+func TestServer_ToolsList(t *testing.T) {
+	c := newTestClient(t)
+
+	var result struct {
+		Tools []struct {
+			Name        string                 `json:"name"`
+			Description string                 `json:"description"`
+			InputSchema map[string]interface{} `json:"inputSchema"`
+		} `json:"tools"`
+	}
+	if err := c.call("tools/list", nil, &result); err != nil {
+		t.Fatalf("tools/list error = %v", err)
+	}
+
+	names := map[string]bool{}
+	for _, tool := range result.Tools {
+		names[tool.Name] = true
+		if tool.Description == "" {
+			t.Errorf("tool %s has no description", tool.Name)
+		}
+		if tool.InputSchema["type"] != "object" {
+			t.Errorf("tool %s schema type = %v, want object", tool.Name, tool.InputSchema["type"])
+		}
+	}
+	for _, want := range []string{"list_tasks", "create_task", "update_task", "advance_status", "add_note", "list_categories", "delete_category"} {
+		if !names[want] {
+			t.Errorf("tools/list missing %s", want)
+		}
+	}
+}
+
+func TestServer_TaskMethods(t *testing.T) {
+	c := newTestClient(t)
+
+	var created api.Task
+	if err := c.call("create_task", map[string]interface{}{"title": "Write report", "priority": "high", "due_date": "tomorrow"}, &created); err != nil {
+		t.Fatalf("create_task error = %v", err)
+	}
+	if created.ID == 0 || created.Status != "new" || created.DueDate == nil || *created.DueDate != "2026-10-15" {
+		t.Errorf("created = %+v", created)
+	}
+
+	var updated api.Task
+	if err := c.call("update_task", map[string]interface{}{"id": created.ID, "description": "Q3"}, &updated); err != nil {
+		t.Fatalf("update_task error = %v", err)
+	}
+	if updated.Description != "Q3" || updated.Title != "Write report" {
+		t.Errorf("updated = %+v", updated)
+	}
+
+	var advanced api.Task
+	for _, want := range []string{"working", "completed", "completed"} {
+		if err := c.call("advance_status", map[string]interface{}{"id": created.ID}, &advanced); err != nil {
+			t.Fatalf("advance_status error = %v", err)
+		}
+		if string(advanced.Status) != want {
+			t.Errorf("status = %v, want %v", advanced.Status, want)
+		}
+	}
+
+	var tasks []api.Task
+	if err := c.call("list_tasks", map[string]interface{}{"status": []string{"completed"}}, &tasks); err != nil {
+		t.Fatalf("list_tasks error = %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].ID != created.ID {
+		t.Errorf("list_tasks = %+v", tasks)
+	}
+
+	var note api.Note
+	if err := c.call("add_note", map[string]interface{}{"id": created.ID, "text": "Sent to finance"}, &note); err != nil {
+		t.Fatalf("add_note error = %v", err)
+	}
+	var got api.Task
+	if err := c.call("get_task", map[string]interface{}{"id": created.ID}, &got); err != nil {
+		t.Fatalf("get_task error = %v", err)
+	}
+	if len(got.Notes) != 1 || got.Notes[0].ID != note.ID || got.Notes[0].Text != "Sent to finance" {
+		t.Errorf("notes = %+v, want the added note", got.Notes)
+	}
+
+	var categories []api.Category
+	if err := c.call("list_categories", nil, &categories); err != nil {
+		t.Fatalf("list_categories error = %v", err)
+	}
+	if len(categories) == 0 {
+		t.Errorf("list_categories returned no categories")
+	}
+
+	if err := c.call("delete_task", map[string]interface{}{"id": created.ID}, nil); err != nil {
+		t.Fatalf("delete_task error = %v", err)
+	}
+	if err := c.call("get_task", map[string]interface{}{"id": created.ID}, nil); err == nil || err.Code != CodeNotFound {
+		t.Errorf("get_task after delete error = %v, want code %d", err, CodeNotFound)
+	}
+}
+
+func TestServer_Errors(t *testing.T) {
+	c := newTestClient(t)
+
+	tests := []struct {
+		name      string
+		method    string
+		params    interface{}
+		wantCode  int
+		wantField string
+	}{
+		{"unknown method", "frobnicate", nil, CodeMethodNotFound, ""},
+		{"missing title", "create_task", map[string]interface{}{"description": "x"}, CodeInvalidParams, "title"},
+		{"unknown field", "create_task", map[string]interface{}{"titel": "x"}, CodeInvalidParams, ""},
+		{"bad due date", "create_task", map[string]interface{}{"title": "x", "due_date": "someday"}, CodeInvalidParams, "due_date"},
+		{"bad filter", "list_tasks", map[string]interface{}{"range": "forever"}, CodeInvalidParams, ""},
+		{"missing id", "advance_status", map[string]interface{}{}, CodeInvalidParams, ""},
+		{"unknown id", "update_task", map[string]interface{}{"id": 999, "title": "x"}, CodeNotFound, ""},
+		{"note for unknown id", "add_note", map[string]interface{}{"id": 999, "text": "x"}, CodeNotFound, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := c.call(tt.method, tt.params, nil)
+			if err == nil {
+				t.Fatalf("%s expected error", tt.method)
+			}
+			if err.Code != tt.wantCode {
+				t.Errorf("error code = %d (%s), want %d", err.Code, err.Message, tt.wantCode)
+			}
+			var field string
+			if data, ok := err.Data.(map[string]interface{}); ok {
+				field, _ = data["field"].(string)
+			}
+			if field != tt.wantField {
+				t.Errorf("error data field = %q, want %q", field, tt.wantField)
+			}
+		})
+	}
+
+	t.Run("malformed json", func(t *testing.T) {
+		resp := c.send(`{"jsonrpc":`)
+		if resp.Error == nil || resp.Error.Code != CodeParseError {
+			t.Errorf("error = %v, want code %d", resp.Error, CodeParseError)
+		}
+	})
+}
+
+func TestServer_ToolsCall(t *testing.T) {
+	c := newTestClient(t)
+
+	var result struct {
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"content"`
+		IsError bool `json:"isError"`
+	}
+
+	params := map[string]interface{}{"name": "create_task", "arguments": map[string]string{"title": "From assistant"}}
+	if err := c.call("tools/call", params, &result); err != nil {
+		t.Fatalf("tools/call error = %v", err)
+	}
+	if result.IsError || len(result.Content) != 1 {
+		t.Fatalf("result = %+v", result)
+	}
+	var task api.Task
+	if err := json.Unmarshal([]byte(result.Content[0].Text), &task); err != nil {
+		t.Fatalf("content is not a task: %v", err)
+	}
+	if task.Title != "From assistant" {
+		t.Errorf("title = %q, want %q", task.Title, "From assistant")
+	}
+
+	// Tool failures are results with isError so the caller can react
+	params = map[string]interface{}{"name": "create_task", "arguments": map[string]string{"title": ""}}
+	if err := c.call("tools/call", params, &result); err != nil {
+		t.Fatalf("tools/call error = %v", err)
+	}
+	if !result.IsError || !strings.Contains(result.Content[0].Text, "title is required") {
+		t.Errorf("result = %+v, want title validation error", result)
+	}
+}
+
+func TestServer_CancelWhileIdle(t *testing.T) {
+	repo := repository.NewMemoryRepository()
+	reqR, reqW := io.Pipe()
+	defer reqW.Close()
+	respR, respW := io.Pipe()
+	srv := New(repo, WithClock(func() time.Time { return now }))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- srv.Serve(ctx, reqR, respW)
+		respW.Close()
+	}()
+
+	// Answer one request, then leave the writer idle
+	out := bufio.NewScanner(respR)
+	io.WriteString(reqW, `{"jsonrpc":"2.0","id":1,"method":"ping"}`+"\n")
+	if !out.Scan() {
+		t.Fatalf("no response to ping: %v", out.Err())
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Serve() after cancel error = %v, want nil", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Serve() still waiting for input after cancel")
+	}
+}
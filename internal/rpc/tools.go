@@ -0,0 +1,385 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hitsumabushi845/task-management/internal/api"
+	"github.com/hitsumabushi845/task-management/internal/domain"
+	"github.com/hitsumabushi845/task-management/internal/hooks"
+)
+
+// tool is a method callable directly or through tools/call
+type tool struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	InputSchema map[string]interface{} `json:"inputSchema"`
+
+	call func(ctx context.Context, params json.RawMessage) (interface{}, error)
+}
+
+// Schema fragments shared by the tools
+var (
+	idSchema = map[string]interface{}{"type": "integer", "description": "Task ID"}
+
+	statusSchema = map[string]interface{}{
+		"type": "string",
+		"enum": []string{"new", "working", "completed"},
+	}
+
+	taskFieldSchemas = map[string]interface{}{
+		"title":       map[string]interface{}{"type": "string", "description": "Title, at most 200 characters"},
+		"description": map[string]interface{}{"type": "string", "description": "Description, at most 1000 characters"},
+		"status":      statusSchema,
+		"priority":    map[string]interface{}{"type": "string", "enum": []string{"low", "medium", "high"}},
+		"category_id": map[string]interface{}{"type": "integer", "description": "Category ID from list_categories; 0 removes the category"},
+		"due_date":    map[string]interface{}{"type": "string", "description": `Due date such as "2026-11-03", "tomorrow", "next fri" or "+3d"; "" clears it`},
+		"remind_at":   map[string]interface{}{"type": "string", "description": `Reminder time such as "+30m", "14:30" or "fri 9:00"; "" clears it`},
+		"checklist": map[string]interface{}{
+			"type":        "array",
+			"description": "Checklist items in order, replacing the current ones; [] clears the checklist",
+			"items": objectSchema(map[string]interface{}{
+				"text": map[string]interface{}{"type": "string", "description": "Item text, at most 200 characters"},
+				"done": map[string]interface{}{"type": "boolean"},
+			}, "text"),
+		},
+		"attachments": map[string]interface{}{
+			"type":        "array",
+			"description": "Attached files and URLs in order, replacing the current ones; [] removes them all",
+			"items": objectSchema(map[string]interface{}{
+				"location": map[string]interface{}{"type": "string", "description": "An absolute file path or a URL"},
+			}, "location"),
+		},
+		"archived": map[string]interface{}{"type": "boolean", "description": "true archives the task, hiding it from lists; false unarchives it"},
+	}
+)
+
+func objectSchema(properties map[string]interface{}, required ...string) map[string]interface{} {
+	schema := map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func withID(properties map[string]interface{}) map[string]interface{} {
+	out := map[string]interface{}{"id": idSchema}
+	for k, v := range properties {
+		out[k] = v
+	}
+	return out
+}
+
+// withVersion adds the optional version that guards an update against
+// concurrent changes
+func withVersion(properties map[string]interface{}) map[string]interface{} {
+	properties["version"] = map[string]interface{}{
+		"type":        "integer",
+		"description": "The task's version when it was read; the update fails with a conflict if it has changed since",
+	}
+	return properties
+}
+
+// buildTools lists the tools in the order tools/list reports them
+func (s *Server) buildTools() []tool {
+	return []tool{
+		{
+			Name:        "list_tasks",
+			Description: "List tasks, optionally filtered and sorted. Returns an array of tasks.",
+			InputSchema: objectSchema(map[string]interface{}{
+				"status":   map[string]interface{}{"type": "array", "items": statusSchema},
+				"priority": map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string", "enum": []string{"low", "medium", "high"}}},
+				"category": map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "integer"}, "description": "Category IDs"},
+				"q":        map[string]interface{}{"type": "string", "description": "Case-insensitive text to find in the title, description or notes"},
+				"range": map[string]interface{}{
+					"type":        "string",
+					"enum":        []string{"all", "today", "this_week", "overdue", "no_date", "this_month", "next_days", "custom"},
+					"description": "Date range applied to field",
+				},
+				"field": map[string]interface{}{"type": "string", "enum": []string{"due", "created", "completed"}, "description": "Date the range applies to, default due"},
+				"days":  map[string]interface{}{"type": "integer", "description": "Length of the next_days range, default 7"},
+				"from":  map[string]interface{}{"type": "string", "description": "First day of the custom range"},
+				"to":    map[string]interface{}{"type": "string", "description": "Last day of the custom range"},
+				"sort":  map[string]interface{}{"type": "string", "enum": []string{"created_at", "due_date", "priority", "status", "title"}},
+				"order": map[string]interface{}{"type": "string", "enum": []string{"asc", "desc"}},
+				"archived": map[string]interface{}{
+					"type":        "string",
+					"enum":        []string{"exclude", "include", "only"},
+					"description": "Whether to list archived tasks, default exclude",
+				},
+			}),
+			call: s.listTasks,
+		},
+		{
+			Name:        "get_task",
+			Description: "Fetch one task by ID.",
+			InputSchema: objectSchema(map[string]interface{}{"id": idSchema}, "id"),
+			call:        s.getTask,
+		},
+		{
+			Name:        "create_task",
+			Description: "Create a task. Priority defaults to medium and status to new. Returns the created task.",
+			InputSchema: objectSchema(taskFieldSchemas, "title"),
+			call:        s.createTask,
+		},
+		{
+			Name:        "update_task",
+			Description: "Change the given fields of a task; omitted fields are left unchanged. Returns the updated task.",
+			InputSchema: objectSchema(withVersion(withID(taskFieldSchemas)), "id"),
+			call:        s.updateTask,
+		},
+		{
+			Name:        "advance_status",
+			Description: "Move a task to its next status: new -> working -> completed. Returns the updated task.",
+			InputSchema: objectSchema(map[string]interface{}{"id": idSchema}, "id"),
+			call:        s.advanceStatus,
+		},
+		{
+			Name:        "delete_task",
+			Description: "Delete a task by ID.",
+			InputSchema: objectSchema(map[string]interface{}{"id": idSchema}, "id"),
+			call:        s.deleteTask,
+		},
+		{
+			Name:        "add_note",
+			Description: "Append a timestamped note to a task, e.g. \"waiting on vendor reply\", keeping the description as it is. Returns the note.",
+			InputSchema: objectSchema(map[string]interface{}{
+				"id":   idSchema,
+				"text": map[string]interface{}{"type": "string", "description": "The note"},
+			}, "id", "text"),
+			call: s.addNote,
+		},
+		{
+			Name:        "list_categories",
+			Description: "List the categories tasks can be assigned to.",
+			InputSchema: objectSchema(map[string]interface{}{}),
+			call:        s.listCategories,
+		},
+		{
+			Name:        "delete_category",
+			Description: "Delete a category by ID. Its tasks are kept without a category.",
+			InputSchema: objectSchema(map[string]interface{}{"id": map[string]interface{}{"type": "integer", "description": "Category ID"}}, "id"),
+			call:        s.deleteCategory,
+		},
+	}
+}
+
+func (s *Server) listTasks(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var p api.ListParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	now := s.now()
+	filter, sort, err := p.Filter(now, s.weekStart)
+	if err != nil {
+		return nil, invalidParams(err)
+	}
+
+	tasks, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, repoError(err)
+	}
+	var matched []*domain.Task
+	for _, task := range tasks {
+		if filter.MatchAt(task, now) {
+			matched = append(matched, task)
+		}
+	}
+	return api.FromTasks(sort.Apply(matched)), nil
+}
+
+func (s *Server) getTask(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	task, err := s.lookupTask(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return api.FromTask(task), nil
+}
+
+func (s *Server) createTask(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var in api.TaskInput
+	if err := decodeParams(params, &in); err != nil {
+		return nil, err
+	}
+	task := api.NewTask()
+	if err := in.Apply(task, s.now(), s.weekStart); err != nil {
+		return nil, invalidParams(err)
+	}
+	if err := task.Validate(); err != nil {
+		return nil, invalidParams(err)
+	}
+	if err := s.repo.Create(ctx, task); err != nil {
+		return nil, repoError(err)
+	}
+	return api.FromTask(task), nil
+}
+
+func (s *Server) updateTask(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var in struct {
+		ID int64 `json:"id"`
+		api.TaskInput
+	}
+	if err := decodeParams(params, &in); err != nil {
+		return nil, err
+	}
+	task, err := s.getByID(ctx, in.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := in.Apply(task, s.now(), s.weekStart); err != nil {
+		return nil, invalidParams(err)
+	}
+	return s.saveTask(ctx, task)
+}
+
+func (s *Server) advanceStatus(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	task, err := s.lookupTask(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if err := task.SetStatus(task.NextStatus(), s.now()); err != nil {
+		return nil, invalidParams(err)
+	}
+	return s.saveTask(ctx, task)
+}
+
+func (s *Server) deleteTask(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	task, err := s.lookupTask(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Delete(ctx, task.ID); err != nil {
+		return nil, repoError(err)
+	}
+	return map[string]int64{"deleted": task.ID}, nil
+}
+
+func (s *Server) addNote(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var in struct {
+		ID int64 `json:"id"`
+		api.NoteInput
+	}
+	if err := decodeParams(params, &in); err != nil {
+		return nil, err
+	}
+	task, err := s.getByID(ctx, in.ID)
+	if err != nil {
+		return nil, err
+	}
+	note := &domain.Note{TaskID: task.ID, Text: in.Text}
+	if err := note.Validate(); err != nil {
+		return nil, invalidParams(err)
+	}
+	if err := s.repo.AddNote(ctx, note); err != nil {
+		return nil, repoError(err)
+	}
+	return api.FromNotes([]*domain.Note{note})[0], nil
+}
+
+func (s *Server) listCategories(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	if err := decodeParams(params, &struct{}{}); err != nil {
+		return nil, err
+	}
+	categories, err := s.repo.GetCategories(ctx)
+	if err != nil {
+		return nil, repoError(err)
+	}
+	return api.FromCategories(categories), nil
+}
+
+func (s *Server) deleteCategory(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		ID int64 `json:"id"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	if p.ID <= 0 {
+		return nil, &Error{Code: CodeInvalidParams, Message: "id is required"}
+	}
+	if err := s.repo.DeleteCategory(ctx, p.ID); err != nil {
+		return nil, repoError(err)
+	}
+	return map[string]int64{"deleted": p.ID}, nil
+}
+
+// lookupTask loads the task named by an {"id": N} parameter object
+func (s *Server) lookupTask(ctx context.Context, params json.RawMessage) (*domain.Task, error) {
+	var p struct {
+		ID int64 `json:"id"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	return s.getByID(ctx, p.ID)
+}
+
+func (s *Server) getByID(ctx context.Context, id int64) (*domain.Task, error) {
+	if id <= 0 {
+		return nil, &Error{Code: CodeInvalidParams, Message: "id is required"}
+	}
+	task, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, repoError(err)
+	}
+	return task, nil
+}
+
+// saveTask validates and stores an updated task
+func (s *Server) saveTask(ctx context.Context, task *domain.Task) (interface{}, error) {
+	if err := task.Validate(); err != nil {
+		return nil, invalidParams(err)
+	}
+	if err := s.repo.Update(ctx, task); err != nil {
+		return nil, repoError(err)
+	}
+	return api.FromTask(task), nil
+}
+
+// decodeParams strictly decodes a params object; absent params decode as {}
+func decodeParams(params json.RawMessage, v interface{}) error {
+	if len(params) == 0 || string(params) == "null" {
+		params = json.RawMessage("{}")
+	}
+	dec := json.NewDecoder(bytes.NewReader(params))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return &Error{Code: CodeInvalidParams, Message: "invalid params: " + err.Error()}
+	}
+	return nil
+}
+
+// invalidParams reports a bad argument, naming the field when err is a
+// domain.ValidationError
+func invalidParams(err error) *Error {
+	e := &Error{Code: CodeInvalidParams, Message: err.Error()}
+	var verr *domain.ValidationError
+	if errors.As(err, &verr) {
+		e.Data = map[string]string{"field": verr.Field}
+	}
+	return e
+}
+
+// repoError maps a repository error to a JSON-RPC error
+func repoError(err error) *Error {
+	var verr *domain.ValidationError
+	var veto *hooks.VetoError
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		return &Error{Code: CodeNotFound, Message: err.Error()}
+	case errors.Is(err, domain.ErrDuplicateCategory), errors.Is(err, domain.ErrConflict):
+		return &Error{Code: CodeConflict, Message: err.Error()}
+	case errors.As(err, &verr):
+		return invalidParams(verr)
+	case errors.As(err, &veto):
+		return &Error{Code: CodeRejected, Message: veto.Error()}
+	}
+	return &Error{Code: CodeInternalError, Message: fmt.Sprintf("repository: %v", err)}
+}
@@ -0,0 +1,420 @@
+// Package server exposes a domain.TaskRepository as a JSON REST API.
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hitsumabushi845/task-management/internal/api"
+	"github.com/hitsumabushi845/task-management/internal/domain"
+	"github.com/hitsumabushi845/task-management/internal/hooks"
+	"github.com/hitsumabushi845/task-management/internal/repository"
+)
+
+// maxBodyBytes bounds the size of request bodies
+const maxBodyBytes = 1 << 20
+
+// Server handles the REST API:
+//
+//	GET    /api/tasks              list tasks, filtered by api.ListParams query parameters
+//	POST   /api/tasks              create a task from an api.TaskInput
+//	GET    /api/tasks/{id}         fetch a task
+//	PATCH  /api/tasks/{id}         update the fields present in an api.TaskInput
+//	DELETE /api/tasks/{id}         delete a task
+//	POST   /api/tasks/{id}/status  change status, {"status": "working"}
+//	POST   /api/tasks/{id}/advance move to the next status
+//	GET    /api/tasks/{id}/notes   list a task's notes, oldest first
+//	POST   /api/tasks/{id}/notes   add a note from an api.NoteInput
+//	GET    /api/categories         list categories
+//	POST   /api/categories         create a category from an api.CategoryInput
+//	DELETE /api/categories/{id}    delete a category, leaving its tasks uncategorized
+//	GET    /api/metrics            repository call statistics, with WithMetrics
+type Server struct {
+	repo      domain.TaskRepository
+	weekStart time.Weekday
+	now       func() time.Time
+	timing    *repository.Timing
+	mux       *http.ServeMux
+}
+
+// Option configures a Server
+type Option func(*Server)
+
+// WithWeekStart sets the first day of calendar weeks for date parsing and filters
+func WithWeekStart(day time.Weekday) Option {
+	return func(s *Server) {
+		s.weekStart = day
+	}
+}
+
+// WithClock sets the time source used for relative dates and timestamps
+func WithClock(now func() time.Time) Option {
+	return func(s *Server) {
+		s.now = now
+	}
+}
+
+// WithMetrics serves the statistics collected by timing at /api/metrics
+func WithMetrics(timing *repository.Timing) Option {
+	return func(s *Server) {
+		s.timing = timing
+	}
+}
+
+// New creates a server backed by repo
+func New(repo domain.TaskRepository, opts ...Option) *Server {
+	s := &Server{
+		repo:      repo,
+		weekStart: time.Monday,
+		now:       time.Now,
+		mux:       http.NewServeMux(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.mux.HandleFunc("GET /api/tasks", s.listTasks)
+	s.mux.HandleFunc("POST /api/tasks", s.createTask)
+	s.mux.HandleFunc("GET /api/tasks/{id}", s.getTask)
+	s.mux.HandleFunc("PATCH /api/tasks/{id}", s.updateTask)
+	s.mux.HandleFunc("DELETE /api/tasks/{id}", s.deleteTask)
+	s.mux.HandleFunc("POST /api/tasks/{id}/status", s.setStatus)
+	s.mux.HandleFunc("POST /api/tasks/{id}/advance", s.advanceTask)
+	s.mux.HandleFunc("GET /api/tasks/{id}/notes", s.listNotes)
+	s.mux.HandleFunc("POST /api/tasks/{id}/notes", s.addNote)
+	s.mux.HandleFunc("GET /api/categories", s.listCategories)
+	s.mux.HandleFunc("POST /api/categories", s.createCategory)
+	s.mux.HandleFunc("DELETE /api/categories/{id}", s.deleteCategory)
+	if s.timing != nil {
+		s.mux.HandleFunc("GET /api/metrics", s.metrics)
+	}
+
+	return s
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) listTasks(w http.ResponseWriter, r *http.Request) {
+	params, err := listParams(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, api.CodeBadRequest, err.Error())
+		return
+	}
+	now := s.now()
+	filter, sort, err := params.Filter(now, s.weekStart)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, api.CodeBadRequest, err.Error())
+		return
+	}
+
+	tasks, err := s.repo.List(r.Context())
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+
+	var matched []*domain.Task
+	for _, task := range tasks {
+		if filter.MatchAt(task, now) {
+			matched = append(matched, task)
+		}
+	}
+	writeJSON(w, http.StatusOK, api.FromTasks(sort.Apply(matched)))
+}
+
+func (s *Server) createTask(w http.ResponseWriter, r *http.Request) {
+	var in api.TaskInput
+	if !decodeBody(w, r, &in) {
+		return
+	}
+
+	task := api.NewTask()
+	if err := in.Apply(task, s.now(), s.weekStart); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+	if err := task.Validate(); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+	if err := s.repo.Create(r.Context(), task); err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, api.FromTask(task))
+}
+
+func (s *Server) getTask(w http.ResponseWriter, r *http.Request) {
+	task, ok := s.lookupTask(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, api.FromTask(task))
+}
+
+func (s *Server) updateTask(w http.ResponseWriter, r *http.Request) {
+	task, ok := s.lookupTask(w, r)
+	if !ok {
+		return
+	}
+	var in api.TaskInput
+	if !decodeBody(w, r, &in) {
+		return
+	}
+	if err := in.Apply(task, s.now(), s.weekStart); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+	s.saveTask(w, r, task)
+}
+
+func (s *Server) deleteTask(w http.ResponseWriter, r *http.Request) {
+	task, ok := s.lookupTask(w, r)
+	if !ok {
+		return
+	}
+	if err := s.repo.Delete(r.Context(), task.ID); err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) setStatus(w http.ResponseWriter, r *http.Request) {
+	task, ok := s.lookupTask(w, r)
+	if !ok {
+		return
+	}
+	var body struct {
+		Status domain.TaskStatus `json:"status"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if err := task.SetStatus(body.Status, s.now()); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+	s.saveTask(w, r, task)
+}
+
+func (s *Server) advanceTask(w http.ResponseWriter, r *http.Request) {
+	task, ok := s.lookupTask(w, r)
+	if !ok {
+		return
+	}
+	if err := task.SetStatus(task.NextStatus(), s.now()); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+	s.saveTask(w, r, task)
+}
+
+func (s *Server) listNotes(w http.ResponseWriter, r *http.Request) {
+	task, ok := s.lookupTask(w, r)
+	if !ok {
+		return
+	}
+	notes, err := s.repo.ListNotes(r.Context(), task.ID)
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, api.FromNotes(notes))
+}
+
+func (s *Server) addNote(w http.ResponseWriter, r *http.Request) {
+	task, ok := s.lookupTask(w, r)
+	if !ok {
+		return
+	}
+	var in api.NoteInput
+	if !decodeBody(w, r, &in) {
+		return
+	}
+	note := &domain.Note{TaskID: task.ID, Text: in.Text}
+	if err := note.Validate(); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+	if err := s.repo.AddNote(r.Context(), note); err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, api.FromNotes([]*domain.Note{note})[0])
+}
+
+func (s *Server) listCategories(w http.ResponseWriter, r *http.Request) {
+	categories, err := s.repo.GetCategories(r.Context())
+	if err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, api.FromCategories(categories))
+}
+
+func (s *Server) createCategory(w http.ResponseWriter, r *http.Request) {
+	var in api.CategoryInput
+	if !decodeBody(w, r, &in) {
+		return
+	}
+	category := &domain.Category{Name: in.Name, Color: in.Color}
+	if err := category.Validate(); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+	if err := s.repo.CreateCategory(r.Context(), category); err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, api.FromCategories([]*domain.Category{category})[0])
+}
+
+func (s *Server) deleteCategory(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, api.CodeBadRequest, "invalid category ID "+strconv.Quote(r.PathValue("id")))
+		return
+	}
+	if err := s.repo.DeleteCategory(r.Context(), id); err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) metrics(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.timing.Snapshot())
+}
+
+// lookupTask loads the task named by the {id} path segment, writing an
+// error response if it cannot
+func (s *Server) lookupTask(w http.ResponseWriter, r *http.Request) (*domain.Task, bool) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, api.CodeBadRequest, "invalid task ID "+strconv.Quote(r.PathValue("id")))
+		return nil, false
+	}
+	task, err := s.repo.GetByID(r.Context(), id)
+	if err != nil {
+		writeRepoError(w, err)
+		return nil, false
+	}
+	return task, true
+}
+
+// saveTask validates and stores an updated task and writes it back
+func (s *Server) saveTask(w http.ResponseWriter, r *http.Request, task *domain.Task) {
+	if err := task.Validate(); err != nil {
+		writeValidationError(w, err)
+		return
+	}
+	if err := s.repo.Update(r.Context(), task); err != nil {
+		writeRepoError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, api.FromTask(task))
+}
+
+// listParams reads list parameters from a query string. List values may be
+// repeated or comma separated, e.g. ?status=new,working
+func listParams(q url.Values) (api.ListParams, error) {
+	params := api.ListParams{
+		Statuses:   splitValues(q["status"]),
+		Priorities: splitValues(q["priority"]),
+		Search:     q.Get("q"),
+		Range:      q.Get("range"),
+		Field:      q.Get("field"),
+		From:       q.Get("from"),
+		To:         q.Get("to"),
+		Sort:       q.Get("sort"),
+		Order:      q.Get("order"),
+		Archived:   q.Get("archived"),
+	}
+	for _, v := range splitValues(q["category"]) {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return params, errors.New("invalid category " + strconv.Quote(v))
+		}
+		params.Categories = append(params.Categories, id)
+	}
+	if v := q.Get("days"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil {
+			return params, errors.New("invalid days " + strconv.Quote(v))
+		}
+		params.Days = days
+	}
+	return params, nil
+}
+
+func splitValues(values []string) []string {
+	var out []string
+	for _, v := range values {
+		for _, part := range strings.Split(v, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				out = append(out, part)
+			}
+		}
+	}
+	return out
+}
+
+// decodeBody reads a JSON request body into v, writing a 400 response if it
+// is malformed
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, api.CodeBadRequest, "invalid JSON body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// writeRepoError maps a repository error to a response
+func writeRepoError(w http.ResponseWriter, err error) {
+	var verr *domain.ValidationError
+	var veto *hooks.VetoError
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		writeError(w, http.StatusNotFound, api.CodeNotFound, err.Error())
+	case errors.Is(err, domain.ErrDuplicateCategory), errors.Is(err, domain.ErrConflict):
+		writeError(w, http.StatusConflict, api.CodeConflict, err.Error())
+	case errors.As(err, &verr):
+		writeValidationError(w, verr)
+	case errors.As(err, &veto):
+		writeError(w, http.StatusConflict, api.CodeRejected, veto.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, api.CodeInternal, err.Error())
+	}
+}
+
+// writeValidationError writes a 400 response naming the invalid field when
+// err is a domain.ValidationError
+func writeValidationError(w http.ResponseWriter, err error) {
+	body := api.ErrorBody{Code: api.CodeValidationFailed, Message: err.Error()}
+	var verr *domain.ValidationError
+	if errors.As(err, &verr) {
+		body.Field = verr.Field
+	}
+	writeJSON(w, http.StatusBadRequest, api.ErrorResponse{Error: body})
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, api.ErrorResponse{Error: api.ErrorBody{Code: code, Message: message}})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,478 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hitsumabushi845/task-management/internal/api"
+	"github.com/hitsumabushi845/task-management/internal/repository"
+)
+
+// now is Wed 2026-10-14, matching the domain tests
+var now = time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	repo, err := repository.NewSQLiteRepository(":memory:")
+	if err != nil {
+		t.Fatalf("NewSQLiteRepository() error = %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+
+	ts := httptest.NewServer(New(repo, WithClock(func() time.Time { return now })))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+// do sends a request with an optional JSON body and decodes the response
+// into out unless it is nil
+func do(t *testing.T, ts *httptest.Server, method, path string, body interface{}, out interface{}) int {
+	t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			t.Fatalf("encode body: %v", err)
+		}
+	}
+	req, err := http.NewRequest(method, ts.URL+path, &buf)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s error = %v", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatalf("%s %s: decode response: %v", method, path, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+func TestServer_TaskCRUD(t *testing.T) {
+	ts := newTestServer(t)
+
+	var created api.Task
+	status := do(t, ts, "POST", "/api/tasks", map[string]string{
+		"title":    "Write report",
+		"priority": "high",
+		"due_date": "fri",
+	}, &created)
+	if status != http.StatusCreated {
+		t.Fatalf("POST /api/tasks status = %d, want %d", status, http.StatusCreated)
+	}
+	if created.ID == 0 || created.Title != "Write report" || created.Status != "new" {
+		t.Errorf("created = %+v", created)
+	}
+	if created.DueDate == nil || *created.DueDate != "2026-10-16" {
+		t.Errorf("DueDate = %v, want 2026-10-16", created.DueDate)
+	}
+
+	path := "/api/tasks/" + itoa(created.ID)
+
+	var fetched api.Task
+	if status := do(t, ts, "GET", path, nil, &fetched); status != http.StatusOK {
+		t.Fatalf("GET status = %d, want %d", status, http.StatusOK)
+	}
+	if fetched.Title != created.Title {
+		t.Errorf("fetched title = %q, want %q", fetched.Title, created.Title)
+	}
+
+	var updated api.Task
+	status = do(t, ts, "PATCH", path, map[string]string{"description": "Q3 numbers", "due_date": ""}, &updated)
+	if status != http.StatusOK {
+		t.Fatalf("PATCH status = %d, want %d", status, http.StatusOK)
+	}
+	if updated.Description != "Q3 numbers" || updated.Title != "Write report" || updated.DueDate != nil {
+		t.Errorf("updated = %+v", updated)
+	}
+
+	if status := do(t, ts, "DELETE", path, nil, nil); status != http.StatusNoContent {
+		t.Fatalf("DELETE status = %d, want %d", status, http.StatusNoContent)
+	}
+
+	var errResp api.ErrorResponse
+	if status := do(t, ts, "GET", path, nil, &errResp); status != http.StatusNotFound {
+		t.Fatalf("GET after delete status = %d, want %d", status, http.StatusNotFound)
+	}
+	if errResp.Error.Code != api.CodeNotFound {
+		t.Errorf("error code = %q, want %q", errResp.Error.Code, api.CodeNotFound)
+	}
+}
+
+func TestServer_ValidationErrors(t *testing.T) {
+	ts := newTestServer(t)
+
+	tests := []struct {
+		name      string
+		method    string
+		path      string
+		body      interface{}
+		wantCode  string
+		wantField string
+	}{
+		{"missing title", "POST", "/api/tasks", map[string]string{"description": "no title"}, api.CodeValidationFailed, "title"},
+		{"invalid priority", "POST", "/api/tasks", map[string]string{"title": "x", "priority": "urgent"}, api.CodeValidationFailed, "priority"},
+		{"unparsable due date", "POST", "/api/tasks", map[string]string{"title": "x", "due_date": "someday"}, api.CodeValidationFailed, "due_date"},
+		{"unknown field", "POST", "/api/tasks", map[string]string{"titel": "x"}, api.CodeBadRequest, ""},
+		{"malformed json", "POST", "/api/tasks", "not an object", api.CodeBadRequest, ""},
+		{"invalid category color", "POST", "/api/categories", map[string]string{"name": "Work", "color": "orange"}, api.CodeValidationFailed, "color"},
+		{"invalid filter", "GET", "/api/tasks?status=done", nil, api.CodeBadRequest, ""},
+		{"invalid archived", "GET", "/api/tasks?archived=yes", nil, api.CodeBadRequest, ""},
+		{"created is never unset", "GET", "/api/tasks?range=no_date&field=created", nil, api.CodeBadRequest, ""},
+		{"invalid ID", "GET", "/api/tasks/abc", nil, api.CodeBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var errResp api.ErrorResponse
+			status := do(t, ts, tt.method, tt.path, tt.body, &errResp)
+			if status != http.StatusBadRequest {
+				t.Errorf("status = %d, want %d", status, http.StatusBadRequest)
+			}
+			if errResp.Error.Code != tt.wantCode {
+				t.Errorf("error code = %q, want %q", errResp.Error.Code, tt.wantCode)
+			}
+			if errResp.Error.Field != tt.wantField {
+				t.Errorf("error field = %q, want %q", errResp.Error.Field, tt.wantField)
+			}
+			if errResp.Error.Message == "" {
+				t.Errorf("error message is empty")
+			}
+		})
+	}
+}
+
+func TestServer_DuplicateCategory(t *testing.T) {
+	ts := newTestServer(t)
+
+	body := map[string]string{"name": "Errands", "color": "green"}
+	if status := do(t, ts, "POST", "/api/categories", body, nil); status != http.StatusCreated {
+		t.Fatalf("first POST status = %d, want %d", status, http.StatusCreated)
+	}
+	var errResp api.ErrorResponse
+	if status := do(t, ts, "POST", "/api/categories", body, &errResp); status != http.StatusConflict {
+		t.Errorf("duplicate POST status = %d, want %d", status, http.StatusConflict)
+	}
+	if errResp.Error.Code != api.CodeConflict {
+		t.Errorf("error code = %q, want %q", errResp.Error.Code, api.CodeConflict)
+	}
+}
+
+func TestServer_VersionConflict(t *testing.T) {
+	ts := newTestServer(t)
+
+	var task api.Task
+	do(t, ts, "POST", "/api/tasks", map[string]string{"title": "Shared"}, &task)
+	path := "/api/tasks/" + itoa(task.ID)
+
+	var updated api.Task
+	body := map[string]interface{}{"title": "First", "version": task.Version}
+	if status := do(t, ts, "PATCH", path, body, &updated); status != http.StatusOK {
+		t.Fatalf("PATCH status = %d, want %d", status, http.StatusOK)
+	}
+	if updated.Version != task.Version+1 {
+		t.Errorf("version = %d, want %d", updated.Version, task.Version+1)
+	}
+
+	var errResp api.ErrorResponse
+	body = map[string]interface{}{"title": "Second", "version": task.Version}
+	if status := do(t, ts, "PATCH", path, body, &errResp); status != http.StatusConflict {
+		t.Errorf("PATCH with a stale version status = %d, want %d", status, http.StatusConflict)
+	}
+	if errResp.Error.Code != api.CodeConflict {
+		t.Errorf("error code = %q, want %q", errResp.Error.Code, api.CodeConflict)
+	}
+
+	// Without a version the update applies to whatever is stored
+	if status := do(t, ts, "PATCH", path, map[string]string{"title": "Third"}, &updated); status != http.StatusOK {
+		t.Errorf("PATCH without a version status = %d, want %d", status, http.StatusOK)
+	}
+}
+
+func TestServer_Checklist(t *testing.T) {
+	ts := newTestServer(t)
+
+	var task api.Task
+	body := map[string]interface{}{
+		"title":     "Release",
+		"checklist": []map[string]interface{}{{"text": "Tag"}, {"text": "Build", "done": true}},
+	}
+	if status := do(t, ts, "POST", "/api/tasks", body, &task); status != http.StatusCreated {
+		t.Fatalf("POST status = %d, want %d", status, http.StatusCreated)
+	}
+	want := []api.ChecklistItem{{Text: "Tag"}, {Text: "Build", Done: true}}
+	if !reflect.DeepEqual(task.Checklist, want) {
+		t.Errorf("checklist = %v, want %v", task.Checklist, want)
+	}
+	path := "/api/tasks/" + itoa(task.ID)
+
+	// Other changes keep the checklist; [] clears it
+	var updated api.Task
+	do(t, ts, "PATCH", path, map[string]string{"title": "Release 1.0"}, &updated)
+	if len(updated.Checklist) != 2 {
+		t.Errorf("checklist after a title change = %v, want 2 items", updated.Checklist)
+	}
+	do(t, ts, "PATCH", path, map[string]interface{}{"checklist": []interface{}{}}, &updated)
+	if updated.Checklist == nil || len(updated.Checklist) != 0 {
+		t.Errorf("checklist after clearing = %#v, want []", updated.Checklist)
+	}
+
+	var errResp api.ErrorResponse
+	body = map[string]interface{}{"checklist": []map[string]string{{"text": ""}}}
+	if status := do(t, ts, "PATCH", path, body, &errResp); status != http.StatusBadRequest {
+		t.Errorf("PATCH with an empty item status = %d, want %d", status, http.StatusBadRequest)
+	}
+	if errResp.Error.Field != "checklist" {
+		t.Errorf("error field = %q, want checklist", errResp.Error.Field)
+	}
+}
+
+func TestServer_Attachments(t *testing.T) {
+	ts := newTestServer(t)
+
+	var task api.Task
+	body := map[string]interface{}{
+		"title":       "Review spec",
+		"attachments": []map[string]string{{"location": "/home/me/spec.pdf"}, {"location": "https://example.com/spec"}},
+	}
+	if status := do(t, ts, "POST", "/api/tasks", body, &task); status != http.StatusCreated {
+		t.Fatalf("POST status = %d, want %d", status, http.StatusCreated)
+	}
+	if len(task.Attachments) != 2 || task.Attachments[1].Location != "https://example.com/spec" || task.Attachments[0].AddedAt.IsZero() {
+		t.Errorf("attachments = %+v, want both, stamped", task.Attachments)
+	}
+	path := "/api/tasks/" + itoa(task.ID)
+
+	var updated api.Task
+	do(t, ts, "PATCH", path, map[string]interface{}{"attachments": []interface{}{}}, &updated)
+	if updated.Attachments == nil || len(updated.Attachments) != 0 {
+		t.Errorf("attachments after clearing = %#v, want []", updated.Attachments)
+	}
+
+	var errResp api.ErrorResponse
+	body = map[string]interface{}{"attachments": []map[string]string{{"location": "spec.pdf"}}}
+	if status := do(t, ts, "PATCH", path, body, &errResp); status != http.StatusBadRequest {
+		t.Errorf("PATCH with a relative path status = %d, want %d", status, http.StatusBadRequest)
+	}
+	if errResp.Error.Field != "attachments" {
+		t.Errorf("error field = %q, want attachments", errResp.Error.Field)
+	}
+}
+
+func TestServer_Notes(t *testing.T) {
+	ts := newTestServer(t)
+
+	var task api.Task
+	do(t, ts, "POST", "/api/tasks", map[string]string{"title": "Order parts"}, &task)
+	path := "/api/tasks/" + itoa(task.ID) + "/notes"
+
+	var note api.Note
+	if status := do(t, ts, "POST", path, map[string]string{"text": "Waiting on vendor reply"}, &note); status != http.StatusCreated {
+		t.Fatalf("POST status = %d, want %d", status, http.StatusCreated)
+	}
+	if note.ID == 0 || note.Text != "Waiting on vendor reply" || note.CreatedAt.IsZero() {
+		t.Errorf("note = %+v, want an ID, the text and a timestamp", note)
+	}
+	do(t, ts, "POST", path, map[string]string{"text": "Parts shipped"}, nil)
+
+	var notes []api.Note
+	if status := do(t, ts, "GET", path, nil, &notes); status != http.StatusOK {
+		t.Fatalf("GET status = %d, want %d", status, http.StatusOK)
+	}
+	if len(notes) != 2 || notes[0].ID != note.ID || notes[1].Text != "Parts shipped" {
+		t.Errorf("notes = %+v, want both, oldest first", notes)
+	}
+
+	// Tasks carry their notes, and search finds them
+	var got api.Task
+	do(t, ts, "GET", "/api/tasks/"+itoa(task.ID), nil, &got)
+	if len(got.Notes) != 2 || got.Version != task.Version {
+		t.Errorf("task notes = %+v, version %d; want 2 notes, version %d", got.Notes, got.Version, task.Version)
+	}
+	var found []api.Task
+	do(t, ts, "GET", "/api/tasks?q=vendor", nil, &found)
+	if len(found) != 1 || found[0].ID != task.ID {
+		t.Errorf("search for a note = %+v, want the task", found)
+	}
+
+	var errResp api.ErrorResponse
+	if status := do(t, ts, "POST", path, map[string]string{"text": ""}, &errResp); status != http.StatusBadRequest {
+		t.Errorf("POST empty note status = %d, want %d", status, http.StatusBadRequest)
+	}
+	if errResp.Error.Field != "text" {
+		t.Errorf("error field = %q, want text", errResp.Error.Field)
+	}
+	if status := do(t, ts, "GET", "/api/tasks/999/notes", nil, nil); status != http.StatusNotFound {
+		t.Errorf("GET notes of a missing task status = %d, want %d", status, http.StatusNotFound)
+	}
+}
+
+func TestServer_DeleteCategory(t *testing.T) {
+	ts := newTestServer(t)
+
+	var category api.Category
+	do(t, ts, "POST", "/api/categories", map[string]string{"name": "Errands", "color": "green"}, &category)
+	var task api.Task
+	do(t, ts, "POST", "/api/tasks", map[string]interface{}{"title": "Buy milk", "category_id": category.ID}, &task)
+
+	path := "/api/categories/" + itoa(category.ID)
+	if status := do(t, ts, "DELETE", path, nil, nil); status != http.StatusNoContent {
+		t.Fatalf("DELETE status = %d, want %d", status, http.StatusNoContent)
+	}
+	if status := do(t, ts, "DELETE", path, nil, nil); status != http.StatusNotFound {
+		t.Errorf("second DELETE status = %d, want %d", status, http.StatusNotFound)
+	}
+
+	do(t, ts, "GET", "/api/tasks/"+itoa(task.ID), nil, &task)
+	if task.CategoryID != nil {
+		t.Errorf("task category_id = %v after its category was deleted, want none", *task.CategoryID)
+	}
+
+	var errResp api.ErrorResponse
+	body := map[string]interface{}{"title": "Orphan", "category_id": category.ID}
+	if status := do(t, ts, "POST", "/api/tasks", body, &errResp); status != http.StatusBadRequest {
+		t.Errorf("POST with a deleted category status = %d, want %d", status, http.StatusBadRequest)
+	}
+	if errResp.Error.Field != "category_id" {
+		t.Errorf("error field = %q, want category_id", errResp.Error.Field)
+	}
+}
+
+func TestServer_StatusTransitions(t *testing.T) {
+	ts := newTestServer(t)
+
+	var task api.Task
+	do(t, ts, "POST", "/api/tasks", map[string]string{"title": "Deploy"}, &task)
+	path := "/api/tasks/" + itoa(task.ID)
+
+	if status := do(t, ts, "POST", path+"/advance", nil, &task); status != http.StatusOK {
+		t.Fatalf("advance status = %d, want %d", status, http.StatusOK)
+	}
+	if task.Status != "working" || task.StartedAt == nil {
+		t.Errorf("after advance: status = %v, started_at = %v", task.Status, task.StartedAt)
+	}
+
+	if status := do(t, ts, "POST", path+"/status", map[string]string{"status": "completed"}, &task); status != http.StatusOK {
+		t.Fatalf("set status = %d, want %d", status, http.StatusOK)
+	}
+	if task.Status != "completed" || task.CompletedAt == nil {
+		t.Errorf("after complete: status = %v, completed_at = %v", task.Status, task.CompletedAt)
+	}
+
+	if status := do(t, ts, "POST", path+"/status", map[string]string{"status": "new"}, &task); status != http.StatusOK {
+		t.Fatalf("reopen status = %d, want %d", status, http.StatusOK)
+	}
+	if task.StartedAt != nil || task.CompletedAt != nil {
+		t.Errorf("after reopen: started_at = %v, completed_at = %v, want both nil", task.StartedAt, task.CompletedAt)
+	}
+
+	var errResp api.ErrorResponse
+	if status := do(t, ts, "POST", path+"/status", map[string]string{"status": "done"}, &errResp); status != http.StatusBadRequest {
+		t.Errorf("invalid status = %d, want %d", status, http.StatusBadRequest)
+	}
+}
+
+func TestServer_ListFilterAndSort(t *testing.T) {
+	ts := newTestServer(t)
+
+	var work api.Category
+	if status := do(t, ts, "POST", "/api/categories", map[string]string{"name": "Work", "color": "blue"}, &work); status != http.StatusCreated {
+		t.Fatalf("POST /api/categories status = %d, want %d", status, http.StatusCreated)
+	}
+
+	inputs := []map[string]interface{}{
+		{"title": "Alpha", "priority": "low", "due_date": "2026-10-14", "category_id": work.ID},
+		{"title": "Bravo", "priority": "high", "due_date": "2026-10-20"},
+		{"title": "Charlie", "priority": "medium", "status": "working"},
+		{"title": "Delta", "priority": "low", "archived": true},
+	}
+	for _, in := range inputs {
+		if status := do(t, ts, "POST", "/api/tasks", in, nil); status != http.StatusCreated {
+			t.Fatalf("POST %v status = %d", in, status)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"all by title", "?sort=title&order=asc", []string{"Alpha", "Bravo", "Charlie"}},
+		{"by priority", "?sort=priority&order=desc", []string{"Bravo", "Charlie", "Alpha"}},
+		{"status", "?status=working", []string{"Charlie"}},
+		{"comma separated priorities", "?priority=low,high&sort=title&order=asc", []string{"Alpha", "Bravo"}},
+		{"category", "?category=" + itoa(work.ID), []string{"Alpha"}},
+		{"due today", "?range=today", []string{"Alpha"}},
+		{"no due date", "?range=no_date", []string{"Charlie"}},
+		{"custom range", "?range=custom&from=2026-10-15&to=2026-10-31", []string{"Bravo"}},
+		{"search", "?q=char", []string{"Charlie"}},
+		{"no match", "?status=completed", []string{}},
+		{"archived included", "?archived=include&sort=title&order=asc", []string{"Alpha", "Bravo", "Charlie", "Delta"}},
+		{"archived only", "?archived=only", []string{"Delta"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tasks []api.Task
+			if status := do(t, ts, "GET", "/api/tasks"+tt.query, nil, &tasks); status != http.StatusOK {
+				t.Fatalf("status = %d, want %d", status, http.StatusOK)
+			}
+			got := make([]string, 0, len(tasks))
+			for _, task := range tasks {
+				got = append(got, task.Title)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("titles = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("titles = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+
+	// Unarchiving lists the task again
+	var archived []api.Task
+	do(t, ts, "GET", "/api/tasks?archived=only", nil, &archived)
+	if len(archived) != 1 {
+		t.Fatalf("archived tasks = %+v, want one", archived)
+	}
+	var restored api.Task
+	do(t, ts, "PATCH", "/api/tasks/"+itoa(archived[0].ID), map[string]interface{}{"archived": false}, &restored)
+	if restored.ArchivedAt != nil {
+		t.Errorf("archived_at after unarchiving = %v, want null", restored.ArchivedAt)
+	}
+	var tasks []api.Task
+	do(t, ts, "GET", "/api/tasks", nil, &tasks)
+	if len(tasks) != 4 {
+		t.Errorf("listed %d tasks after unarchiving, want 4", len(tasks))
+	}
+
+	var categories []api.Category
+	do(t, ts, "GET", "/api/categories", nil, &categories)
+	found := false
+	for _, c := range categories {
+		if c.ID == work.ID && c.Name == "Work" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("categories = %+v, want to include %+v", categories, work)
+	}
+}
+
+func itoa(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
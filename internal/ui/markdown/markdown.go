@@ -0,0 +1,283 @@
+// Package markdown renders the Markdown used in task descriptions for the
+// terminal: headings, paragraphs, bullet and numbered lists with task
+// checkboxes, block quotes, fenced code blocks, rules, and bold, italic,
+// code and link spans. Line breaks are kept as written, as in GitHub
+// comments, rather than joined into paragraphs.
+package markdown
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/hitsumabushi845/task-management/internal/ui/styles"
+)
+
+var (
+	headingPattern = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	listPattern    = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+(.*)$`)
+	checkPattern   = regexp.MustCompile(`^\[([ xX])\]\s+(.*)$`)
+	rulePattern    = regexp.MustCompile(`^\s*(-\s*){3,}$|^\s*(\*\s*){3,}$|^\s*(_\s*){3,}$`)
+	fencePattern   = regexp.MustCompile("^\\s*(```|~~~)")
+	linkPattern    = regexp.MustCompile(`^\[([^\]]+)\]\(([^)\s]+)\)`)
+)
+
+// Render formats src to lines at most width columns wide, joined by "\n"
+func Render(src string, width int) string {
+	if width < 10 {
+		width = 10
+	}
+	r := renderer{width: width}
+	var fence string // The open code fence, "" outside code blocks
+	for _, line := range strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n") {
+		if fence != "" {
+			if strings.HasPrefix(strings.TrimSpace(line), fence) {
+				fence = ""
+				continue
+			}
+			r.code(line)
+			continue
+		}
+		if m := fencePattern.FindStringSubmatch(line); m != nil {
+			fence = m[1]
+			continue
+		}
+		r.line(line)
+	}
+	return strings.Join(r.trimmed(), "\n")
+}
+
+// renderer collects the output lines
+type renderer struct {
+	width int
+	out   []string
+}
+
+// line renders one line outside code blocks
+func (r *renderer) line(line string) {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" {
+		// Collapse runs of blank lines
+		if len(r.out) > 0 && r.out[len(r.out)-1] != "" {
+			r.out = append(r.out, "")
+		}
+		return
+	}
+
+	if m := headingPattern.FindStringSubmatch(trimmed); m != nil {
+		style := styles.MarkdownHeading
+		if len(m[1]) == 1 {
+			style = style.Underline(true)
+		}
+		r.wrap(parseInline(m[2], style), "", "")
+		return
+	}
+
+	if rulePattern.MatchString(line) {
+		r.out = append(r.out, styles.MarkdownRule.Render(strings.Repeat("─", r.width)))
+		return
+	}
+
+	if m := listPattern.FindStringSubmatch(line); m != nil {
+		indent := strings.Repeat(" ", utf8.RuneCountInString(strings.ReplaceAll(m[1], "\t", "  ")))
+		marker := "• "
+		if m[2][0] >= '0' && m[2][0] <= '9' {
+			marker = m[2] + " "
+		}
+		text, base := m[3], styles.Normal
+		if c := checkPattern.FindStringSubmatch(text); c != nil {
+			marker = "☐ "
+			if c[1] != " " {
+				marker = "☑ "
+				base = styles.MarkdownDone
+			}
+			text = c[2]
+		}
+		r.wrap(parseInline(text, base), indent+marker, indent+strings.Repeat(" ", utf8.RuneCountInString(marker)))
+		return
+	}
+
+	if strings.HasPrefix(trimmed, ">") {
+		text := strings.TrimSpace(strings.TrimPrefix(trimmed, ">"))
+		bar := styles.MarkdownQuote.Render("│") + " "
+		r.wrap(parseInline(text, styles.MarkdownQuote), bar, bar)
+		return
+	}
+
+	r.wrap(parseInline(trimmed, styles.Normal), "", "")
+}
+
+// code renders a line of a code block as is, cutting it at the width
+func (r *renderer) code(line string) {
+	line = "  " + strings.ReplaceAll(line, "\t", "    ")
+	if utf8.RuneCountInString(line) > r.width {
+		line = string([]rune(line)[:r.width-1]) + "…"
+	}
+	r.out = append(r.out, styles.MarkdownCode.Render(line))
+}
+
+// wrap breaks runs into lines at spaces. first prefixes the first line and
+// rest the others; both are counted in the width by their visible length.
+func (r *renderer) wrap(runs []run, first, rest string) {
+	words := splitWords(runs)
+	prefix := first
+	var line []run
+	lineWidth := 0
+	flush := func() {
+		r.out = append(r.out, prefix+renderRuns(line))
+		prefix = rest
+		line = nil
+		lineWidth = 0
+	}
+
+	for _, word := range words {
+		available := r.width - lipgloss.Width(prefix)
+		w := word.width()
+		if lineWidth > 0 && lineWidth+1+w > available {
+			flush()
+			available = r.width - lipgloss.Width(prefix)
+		}
+		// Split words longer than a line
+		for w > available && available > 0 {
+			head, tail := word.split(available)
+			line = append(line, head...)
+			flush()
+			word, w = tail, tail.width()
+			available = r.width - lipgloss.Width(prefix)
+		}
+		if lineWidth > 0 {
+			line = append(line, run{text: " ", style: word[0].style})
+			lineWidth++
+		}
+		line = append(line, word...)
+		lineWidth += w
+	}
+	flush()
+}
+
+// trimmed drops a trailing blank line
+func (r *renderer) trimmed() []string {
+	out := r.out
+	for len(out) > 0 && out[len(out)-1] == "" {
+		out = out[:len(out)-1]
+	}
+	return out
+}
+
+// run is text in a single style
+type run struct {
+	text  string
+	style lipgloss.Style
+}
+
+// parseInline splits text into runs for **bold**, *italic*, `code` and
+// [links](url). A delimiter without a closing match is kept as text.
+func parseInline(text string, base lipgloss.Style) []run {
+	var runs []run
+	plain := ""
+	emit := func(r ...run) {
+		if plain != "" {
+			runs = append(runs, run{text: plain, style: base})
+			plain = ""
+		}
+		runs = append(runs, r...)
+	}
+
+	for i := 0; i < len(text); {
+		rest := text[i:]
+		switch {
+		case rest[0] == '`':
+			if end := strings.IndexByte(rest[1:], '`'); end > 0 {
+				emit(run{text: rest[1 : end+1], style: styles.MarkdownCode.Inherit(base)})
+				i += end + 2
+				continue
+			}
+		case strings.HasPrefix(rest, "**") || strings.HasPrefix(rest, "__"):
+			if end := strings.Index(rest[2:], rest[:2]); end > 0 {
+				emit(parseInline(rest[2:end+2], base.Bold(true))...)
+				i += end + 4
+				continue
+			}
+		case (rest[0] == '*' || (rest[0] == '_' && (i == 0 || text[i-1] == ' '))) && len(rest) > 1 && rest[1] != ' ':
+			if end := strings.IndexByte(rest[1:], rest[0]); end > 0 {
+				emit(parseInline(rest[1:end+1], base.Italic(true))...)
+				i += end + 2
+				continue
+			}
+		case rest[0] == '[':
+			if m := linkPattern.FindStringSubmatch(rest); m != nil {
+				emit(parseInline(m[1], styles.MarkdownLink.Inherit(base))...)
+				emit(run{text: " (" + m[2] + ")", style: styles.MarkdownRule})
+				i += len(m[0])
+				continue
+			}
+		}
+		_, size := utf8.DecodeRuneInString(rest)
+		plain += rest[:size]
+		i += size
+	}
+	emit()
+	return runs
+}
+
+// word is the runs between two spaces
+type word []run
+
+func (w word) width() int {
+	n := 0
+	for _, r := range w {
+		n += utf8.RuneCountInString(r.text)
+	}
+	return n
+}
+
+// split cuts the word after n runes
+func (w word) split(n int) (word, word) {
+	var head, tail word
+	for _, r := range w {
+		runes := []rune(r.text)
+		switch {
+		case n <= 0:
+			tail = append(tail, r)
+		case len(runes) <= n:
+			head = append(head, r)
+			n -= len(runes)
+		default:
+			head = append(head, run{text: string(runes[:n]), style: r.style})
+			tail = append(tail, run{text: string(runes[n:]), style: r.style})
+			n = 0
+		}
+	}
+	return head, tail
+}
+
+// splitWords breaks runs at spaces, dropping the spaces
+func splitWords(runs []run) []word {
+	var words []word
+	var current word
+	for _, r := range runs {
+		parts := strings.Split(r.text, " ")
+		for i, part := range parts {
+			if i > 0 && len(current) > 0 {
+				words = append(words, current)
+				current = nil
+			}
+			if part != "" {
+				current = append(current, run{text: part, style: r.style})
+			}
+		}
+	}
+	if len(current) > 0 {
+		words = append(words, current)
+	}
+	return words
+}
+
+func renderRuns(runs []run) string {
+	var b strings.Builder
+	for _, r := range runs {
+		b.WriteString(r.style.Render(r.text))
+	}
+	return b.String()
+}
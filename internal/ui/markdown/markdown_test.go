@@ -0,0 +1,111 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+// Tests run without a terminal, so lipgloss renders plain text
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name  string
+		src   string
+		width int
+		want  []string
+	}{
+		{
+			name:  "heading and paragraph",
+			src:   "# Plan\nShip the **first** draft",
+			width: 40,
+			want:  []string{"Plan", "Ship the first draft"},
+		},
+		{
+			name:  "line breaks are kept",
+			src:   "one\ntwo",
+			width: 40,
+			want:  []string{"one", "two"},
+		},
+		{
+			name:  "blank lines collapse",
+			src:   "one\n\n\n\ntwo\n\n",
+			width: 40,
+			want:  []string{"one", "", "two"},
+		},
+		{
+			name:  "wraps at spaces",
+			src:   "the quick brown fox jumps",
+			width: 10,
+			want:  []string{"the quick", "brown fox", "jumps"},
+		},
+		{
+			name:  "splits words longer than a line",
+			src:   "abcdefghijklmnop",
+			width: 10,
+			want:  []string{"abcdefghij", "klmnop"},
+		},
+		{
+			name:  "bullet list with hanging indent",
+			src:   "- first item wraps here\n* second",
+			width: 14,
+			want:  []string{"• first item", "  wraps here", "• second"},
+		},
+		{
+			name:  "nested and numbered lists",
+			src:   "1. top\n  - nested",
+			width: 40,
+			want:  []string{"1. top", "  • nested"},
+		},
+		{
+			name:  "checkboxes",
+			src:   "- [ ] todo\n- [x] done",
+			width: 40,
+			want:  []string{"☐ todo", "☑ done"},
+		},
+		{
+			name:  "code block is not wrapped or parsed",
+			src:   "```go\nfmt.Println(\"**hi**\")\n```\nafter",
+			width: 40,
+			want:  []string{`  fmt.Println("**hi**")`, "after"},
+		},
+		{
+			name:  "long code lines are cut",
+			src:   "~~~\n0123456789abcdef\n~~~",
+			width: 12,
+			want:  []string{"  012345678…"},
+		},
+		{
+			name:  "quote",
+			src:   "> waiting on vendor",
+			width: 40,
+			want:  []string{"│ waiting on vendor"},
+		},
+		{
+			name:  "rule",
+			src:   "---",
+			width: 10,
+			want:  []string{"──────────"},
+		},
+		{
+			name:  "inline spans",
+			src:   "run `go test` *now*, see [docs](http://x)",
+			width: 60,
+			want:  []string{"run go test now, see docs (http://x)"},
+		},
+		{
+			name:  "unmatched delimiters stay",
+			src:   "5 * 3 and snake_case_name and **open",
+			width: 60,
+			want:  []string{"5 * 3 and snake_case_name and **open"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := strings.Split(Render(tt.src, tt.width), "\n")
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("Render() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
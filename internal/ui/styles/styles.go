@@ -17,6 +17,34 @@ var (
 	Selected = lipgloss.NewStyle().Foreground(lipgloss.Color("170")).Bold(true)
 	Normal   = lipgloss.NewStyle()
 
+	// Calendar date picker
+	CalendarSelected = lipgloss.NewStyle().Foreground(lipgloss.Color("230")).Background(lipgloss.Color("170")).Bold(true)
+	CalendarToday    = lipgloss.NewStyle().Underline(true).Bold(true)
+	CalendarHasTasks = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+
+	// Stale task marker
+	Stale = lipgloss.NewStyle().Foreground(lipgloss.Color("208"))
+
+	// Reminder banner
+	Reminder = lipgloss.NewStyle().Foreground(lipgloss.Color("232")).Background(lipgloss.Color("214")).Bold(true).Padding(0, 1)
+
+	// Marker of tasks selected for a bulk action
+	Marked = lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true)
+
+	// Notice line for rejected changes
+	Notice = lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true)
+
+	// Text cursor in the description editor
+	Cursor = lipgloss.NewStyle().Reverse(true)
+
+	// Markdown in task descriptions
+	MarkdownHeading = lipgloss.NewStyle().Foreground(lipgloss.Color("170")).Bold(true)
+	MarkdownCode    = lipgloss.NewStyle().Foreground(lipgloss.Color("180"))
+	MarkdownQuote   = lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Italic(true)
+	MarkdownLink    = lipgloss.NewStyle().Foreground(lipgloss.Color("33")).Underline(true)
+	MarkdownRule    = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	MarkdownDone    = lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Strikethrough(true)
+
 	// Status bar
 	StatusBar = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("230")).
@@ -0,0 +1,101 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hitsumabushi845/task-management/internal/domain"
+	"github.com/hitsumabushi845/task-management/internal/repository"
+)
+
+// Publisher queues a delivery to every URL for each event. It implements
+// repository.EventSink, so it is installed with repository.WithEvents.
+type Publisher struct {
+	queue Queue
+	urls  []string
+}
+
+// NewPublisher creates a publisher queueing deliveries for urls
+func NewPublisher(queue Queue, urls []string) *Publisher {
+	return &Publisher{queue: queue, urls: urls}
+}
+
+// Publish queues one delivery of e per URL
+func (p *Publisher) Publish(ctx context.Context, e domain.Event) error {
+	payload, err := NewPayload(e)
+	if err != nil {
+		return err
+	}
+	for _, url := range p.urls {
+		d := &repository.WebhookDelivery{URL: url, Event: string(e.Type), Payload: payload}
+		if err := p.queue.EnqueueWebhook(ctx, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Middleware queues the deliveries of each change to urls in the same
+// transaction as the change, so that a change is never stored without its
+// webhooks or the other way round. It must wrap a store whose transactions
+// implement Queue, such as *repository.SQLiteRepository, directly.
+func Middleware(urls []string) repository.Middleware {
+	return func(next domain.TaskRepository) domain.TaskRepository {
+		return &queueingRepository{TaskRepository: next, urls: urls}
+	}
+}
+
+// queueingRepository runs every change in a transaction queueing its
+// deliveries
+type queueingRepository struct {
+	domain.TaskRepository
+	urls []string
+}
+
+func (r *queueingRepository) Create(ctx context.Context, task *domain.Task) error {
+	return r.WithTx(ctx, func(tx domain.TaskRepository) error {
+		return tx.Create(ctx, task)
+	})
+}
+
+func (r *queueingRepository) Restore(ctx context.Context, task *domain.Task) error {
+	return r.WithTx(ctx, func(tx domain.TaskRepository) error {
+		return tx.Restore(ctx, task)
+	})
+}
+
+func (r *queueingRepository) Update(ctx context.Context, task *domain.Task) error {
+	return r.WithTx(ctx, func(tx domain.TaskRepository) error {
+		return tx.Update(ctx, task)
+	})
+}
+
+func (r *queueingRepository) Delete(ctx context.Context, id int64) error {
+	return r.WithTx(ctx, func(tx domain.TaskRepository) error {
+		return tx.Delete(ctx, id)
+	})
+}
+
+// WithTx queues the deliveries of the changes made in fn in the
+// transaction itself. A failure to queue rolls back the whole transaction.
+func (r *queueingRepository) WithTx(ctx context.Context, fn func(domain.TaskRepository) error) error {
+	return r.TaskRepository.WithTx(ctx, func(tx domain.TaskRepository) error {
+		queue, ok := tx.(Queue)
+		if !ok {
+			return fmt.Errorf("webhook: %T does not queue deliveries", tx)
+		}
+		publish := repository.WithEvents(NewPublisher(queue, r.urls))
+		return fn(&queueingTx{TaskRepository: publish(tx)})
+	})
+}
+
+// queueingTx is a transaction publishing each change to the queue as it
+// is made
+type queueingTx struct {
+	domain.TaskRepository
+}
+
+// WithTx runs fn in the transaction already open
+func (tx *queueingTx) WithTx(ctx context.Context, fn func(domain.TaskRepository) error) error {
+	return fn(tx)
+}
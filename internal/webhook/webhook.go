@@ -0,0 +1,248 @@
+// Package webhook sends signed JSON payloads to configured URLs when tasks
+// change. Events are written to a persistent queue by Publisher and sent,
+// with retries, by Dispatcher.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hitsumabushi845/task-management/internal/api"
+	"github.com/hitsumabushi845/task-management/internal/domain"
+	"github.com/hitsumabushi845/task-management/internal/repository"
+)
+
+// Request headers set on every delivery
+const (
+	HeaderEvent     = "X-Task-Event"
+	HeaderDelivery  = "X-Task-Delivery"
+	HeaderSignature = "X-Task-Signature-256" // "sha256=" + hex HMAC of the body
+)
+
+// DefaultMaxAttempts is used when Dispatcher.MaxAttempts is not set
+const DefaultMaxAttempts = 8
+
+// DefaultRetention is used when Dispatcher.Retention is not set
+const DefaultRetention = 7 * 24 * time.Hour
+
+// batchSize is how many deliveries Flush loads at a time
+const batchSize = 50
+
+// pruneInterval is how often Run deletes retired deliveries
+const pruneInterval = time.Hour
+
+// Queue stores deliveries until they succeed or run out of attempts.
+// *repository.SQLiteRepository implements it.
+type Queue interface {
+	EnqueueWebhook(ctx context.Context, d *repository.WebhookDelivery) error
+	PendingWebhooks(ctx context.Context, now time.Time, limit int) ([]*repository.WebhookDelivery, error)
+	MarkWebhookDelivered(ctx context.Context, id int64, attempts int, at time.Time) error
+	MarkWebhookFailed(ctx context.Context, id int64, attempts int, lastError string, next *time.Time) error
+	PruneWebhooks(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Payload is the JSON body of a delivery
+type Payload struct {
+	Event          domain.EventType  `json:"event"`
+	OccurredAt     time.Time         `json:"occurred_at"`
+	Task           api.Task          `json:"task"`
+	PreviousStatus domain.TaskStatus `json:"previous_status,omitempty"`
+}
+
+// NewPayload converts an event to its JSON body
+func NewPayload(e domain.Event) ([]byte, error) {
+	return json.Marshal(Payload{
+		Event:          e.Type,
+		OccurredAt:     e.OccurredAt,
+		Task:           api.FromTask(e.Task),
+		PreviousStatus: e.PreviousStatus,
+	})
+}
+
+// Sign returns the signature header value for body
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature matches body, for use by receivers
+func Verify(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
+
+// Backoff returns the wait before the next attempt after attempts failures:
+// 30s, 1m, 2m, ... capped at one hour
+func Backoff(attempts int) time.Duration {
+	d := 30 * time.Second
+	for i := 1; i < attempts && d < time.Hour; i++ {
+		d *= 2
+	}
+	if d > time.Hour {
+		d = time.Hour
+	}
+	return d
+}
+
+// Dispatcher sends queued deliveries
+type Dispatcher struct {
+	Queue       Queue
+	Client      *http.Client
+	Secret      string // Signs payloads when set
+	MaxAttempts int    // DefaultMaxAttempts if zero
+	// Retention is how long delivered and failed deliveries are kept,
+	// DefaultRetention if zero
+	Retention time.Duration
+
+	// Now and Backoff may be replaced in tests
+	Now     func() time.Time
+	Backoff func(attempts int) time.Duration
+}
+
+// Flush sends every delivery that is due, returning how many succeeded.
+// Failures are rescheduled rather than returned; the error reports queue
+// problems only.
+func (d *Dispatcher) Flush(ctx context.Context) (int, error) {
+	delivered := 0
+	seen := map[int64]bool{}
+	for {
+		pending, err := d.Queue.PendingWebhooks(ctx, d.now(), batchSize)
+		if err != nil {
+			return delivered, err
+		}
+
+		progressed := false
+		for _, delivery := range pending {
+			// A zero backoff makes a failed delivery due again at once;
+			// leave it for the next Flush
+			if seen[delivery.ID] {
+				continue
+			}
+			seen[delivery.ID] = true
+			progressed = true
+
+			ok, err := d.attempt(ctx, delivery)
+			if err != nil {
+				return delivered, err
+			}
+			if ok {
+				delivered++
+			}
+		}
+		if !progressed || len(pending) < batchSize {
+			return delivered, nil
+		}
+	}
+}
+
+// Prune deletes the deliveries retired longer than the retention ago
+func (d *Dispatcher) Prune(ctx context.Context) (int64, error) {
+	retention := d.Retention
+	if retention <= 0 {
+		retention = DefaultRetention
+	}
+	return d.Queue.PruneWebhooks(ctx, d.now().Add(-retention))
+}
+
+// Run flushes the queue every interval, and prunes it every hour, until ctx
+// is done. Errors are logged to logger.
+func (d *Dispatcher) Run(ctx context.Context, interval time.Duration, logger *slog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var pruned time.Time
+	for {
+		if _, err := d.Flush(ctx); err != nil && ctx.Err() == nil {
+			logger.LogAttrs(ctx, slog.LevelError, "webhook flush failed", slog.String("error", err.Error()))
+		}
+		if time.Since(pruned) >= pruneInterval {
+			pruned = time.Now()
+			if _, err := d.Prune(ctx); err != nil && ctx.Err() == nil {
+				logger.LogAttrs(ctx, slog.LevelError, "webhook prune failed", slog.String("error", err.Error()))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// attempt sends one delivery and records the outcome
+func (d *Dispatcher) attempt(ctx context.Context, delivery *repository.WebhookDelivery) (bool, error) {
+	attempts := delivery.Attempts + 1
+	sendErr := d.send(ctx, delivery)
+	if sendErr == nil {
+		return true, d.Queue.MarkWebhookDelivered(ctx, delivery.ID, attempts, d.now())
+	}
+
+	var next *time.Time
+	if attempts < d.maxAttempts() {
+		at := d.now().Add(d.backoff(attempts))
+		next = &at
+	}
+	return false, d.Queue.MarkWebhookFailed(ctx, delivery.ID, attempts, sendErr.Error(), next)
+}
+
+// send posts the payload, treating any non-2xx response as a failure
+func (d *Dispatcher) send(ctx context.Context, delivery *repository.WebhookDelivery) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, delivery.Event)
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(delivery.ID, 10))
+	if d.Secret != "" {
+		req.Header.Set(HeaderSignature, Sign(d.Secret, delivery.Payload))
+	}
+
+	client := d.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+func (d *Dispatcher) now() time.Time {
+	if d.Now != nil {
+		return d.Now()
+	}
+	return time.Now()
+}
+
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	if d.Backoff != nil {
+		return d.Backoff(attempts)
+	}
+	return Backoff(attempts)
+}
+
+func (d *Dispatcher) maxAttempts() int {
+	if d.MaxAttempts > 0 {
+		return d.MaxAttempts
+	}
+	return DefaultMaxAttempts
+}
@@ -0,0 +1,306 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hitsumabushi845/task-management/internal/domain"
+	"github.com/hitsumabushi845/task-management/internal/repository"
+)
+
+const testSecret = "s3cret"
+
+// receiver is a webhook endpoint that records verified payloads and fails
+// the first failures requests
+type receiver struct {
+	mu       sync.Mutex
+	failures int
+	payloads []Payload
+	badSigs  int
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if rc.failures > 0 {
+		rc.failures--
+		http.Error(w, "try again", http.StatusInternalServerError)
+		return
+	}
+
+	body, _ := io.ReadAll(r.Body)
+	if !Verify(testSecret, body, r.Header.Get(HeaderSignature)) {
+		rc.badSigs++
+	}
+	var p Payload
+	if err := json.Unmarshal(body, &p); err == nil && string(p.Event) == r.Header.Get(HeaderEvent) {
+		rc.payloads = append(rc.payloads, p)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func newQueue(t *testing.T) *repository.SQLiteRepository {
+	t.Helper()
+	repo, err := repository.NewSQLiteRepository(":memory:")
+	if err != nil {
+		t.Fatalf("NewSQLiteRepository() error = %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	return repo
+}
+
+func TestPublisher_DeliversTaskEvents(t *testing.T) {
+	rc := &receiver{}
+	ts := httptest.NewServer(rc)
+	defer ts.Close()
+
+	store := newQueue(t)
+	repo := repository.Chain(store, Middleware([]string{ts.URL}))
+	d := &Dispatcher{Queue: store, Client: ts.Client(), Secret: testSecret}
+	ctx := context.Background()
+
+	task := &domain.Task{Title: "Ship it", Status: domain.TaskStatusNew, Priority: domain.PriorityHigh}
+	if err := repo.Create(ctx, task); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	task.Description = "release notes"
+	if err := repo.Update(ctx, task); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	task.SetStatus(domain.TaskStatusCompleted, time.Now())
+	if err := repo.Update(ctx, task); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if err := repo.Delete(ctx, task.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	delivered, err := d.Flush(ctx)
+	if err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if delivered != 5 {
+		t.Errorf("Flush() delivered %d, want 5", delivered)
+	}
+
+	want := []domain.EventType{
+		domain.EventTaskCreated,
+		domain.EventTaskUpdated,
+		domain.EventTaskUpdated,
+		domain.EventTaskStatusChanged,
+		domain.EventTaskDeleted,
+	}
+	if len(rc.payloads) != len(want) {
+		t.Fatalf("received %d payloads, want %d", len(rc.payloads), len(want))
+	}
+	for i, p := range rc.payloads {
+		if p.Event != want[i] {
+			t.Errorf("payload %d event = %s, want %s", i, p.Event, want[i])
+		}
+		if p.Task.ID != task.ID {
+			t.Errorf("payload %d task ID = %d, want %d", i, p.Task.ID, task.ID)
+		}
+	}
+	if got := rc.payloads[3]; got.PreviousStatus != domain.TaskStatusNew || got.Task.Status != domain.TaskStatusCompleted {
+		t.Errorf("status change = %s -> %s, want new -> completed", got.PreviousStatus, got.Task.Status)
+	}
+	if rc.badSigs != 0 {
+		t.Errorf("%d payloads had invalid signatures", rc.badSigs)
+	}
+
+	// Delivered entries are not sent again
+	if delivered, _ := d.Flush(ctx); delivered != 0 {
+		t.Errorf("second Flush() delivered %d, want 0", delivered)
+	}
+}
+
+func TestMiddleware_QueuesInTransaction(t *testing.T) {
+	store := newQueue(t)
+	repo := repository.Chain(store, Middleware([]string{"http://example.invalid/hook"}))
+	ctx := context.Background()
+
+	// A rolled back change leaves nothing queued
+	errAbort := errors.New("abort")
+	err := repo.WithTx(ctx, func(tx domain.TaskRepository) error {
+		task := &domain.Task{Title: "Discarded", Status: domain.TaskStatusNew, Priority: domain.PriorityLow}
+		if err := tx.Create(ctx, task); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("WithTx() error = %v, want %v", err, errAbort)
+	}
+	if pending, err := store.PendingWebhooks(ctx, time.Now(), 10); err != nil || len(pending) != 0 {
+		t.Errorf("PendingWebhooks() = %d, %v after a rollback; want none", len(pending), err)
+	}
+
+	task := &domain.Task{Title: "Kept", Status: domain.TaskStatusNew, Priority: domain.PriorityLow}
+	if err := repo.Create(ctx, task); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if pending, err := store.PendingWebhooks(ctx, time.Now(), 10); err != nil || len(pending) != 1 {
+		t.Errorf("PendingWebhooks() = %d, %v after a create; want 1", len(pending), err)
+	}
+
+	// A store that can't queue fails rather than dropping deliveries
+	memory := repository.Chain(repository.NewMemoryRepository(), Middleware([]string{"http://example.invalid/hook"}))
+	if err := memory.Create(ctx, &domain.Task{Title: "x", Status: domain.TaskStatusNew, Priority: domain.PriorityLow}); err == nil {
+		t.Errorf("Create() on a store without a queue succeeded")
+	}
+}
+
+func TestDispatcher_Prune(t *testing.T) {
+	store := newQueue(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	var ids []int64
+	for range 3 {
+		delivery := &repository.WebhookDelivery{URL: "http://example.invalid/hook", Event: string(domain.EventTaskCreated), Payload: []byte(`{}`)}
+		if err := store.EnqueueWebhook(ctx, delivery); err != nil {
+			t.Fatalf("EnqueueWebhook() error = %v", err)
+		}
+		ids = append(ids, delivery.ID)
+	}
+	// Delivered long ago, given up on recently, still pending
+	if err := store.MarkWebhookDelivered(ctx, ids[0], 1, now.Add(-8*24*time.Hour)); err != nil {
+		t.Fatalf("MarkWebhookDelivered() error = %v", err)
+	}
+	if err := store.MarkWebhookFailed(ctx, ids[1], 8, "gone", nil); err != nil {
+		t.Fatalf("MarkWebhookFailed() error = %v", err)
+	}
+
+	d := &Dispatcher{Queue: store, Now: func() time.Time { return now }}
+	if n, err := d.Prune(ctx); err != nil || n != 1 {
+		t.Fatalf("Prune() = %d, %v; want 1, nil", n, err)
+	}
+	if _, err := store.WebhookDeliveryByID(ctx, ids[0]); err == nil {
+		t.Errorf("old delivery was kept")
+	}
+	for _, id := range ids[1:] {
+		if _, err := store.WebhookDeliveryByID(ctx, id); err != nil {
+			t.Errorf("WebhookDeliveryByID(%d) error = %v, want it kept", id, err)
+		}
+	}
+}
+
+func TestDispatcher_RetriesWithBackoff(t *testing.T) {
+	rc := &receiver{failures: 2}
+	ts := httptest.NewServer(rc)
+	defer ts.Close()
+
+	store := newQueue(t)
+	ctx := context.Background()
+	delivery := &repository.WebhookDelivery{URL: ts.URL, Event: string(domain.EventTaskCreated), Payload: []byte(`{"event":"task.created"}`)}
+	if err := store.EnqueueWebhook(ctx, delivery); err != nil {
+		t.Fatalf("EnqueueWebhook() error = %v", err)
+	}
+
+	clock := time.Now()
+	d := &Dispatcher{Queue: store, Client: ts.Client(), Secret: testSecret, Now: func() time.Time { return clock }}
+
+	// First attempt fails and is rescheduled 30s out
+	if n, err := d.Flush(ctx); err != nil || n != 0 {
+		t.Fatalf("Flush() = %d, %v; want 0, nil", n, err)
+	}
+	if n, _ := d.Flush(ctx); n != 0 {
+		t.Errorf("Flush() before backoff elapsed delivered %d, want 0", n)
+	}
+
+	clock = clock.Add(Backoff(1) + time.Second)
+	if n, _ := d.Flush(ctx); n != 0 {
+		t.Fatalf("second attempt delivered %d, want 0", n)
+	}
+
+	clock = clock.Add(Backoff(2) + time.Second)
+	if n, err := d.Flush(ctx); err != nil || n != 1 {
+		t.Fatalf("third attempt = %d, %v; want 1, nil", n, err)
+	}
+
+	got, err := store.WebhookDeliveryByID(ctx, delivery.ID)
+	if err != nil {
+		t.Fatalf("WebhookDeliveryByID() error = %v", err)
+	}
+	if got.Attempts != 3 || got.DeliveredAt == nil || got.LastError != "" {
+		t.Errorf("delivery = attempts %d, delivered %v, last error %q", got.Attempts, got.DeliveredAt, got.LastError)
+	}
+}
+
+func TestDispatcher_GivesUpAfterMaxAttempts(t *testing.T) {
+	rc := &receiver{failures: 100}
+	ts := httptest.NewServer(rc)
+	defer ts.Close()
+
+	store := newQueue(t)
+	ctx := context.Background()
+	delivery := &repository.WebhookDelivery{URL: ts.URL, Event: string(domain.EventTaskDeleted), Payload: []byte(`{}`)}
+	if err := store.EnqueueWebhook(ctx, delivery); err != nil {
+		t.Fatalf("EnqueueWebhook() error = %v", err)
+	}
+
+	clock := time.Now()
+	d := &Dispatcher{Queue: store, Client: ts.Client(), MaxAttempts: 2, Now: func() time.Time { return clock }}
+	for i := 0; i < 3; i++ {
+		if _, err := d.Flush(ctx); err != nil {
+			t.Fatalf("Flush() error = %v", err)
+		}
+		clock = clock.Add(time.Hour)
+	}
+
+	got, err := store.WebhookDeliveryByID(ctx, delivery.ID)
+	if err != nil {
+		t.Fatalf("WebhookDeliveryByID() error = %v", err)
+	}
+	if got.Attempts != 2 || got.FailedAt == nil {
+		t.Errorf("delivery = attempts %d, failed %v; want 2 attempts and failed", got.Attempts, got.FailedAt)
+	}
+	if !strings.Contains(got.LastError, "500") {
+		t.Errorf("LastError = %q, want the response status", got.LastError)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{20, time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := Backoff(tt.attempts); got != tt.want {
+			t.Errorf("Backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestSignAndVerify(t *testing.T) {
+	body := []byte(`{"event":"task.created"}`)
+	sig := Sign(testSecret, body)
+
+	if !strings.HasPrefix(sig, "sha256=") {
+		t.Errorf("Sign() = %q, want sha256= prefix", sig)
+	}
+	if !Verify(testSecret, body, sig) {
+		t.Errorf("Verify() rejected a valid signature")
+	}
+	if Verify("other", body, sig) {
+		t.Errorf("Verify() accepted a signature made with another secret")
+	}
+	if Verify(testSecret, []byte(`{"event":"task.deleted"}`), sig) {
+		t.Errorf("Verify() accepted a signature for another body")
+	}
+}